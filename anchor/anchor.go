@@ -0,0 +1,140 @@
+// Package anchor keeps ranges of text, such as highlights, attached to the
+// same passage when the text around them is edited. A range is stored as its
+// offsets together with the quoted text and a little context on each side;
+// when the text changes, the quote is searched for and the occurrence whose
+// context and position match best becomes the new range.
+package anchor
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ContextLength is the number of characters of context kept on each side of
+// a quote.
+const ContextLength = 32
+
+// Selector describes a range of text. Start and End are character (rune)
+// offsets, End being exclusive.
+type Selector struct {
+	Start  int
+	End    int
+	Quote  string
+	Prefix string
+	Suffix string
+}
+
+// New creates a selector for the range [start, end) of text.
+func New(text string, start, end int) (Selector, error) {
+	runes := []rune(text)
+	if start < 0 || end > len(runes) || start >= end {
+		return Selector{}, fmt.Errorf("invalid range %d-%d for a text of %d characters", start, end, len(runes))
+	}
+	return selectorAt(runes, start, end), nil
+}
+
+func selectorAt(runes []rune, start, end int) Selector {
+	return Selector{
+		Start:  start,
+		End:    end,
+		Quote:  string(runes[start:end]),
+		Prefix: string(runes[max(0, start-ContextLength):start]),
+		Suffix: string(runes[end:min(len(runes), end+ContextLength)]),
+	}
+}
+
+// Reanchor finds the selector's passage in an edited text and returns a
+// selector for it, with fresh context. It returns false when the passage can
+// no longer be found.
+func Reanchor(text string, s Selector) (Selector, bool) {
+	runes := []rune(text)
+	quote := []rune(s.Quote)
+	if len(quote) == 0 {
+		return Selector{}, false
+	}
+
+	// Unchanged passage with unchanged surroundings.
+	if s.End <= len(runes) && s.End-s.Start == len(quote) && string(runes[s.Start:s.End]) == s.Quote {
+		candidate := selectorAt(runes, s.Start, s.End)
+		if candidate.Prefix == s.Prefix && candidate.Suffix == s.Suffix {
+			return candidate, true
+		}
+	}
+
+	best, bestScore := -1, -1.0
+	for _, start := range occurrences(runes, quote) {
+		score := contextScore(runes, start, start+len(quote), s)
+		if score > bestScore {
+			best, bestScore = start, score
+		}
+	}
+	if best >= 0 {
+		return selectorAt(runes, best, best+len(quote)), true
+	}
+
+	// The quote itself was edited: look for its old surroundings and take
+	// what lies between them, if it is of a similar length.
+	prefix, suffix := []rune(s.Prefix), []rune(s.Suffix)
+	if len(prefix) == 0 || len(suffix) == 0 {
+		return Selector{}, false
+	}
+	for _, p := range occurrences(runes, prefix) {
+		start := p + len(prefix)
+		rest := occurrences(runes[start:], suffix)
+		if len(rest) == 0 {
+			continue
+		}
+		end := start + rest[0]
+		if end > start && end-start <= 2*len(quote) && 2*(end-start) >= len(quote) {
+			return selectorAt(runes, start, end), true
+		}
+	}
+
+	return Selector{}, false
+}
+
+// contextScore rates how well the occurrence [start, end) matches the
+// selector: each matching character of context counts, and being close to
+// the old position breaks ties.
+func contextScore(runes []rune, start, end int, s Selector) float64 {
+	prefix, suffix := []rune(s.Prefix), []rune(s.Suffix)
+
+	matched := 0
+	for i := 1; i <= len(prefix) && start-i >= 0 && runes[start-i] == prefix[len(prefix)-i]; i++ {
+		matched++
+	}
+	for i := 0; i < len(suffix) && end+i < len(runes) && runes[end+i] == suffix[i]; i++ {
+		matched++
+	}
+
+	distance := start - s.Start
+	if distance < 0 {
+		distance = -distance
+	}
+	return float64(matched) + 1/float64(1+distance)
+}
+
+// occurrences returns the rune offsets at which needle occurs in haystack.
+func occurrences(haystack, needle []rune) []int {
+	if len(needle) == 0 {
+		return nil
+	}
+
+	var offsets []int
+	text, search := string(haystack), string(needle)
+	runeOffset, byteOffset := 0, 0
+	for {
+		i := strings.Index(text[byteOffset:], search)
+		if i < 0 {
+			return offsets
+		}
+		runeOffset += len([]rune(text[byteOffset : byteOffset+i]))
+		offsets = append(offsets, runeOffset)
+		// Continue right after the first character of the match, so that
+		// overlapping occurrences are found too.
+		_, size := utf8.DecodeRuneInString(text[byteOffset+i:])
+		byteOffset += i + size
+		runeOffset++
+	}
+}
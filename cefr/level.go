@@ -0,0 +1,72 @@
+package cefr
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Level is a Common European Framework of Reference proficiency level.
+// It is shared by the Ent schemas and the GraphQL layer so that every
+// entity carrying a level (readings, courses, ...) uses the same enum.
+type Level string
+
+const (
+	A1 Level = "A1"
+	A2 Level = "A2"
+	B1 Level = "B1"
+	B2 Level = "B2"
+	C1 Level = "C1"
+	C2 Level = "C2"
+)
+
+// All lists the levels from easiest to hardest.
+var All = []Level{A1, A2, B1, B2, C1, C2}
+
+// Values implements ent's EnumValues interface.
+func (Level) Values() []string {
+	values := make([]string, len(All))
+	for i, l := range All {
+		values[i] = string(l)
+	}
+	return values
+}
+
+// IsValid reports whether the level is one of the known CEFR levels.
+func (l Level) IsValid() bool {
+	return l.Rank() >= 0
+}
+
+// Rank returns the position of the level in All, or -1 if it is unknown.
+func (l Level) Rank() int {
+	for i, known := range All {
+		if known == l {
+			return i
+		}
+	}
+	return -1
+}
+
+// String returns the level as a string.
+func (l Level) String() string {
+	return string(l)
+}
+
+// UnmarshalGQL implements the graphql.Unmarshaler interface.
+func (l *Level) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*l = Level(str)
+	if !l.IsValid() {
+		return fmt.Errorf("%s is not a valid CEFRLevel", str)
+	}
+	return nil
+}
+
+// MarshalGQL implements the graphql.Marshaler interface.
+func (l Level) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(l.String()))
+}
@@ -0,0 +1,263 @@
+// Package dictionary reads bilingual dictionaries in the StarDict, Yomichan
+// and tab-separated formats, and finds the base forms inflected words may be
+// listed under.
+package dictionary
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// Format is a dictionary file format.
+type Format string
+
+const (
+	FormatStarDict Format = "STARDICT"
+	FormatYomichan Format = "YOMICHAN"
+	FormatTSV      Format = "TSV"
+)
+
+// maxArchiveEntrySize caps how much of a single archive entry is read, to
+// protect against zip bombs.
+const maxArchiveEntrySize = 256 << 20
+
+// ErrUnsupportedFormat is returned when a file's format can't be detected or
+// is not supported.
+var ErrUnsupportedFormat = errors.New("unsupported dictionary format")
+
+// Entry is a headword with its definitions.
+type Entry struct {
+	Headword string
+	// Reading is the pronunciation, or the kana reading of a Japanese
+	// headword, when the dictionary gives one.
+	Reading      string
+	PartOfSpeech string
+	Definitions  []string
+	Examples     []string
+}
+
+// Inflection says that Form is an inflected form, or a variant, of Lemma.
+type Inflection struct {
+	Form  string
+	Lemma string
+}
+
+// Data is the content of a dictionary file.
+type Data struct {
+	Title       string
+	Entries     []Entry
+	Inflections []Inflection
+}
+
+// sniffSize is how much of a file DetectFormat looks at to tell text from
+// binary data.
+const sniffSize = 64 << 10
+
+// DetectFormat guesses the format of a dictionary file of the given size:
+// Yomichan dictionaries are zip archives with an index.json, StarDict ones
+// are zip or tar archives with an .ifo file, and anything else that starts
+// with tab-separated text is TSV.
+func DetectFormat(r io.ReaderAt, size int64) (Format, error) {
+	head := make([]byte, min(size, sniffSize))
+	n, err := r.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read dictionary: %w", err)
+	}
+	head = head[:n]
+
+	if isArchive(head) {
+		var format Format
+		err := walkArchive(r, size, func(name string, _ func() ([]byte, error)) error {
+			switch {
+			case path.Base(name) == "index.json":
+				format = FormatYomichan
+			case strings.HasSuffix(name, ".ifo") && format == "":
+				format = FormatStarDict
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if format == "" {
+			return "", ErrUnsupportedFormat
+		}
+		return format, nil
+	}
+	if int64(n) < size {
+		head = trimPartialRune(head)
+	}
+	if utf8.Valid(head) && bytes.ContainsRune(head, '\t') {
+		return FormatTSV, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Parse reads a dictionary file of the given size in the given format.
+// Archives are read entry by entry, so the file itself is never held in
+// memory.
+func Parse(r io.ReaderAt, size int64, format Format) (*Data, error) {
+	switch format {
+	case FormatTSV:
+		return parseTSV(io.NewSectionReader(r, 0, size))
+	case FormatStarDict, FormatYomichan:
+		files, err := readArchive(r, size)
+		if err != nil {
+			return nil, err
+		}
+		if format == FormatStarDict {
+			return parseStarDict(files)
+		}
+		return parseYomichan(files)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+}
+
+func isArchive(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04")) ||
+		bytes.HasPrefix(data, []byte{0x1f, 0x8b}) ||
+		bytes.HasPrefix(data, []byte("BZh")) ||
+		(len(data) > 262 && string(data[257:262]) == "ustar")
+}
+
+// trimPartialRune drops a UTF-8 sequence cut off at the end of data.
+func trimPartialRune(data []byte) []byte {
+	i := len(data) - 1
+	for i > 0 && i > len(data)-utf8.UTFMax && !utf8.RuneStart(data[i]) {
+		i--
+	}
+	if i >= 0 && !utf8.FullRune(data[i:]) {
+		return data[:i]
+	}
+	return data
+}
+
+// readArchive returns the files of an archive by their path in the archive.
+func readArchive(r io.ReaderAt, size int64) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := walkArchive(r, size, func(name string, read func() ([]byte, error)) error {
+		content, err := read()
+		if err != nil {
+			return err
+		}
+		files[name] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// walkArchive calls visit with the path of every file of a zip archive or of
+// a tar archive, which may be compressed with gzip or bzip2, and a function
+// reading the file's content.
+func walkArchive(r io.ReaderAt, size int64, visit func(name string, read func() ([]byte, error)) error) error {
+	var magic [4]byte
+	n, _ := r.ReadAt(magic[:], 0)
+	if bytes.HasPrefix(magic[:n], []byte("PK\x03\x04")) {
+		return walkZip(r, size, visit)
+	}
+
+	var tr io.Reader = io.NewSectionReader(r, 0, size)
+	switch {
+	case bytes.HasPrefix(magic[:n], []byte{0x1f, 0x8b}):
+		gz, err := gzip.NewReader(tr)
+		if err != nil {
+			return fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer gz.Close()
+		tr = gz
+	case bytes.HasPrefix(magic[:n], []byte("BZh")):
+		tr = bzip2.NewReader(tr)
+	}
+
+	archive := tar.NewReader(tr)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := header.Name
+		if err := visit(name, func() ([]byte, error) { return readLimited(archive, name) }); err != nil {
+			return err
+		}
+	}
+}
+
+func walkZip(r io.ReaderAt, size int64, visit func(name string, read func() ([]byte, error)) error) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		err := visit(f.Name, func() ([]byte, error) {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+			}
+			defer rc.Close()
+			return readLimited(rc, f.Name)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readLimited(r io.Reader, name string) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxArchiveEntrySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(content) > maxArchiveEntrySize {
+		return nil, fmt.Errorf("%s is too large", name)
+	}
+	return content, nil
+}
+
+// gunzip decompresses gzip data, such as dictzip files; other data is
+// returned as is.
+func gunzip(data []byte, name string) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		return data, nil
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer gz.Close()
+	return readLimited(gz, name)
+}
+
+// splitLines returns the non-empty lines of text, trimmed.
+func splitLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
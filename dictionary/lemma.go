@@ -0,0 +1,202 @@
+package dictionary
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// suffixRule turns words ending in suffix into candidate base forms by
+// replacing the suffix with each of the replacements.
+type suffixRule struct {
+	suffix       string
+	replacements []string
+}
+
+func rule(suffix string, replacements ...string) suffixRule {
+	return suffixRule{suffix: suffix, replacements: replacements}
+}
+
+// minStemLength is the shortest stem a suffix rule leaves.
+const minStemLength = 2
+
+// suffixRules are the inflectional endings stripped to find base forms, by
+// language. They overgenerate on purpose: a candidate only matters if the
+// dictionary has it, and the candidates are tried in order. Plural endings
+// come before verb endings, so that casas is looked up as casa before casar.
+var suffixRules = map[string][]suffixRule{
+	"en": {
+		rule("'s", ""), rule("’s", ""),
+		rule("ies", "y"), rule("ied", "y"), rule("ier", "y"), rule("iest", "y"),
+		rule("ves", "f", "fe"),
+		rule("ches", "ch"), rule("shes", "sh"), rule("sses", "ss"), rule("xes", "x"), rule("zes", "z"), rule("oes", "o"),
+		rule("ing", "", "e"), rule("ed", "", "e"),
+		rule("est", "", "e"), rule("er", "", "e"),
+		rule("es", "e"), rule("s", ""),
+		rule("ily", "y"), rule("ly", "", "le"),
+	},
+	"es": {
+		rule("s", ""), rule("es", ""), rule("ces", "z"),
+		rule("ando", "ar"), rule("iendo", "er", "ir"), rule("yendo", "er", "ir"),
+		rule("ado", "ar"), rule("ada", "ar"), rule("ados", "ar"), rule("adas", "ar"),
+		rule("ido", "er", "ir"), rule("ida", "er", "ir"), rule("idos", "er", "ir"), rule("idas", "er", "ir"),
+		rule("aba", "ar"), rule("abas", "ar"), rule("ábamos", "ar"), rule("aban", "ar"),
+		rule("ía", "er", "ir"), rule("ías", "er", "ir"), rule("íamos", "er", "ir"), rule("ían", "er", "ir"),
+		rule("aste", "ar"), rule("asteis", "ar"), rule("aron", "ar"), rule("ó", "ar"), rule("é", "ar"),
+		rule("iste", "er", "ir"), rule("isteis", "er", "ir"), rule("ieron", "er", "ir"), rule("ió", "er", "ir"), rule("í", "er", "ir"),
+		rule("amos", "ar"), rule("áis", "ar"), rule("an", "ar"), rule("as", "o", "ar"),
+		rule("emos", "er"), rule("éis", "er"), rule("en", "er", "ir"), rule("es", "er", "ir"),
+		rule("imos", "ir"), rule("ís", "ir"),
+		rule("a", "o", "ar"), rule("e", "er", "ir"), rule("o", "ar", "er", "ir"),
+	},
+	"pt": {
+		rule("s", ""), rule("es", ""), rule("ões", "ão"), rule("ães", "ão"), rule("ns", "m"), rule("is", "l"),
+		rule("ando", "ar"), rule("endo", "er"), rule("indo", "ir"),
+		rule("ado", "ar"), rule("ada", "ar"), rule("ados", "ar"), rule("adas", "ar"),
+		rule("ido", "er", "ir"), rule("ida", "er", "ir"), rule("idos", "er", "ir"), rule("idas", "er", "ir"),
+		rule("ava", "ar"), rule("avam", "ar"), rule("ia", "er", "ir"), rule("iam", "er", "ir"),
+		rule("ou", "ar"), rule("eu", "er"), rule("iu", "ir"), rule("aram", "ar"), rule("eram", "er"), rule("iram", "ir"),
+		rule("amos", "ar"), rule("emos", "er"), rule("imos", "ir"),
+		rule("am", "ar"), rule("em", "er", "ir"), rule("as", "o", "ar"), rule("es", "er", "ir"),
+		rule("a", "o", "ar"), rule("e", "er", "ir"), rule("o", "ar", "er", "ir"),
+	},
+	"fr": {
+		rule("aux", "al"), rule("eaux", "eau"), rule("s", ""), rule("x", ""),
+		rule("euses", "eur"), rule("euse", "eur"), rule("ives", "if"), rule("ive", "if"),
+		rule("ées", "er"), rule("és", "er"), rule("ée", "er"), rule("é", "er"),
+		rule("ant", "er", "re", "ir"),
+		rule("issons", "ir"), rule("issez", "ir"), rule("issent", "ir"), rule("issait", "ir"),
+		rule("aient", "er", "re", "ir"), rule("ais", "er", "re", "ir"), rule("ait", "er", "re", "ir"),
+		rule("ions", "er", "re", "ir"), rule("iez", "er", "re", "ir"),
+		rule("erai", "er"), rule("eras", "er"), rule("era", "er"), rule("erons", "er"), rule("erez", "er"), rule("eront", "er"),
+		rule("irai", "ir"), rule("iras", "ir"), rule("ira", "ir"), rule("irons", "ir"), rule("irez", "ir"), rule("iront", "ir"),
+		rule("ons", "er", "re"), rule("ez", "er", "re"), rule("ent", "er", "re", ""),
+		rule("ies", "ir"), rule("ie", "ir"), rule("is", "ir", "re"), rule("it", "ir", "re"),
+		rule("es", "er", "e"), rule("e", "er", ""),
+	},
+	"it": {
+		rule("ando", "are"), rule("endo", "ere", "ire"),
+		rule("ato", "are"), rule("ata", "are"), rule("ati", "are"), rule("ate", "are"),
+		rule("uto", "ere"), rule("uta", "ere"), rule("uti", "ere"), rule("ute", "ere"),
+		rule("ito", "ire"), rule("ita", "ire"), rule("iti", "ire"), rule("ite", "ire"),
+		rule("iamo", "are", "ere", "ire"), rule("ete", "ere"),
+		rule("ano", "are"), rule("ono", "ere", "ire"),
+		rule("chi", "co"), rule("ghi", "go"), rule("che", "ca"), rule("ghe", "ga"),
+		rule("i", "o", "e", "are", "ere", "ire"), rule("e", "a", "ere"), rule("a", "are"), rule("o", "are", "ere", "ire"),
+	},
+	"de": {
+		rule("test", "en"), rule("tet", "en"), rule("ten", "en"), rule("te", "en"),
+		rule("est", "en", ""), rule("st", "en"), rule("et", "en"),
+		rule("ern", "er", ""), rule("en", "", "e"), rule("em", "", "e"), rule("er", "", "e"), rule("es", "", "e"),
+		rule("e", "", "en"), rule("n", ""), rule("s", ""), rule("t", "en"),
+	},
+}
+
+// irregulars map common irregular forms to their lemmas, by language.
+var irregulars = map[string]map[string]string{
+	"en": {
+		"am": "be", "is": "be", "are": "be", "was": "be", "were": "be", "been": "be", "being": "be",
+		"has": "have", "had": "have", "does": "do", "did": "do", "done": "do",
+		"went": "go", "gone": "go", "better": "good", "best": "good", "worse": "bad", "worst": "bad",
+		"children": "child", "men": "man", "women": "woman", "feet": "foot", "teeth": "tooth", "mice": "mouse", "people": "person",
+		"saw": "see", "seen": "see", "took": "take", "taken": "take", "came": "come", "made": "make", "said": "say",
+		"got": "get", "gotten": "get", "knew": "know", "known": "know", "thought": "think", "found": "find",
+		"gave": "give", "given": "give", "told": "tell", "became": "become", "left": "leave", "felt": "feel",
+		"brought": "bring", "began": "begin", "begun": "begin", "kept": "keep", "held": "hold", "wrote": "write",
+		"written": "write", "stood": "stand", "heard": "hear", "meant": "mean", "met": "meet", "ran": "run",
+		"paid": "pay", "sat": "sit", "spoke": "speak", "spoken": "speak", "led": "lead", "grew": "grow",
+		"grown": "grow", "lost": "lose", "fell": "fall", "fallen": "fall", "sent": "send", "built": "build",
+		"understood": "understand", "drew": "draw", "drawn": "draw", "broke": "break", "broken": "break",
+		"spent": "spend", "rose": "rise", "risen": "rise", "drove": "drive", "driven": "drive", "bought": "buy",
+		"wore": "wear", "worn": "wear", "chose": "choose", "chosen": "choose", "ate": "eat", "eaten": "eat",
+		"taught": "teach", "caught": "catch", "fought": "fight", "sold": "sell", "slept": "sleep", "won": "win",
+	},
+	"es": {
+		"soy": "ser", "eres": "ser", "es": "ser", "somos": "ser", "son": "ser", "era": "ser", "fue": "ser", "fui": "ser", "sido": "ser",
+		"estoy": "estar", "estás": "estar", "está": "estar", "están": "estar", "estuvo": "estar",
+		"voy": "ir", "vas": "ir", "va": "ir", "vamos": "ir", "van": "ir", "iba": "ir", "fueron": "ir",
+		"tengo": "tener", "tiene": "tener", "tienen": "tener", "tuvo": "tener", "hay": "haber", "he": "haber", "ha": "haber", "han": "haber",
+		"hago": "hacer", "hizo": "hacer", "hecho": "hacer", "digo": "decir", "dice": "decir", "dijo": "decir", "dicho": "decir",
+		"puedo": "poder", "puede": "poder", "pudo": "poder", "quiero": "querer", "quiere": "querer", "sé": "saber", "supo": "saber",
+	},
+	"fr": {
+		"suis": "être", "es": "être", "est": "être", "sommes": "être", "êtes": "être", "sont": "être", "été": "être", "était": "être",
+		"ai": "avoir", "as": "avoir", "a": "avoir", "avons": "avoir", "avez": "avoir", "ont": "avoir", "eu": "avoir", "avait": "avoir",
+		"vais": "aller", "vas": "aller", "va": "aller", "vont": "aller", "allé": "aller", "fait": "faire", "fais": "faire", "font": "faire",
+		"dit": "dire", "peux": "pouvoir", "peut": "pouvoir", "pu": "pouvoir", "veux": "vouloir", "veut": "vouloir", "sais": "savoir", "su": "savoir",
+		"yeux": "œil",
+	},
+	"de": {
+		"bin": "sein", "bist": "sein", "ist": "sein", "sind": "sein", "seid": "sein", "war": "sein", "waren": "sein", "gewesen": "sein",
+		"habe": "haben", "hast": "haben", "hat": "haben", "hatte": "haben", "gehabt": "haben",
+		"wird": "werden", "wirst": "werden", "wurde": "werden", "geworden": "werden",
+		"ging": "gehen", "gegangen": "gehen", "kam": "kommen", "gekommen": "kommen", "sah": "sehen", "gesehen": "sehen",
+		"kann": "können", "konnte": "können", "muss": "müssen", "musste": "müssen", "will": "wollen", "wollte": "wollen",
+	},
+	"it": {
+		"sono": "essere", "sei": "essere", "è": "essere", "siamo": "essere", "siete": "essere", "era": "essere", "stato": "essere",
+		"ho": "avere", "hai": "avere", "ha": "avere", "abbiamo": "avere", "avete": "avere", "hanno": "avere",
+		"vado": "andare", "vai": "andare", "va": "andare", "vanno": "andare", "faccio": "fare", "fa": "fare", "fatto": "fare",
+	},
+	"pt": {
+		"sou": "ser", "é": "ser", "somos": "ser", "são": "ser", "era": "ser", "foi": "ser", "fui": "ser",
+		"estou": "estar", "está": "estar", "estão": "estar", "tenho": "ter", "tem": "ter", "têm": "ter", "teve": "ter",
+		"vou": "ir", "vai": "ir", "vamos": "ir", "vão": "ir", "faço": "fazer", "fez": "fazer", "feito": "fazer",
+	},
+}
+
+// Lemmas returns the forms a lowercased word may be listed under in a
+// dictionary of the given language, most likely first, not including the
+// word itself: irregular lemmas, forms found by stripping inflectional
+// endings and, for German, participles without their ge- prefix.
+func Lemmas(word, language string) []string {
+	language = strings.ToLower(language)
+	if base, _, ok := strings.Cut(language, "-"); ok {
+		language = base
+	}
+
+	var lemmas []string
+	seen := map[string]bool{word: true}
+	add := func(lemma string) {
+		if !seen[lemma] && utf8.RuneCountInString(lemma) >= minStemLength {
+			seen[lemma] = true
+			lemmas = append(lemmas, lemma)
+		}
+	}
+
+	if lemma, ok := irregulars[language][word]; ok {
+		add(lemma)
+	}
+	if language == "de" && strings.HasPrefix(word, "ge") {
+		// gemacht → machen, gefahren → fahren
+		for _, suffix := range []string{"t", "et", "en"} {
+			if stem, ok := strings.CutSuffix(word[2:], suffix); ok && utf8.RuneCountInString(stem) >= minStemLength {
+				add(stem + "en")
+			}
+		}
+	}
+
+	for _, r := range suffixRules[language] {
+		stem, ok := strings.CutSuffix(word, r.suffix)
+		if !ok || utf8.RuneCountInString(stem) < minStemLength {
+			continue
+		}
+		for _, replacement := range r.replacements {
+			add(stem + replacement)
+		}
+		// English doubles a final consonant before -ing, -ed, -er and
+		// -est: running → run, bigger → big.
+		if language == "en" && r.replacements[0] == "" && hasDoubledConsonant(stem) {
+			add(stem[:len(stem)-1])
+		}
+	}
+
+	return lemmas
+}
+
+func hasDoubledConsonant(s string) bool {
+	n := len(s)
+	if n < 3 || s[n-1] != s[n-2] {
+		return false
+	}
+	return !strings.ContainsRune("aeiouylsfz", rune(s[n-1]))
+}
@@ -0,0 +1,234 @@
+package dictionary
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"LinganoGO/importer"
+
+	nethtml "golang.org/x/net/html"
+)
+
+var (
+	markupTag = regexp.MustCompile(`<[^>]*>`)
+	// XDXF elements holding an example, a part of speech and a transcription.
+	xdxfExample      = regexp.MustCompile(`(?s)<ex[^>]*>(.*?)</ex>`)
+	xdxfPartOfSpeech = regexp.MustCompile(`(?s)<(?:pos|gr)[^>]*>(.*?)</(?:pos|gr)>`)
+	xdxfTranscript   = regexp.MustCompile(`(?s)<tr[^>]*>(.*?)</tr>`)
+	xdxfKey          = regexp.MustCompile(`(?s)<k[^>]*>.*?</k>`)
+	xdxfBlock        = regexp.MustCompile(`<(?:br|/?def|/?blockquote|/?div|/?p)\b[^>]*>`)
+)
+
+// parseStarDict reads a StarDict dictionary: an .ifo file describing it, an
+// .idx index of headwords and a .dict file, optionally dictzipped, holding
+// the articles. An .syn file, if present, lists variant forms.
+func parseStarDict(files map[string][]byte) (*Data, error) {
+	find := func(suffixes ...string) ([]byte, string) {
+		for _, suffix := range suffixes {
+			for name, content := range files {
+				if strings.HasSuffix(name, suffix) {
+					return content, name
+				}
+			}
+		}
+		return nil, ""
+	}
+
+	ifo, _ := find(".ifo")
+	if ifo == nil {
+		return nil, fmt.Errorf("StarDict archive is missing the .ifo file")
+	}
+	info, err := parseIfo(ifo)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, idxName := find(".idx", ".idx.gz")
+	if idx == nil {
+		return nil, fmt.Errorf("StarDict archive is missing the .idx file")
+	}
+	if idx, err = gunzip(idx, idxName); err != nil {
+		return nil, err
+	}
+	dict, dictName := find(".dict", ".dict.dz")
+	if dict == nil {
+		return nil, fmt.Errorf("StarDict archive is missing the .dict file")
+	}
+	if dict, err = gunzip(dict, dictName); err != nil {
+		return nil, err
+	}
+
+	offsetSize := 4
+	if info["idxoffsetbits"] == "64" {
+		offsetSize = 8
+	}
+	sequence := info["sametypesequence"]
+
+	result := &Data{Title: info["bookname"]}
+	for pos := 0; pos < len(idx); {
+		end := bytes.IndexByte(idx[pos:], 0)
+		if end < 0 || pos+end+1+offsetSize+4 > len(idx) {
+			return nil, fmt.Errorf("StarDict index is truncated")
+		}
+		headword := string(idx[pos : pos+end])
+		pos += end + 1
+
+		var offset uint64
+		if offsetSize == 8 {
+			offset = binary.BigEndian.Uint64(idx[pos:])
+		} else {
+			offset = uint64(binary.BigEndian.Uint32(idx[pos:]))
+		}
+		size := uint64(binary.BigEndian.Uint32(idx[pos+offsetSize:]))
+		pos += offsetSize + 4
+
+		if offset+size > uint64(len(dict)) {
+			return nil, fmt.Errorf("StarDict article for %q is outside the .dict file", headword)
+		}
+		entry := starDictEntry(headword, dict[offset:offset+size], sequence)
+		result.Entries = append(result.Entries, entry)
+	}
+
+	if syn, synName := find(".syn", ".syn.dz"); syn != nil {
+		if syn, err = gunzip(syn, synName); err != nil {
+			return nil, err
+		}
+		for pos := 0; pos < len(syn); {
+			end := bytes.IndexByte(syn[pos:], 0)
+			if end < 0 || pos+end+5 > len(syn) {
+				return nil, fmt.Errorf("StarDict synonym file is truncated")
+			}
+			form := string(syn[pos : pos+end])
+			i := int(binary.BigEndian.Uint32(syn[pos+end+1:]))
+			pos += end + 5
+			if i < len(result.Entries) {
+				result.Inflections = append(result.Inflections, Inflection{Form: form, Lemma: result.Entries[i].Headword})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func parseIfo(data []byte) (map[string]string, error) {
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if len(lines) == 0 || !strings.Contains(lines[0], "StarDict's dict ifo file") {
+		return nil, fmt.Errorf("invalid StarDict .ifo file")
+	}
+	info := make(map[string]string)
+	for _, line := range lines[1:] {
+		if key, value, ok := strings.Cut(line, "="); ok {
+			info[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return info, nil
+}
+
+// starDictEntry decodes an article. With a sametypesequence every field has
+// the type given by the sequence; otherwise each field starts with its type.
+// Lowercase types are NUL-terminated text, except for the last field of a
+// sequence, and uppercase types are binary data preceded by their size.
+func starDictEntry(headword string, data []byte, sequence string) Entry {
+	entry := Entry{Headword: headword}
+
+	add := func(typ byte, field []byte) {
+		text := string(field)
+		switch typ {
+		case 'm', 'l', 'w':
+			entry.Definitions = append(entry.Definitions, splitLines(text)...)
+		case 't', 'y':
+			if entry.Reading == "" {
+				entry.Reading = strings.TrimSpace(text)
+			}
+		case 'g', 'k':
+			entry.Definitions = append(entry.Definitions, splitLines(stripMarkup(text))...)
+		case 'h':
+			if root, err := nethtml.Parse(strings.NewReader(text)); err == nil {
+				entry.Definitions = append(entry.Definitions, splitLines(importer.HTMLText(root))...)
+			}
+		case 'x':
+			addXDXF(&entry, text)
+		}
+	}
+
+	pos := 0
+	next := func(typ byte, last bool) bool {
+		switch {
+		case pos >= len(data):
+			return false
+		case unicode.IsUpper(rune(typ)):
+			if last {
+				pos = len(data)
+				return true
+			}
+			if pos+4 > len(data) {
+				return false
+			}
+			size := int(binary.BigEndian.Uint32(data[pos:]))
+			pos += 4 + size
+			return pos <= len(data)
+		case last:
+			add(typ, bytes.TrimRight(data[pos:], "\x00"))
+			pos = len(data)
+			return true
+		default:
+			end := bytes.IndexByte(data[pos:], 0)
+			if end < 0 {
+				end = len(data) - pos
+			}
+			add(typ, data[pos:pos+end])
+			pos += end + 1
+			return true
+		}
+	}
+
+	if sequence != "" {
+		for i := 0; i < len(sequence); i++ {
+			if !next(sequence[i], i == len(sequence)-1) {
+				break
+			}
+		}
+		return entry
+	}
+	for pos < len(data) {
+		typ := data[pos]
+		pos++
+		if !next(typ, false) {
+			break
+		}
+	}
+	return entry
+}
+
+// addXDXF adds an XDXF article: the repeated headword is dropped, and
+// examples, the part of speech and the transcription are taken out of the
+// definition text.
+func addXDXF(entry *Entry, text string) {
+	for _, m := range xdxfExample.FindAllStringSubmatch(text, -1) {
+		if example := strings.TrimSpace(stripMarkup(m[1])); example != "" {
+			entry.Examples = append(entry.Examples, example)
+		}
+	}
+	if m := xdxfPartOfSpeech.FindStringSubmatch(text); m != nil && entry.PartOfSpeech == "" {
+		entry.PartOfSpeech = strings.TrimSpace(stripMarkup(m[1]))
+	}
+	if m := xdxfTranscript.FindStringSubmatch(text); m != nil && entry.Reading == "" {
+		entry.Reading = strings.TrimSpace(stripMarkup(m[1]))
+	}
+
+	text = xdxfKey.ReplaceAllString(text, "")
+	text = xdxfExample.ReplaceAllString(text, "")
+	text = xdxfPartOfSpeech.ReplaceAllString(text, "")
+	text = xdxfTranscript.ReplaceAllString(text, "")
+	text = xdxfBlock.ReplaceAllString(text, "\n")
+	entry.Definitions = append(entry.Definitions, splitLines(stripMarkup(text))...)
+}
+
+func stripMarkup(s string) string {
+	return html.UnescapeString(markupTag.ReplaceAllString(s, ""))
+}
@@ -0,0 +1,111 @@
+package dictionary
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parseTSV reads a tab-separated dictionary with one entry per line:
+//
+//	headword <TAB> definitions [<TAB> part of speech [<TAB> examples]]
+//
+// Several definitions or examples are separated by "|". Lines for the same
+// headword and part of speech are merged. Lines starting with # are comments.
+func parseTSV(r io.Reader) (*Data, error) {
+	var (
+		result = &Data{}
+		index  = make(map[[2]string]int)
+	)
+	err := scanTSV(r, func(columns []string) {
+		if len(columns) < 2 {
+			return
+		}
+		headword := strings.TrimSpace(columns[0])
+		definitions := splitList(columns[1])
+		if headword == "" || len(definitions) == 0 {
+			return
+		}
+		var partOfSpeech string
+		if len(columns) > 2 {
+			partOfSpeech = strings.TrimSpace(columns[2])
+		}
+		var examples []string
+		if len(columns) > 3 {
+			examples = splitList(columns[3])
+		}
+
+		key := [2]string{headword, partOfSpeech}
+		if i, ok := index[key]; ok {
+			entry := &result.Entries[i]
+			entry.Definitions = append(entry.Definitions, definitions...)
+			entry.Examples = append(entry.Examples, examples...)
+			return
+		}
+		index[key] = len(result.Entries)
+		result.Entries = append(result.Entries, Entry{
+			Headword:     headword,
+			PartOfSpeech: partOfSpeech,
+			Definitions:  definitions,
+			Examples:     examples,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ParseInflections reads a tab-separated inflection table with an inflected
+// form and its lemma on each line. Further columns, such as grammatical tags,
+// are ignored, as are lines starting with #.
+func ParseInflections(r io.Reader) ([]Inflection, error) {
+	var inflections []Inflection
+	err := scanTSV(r, func(columns []string) {
+		if len(columns) < 2 {
+			return
+		}
+		form, lemma := strings.TrimSpace(columns[0]), strings.TrimSpace(columns[1])
+		if form != "" && lemma != "" && form != lemma {
+			inflections = append(inflections, Inflection{Form: form, Lemma: lemma})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inflections, nil
+}
+
+func scanTSV(r io.Reader, line func(columns []string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	first := true
+	for scanner.Scan() {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if first {
+			text = strings.TrimPrefix(text, "\ufeff")
+			first = false
+		}
+		if strings.HasPrefix(text, "#") || strings.TrimSpace(text) == "" {
+			continue
+		}
+		line(strings.Split(text, "\t"))
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read TSV: %w", err)
+	}
+	return nil
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,190 @@
+package dictionary
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+type yomichanIndex struct {
+	Title   string `json:"title"`
+	Format  int    `json:"format"`
+	Version int    `json:"version"`
+}
+
+// structuredBlocks are the structured-content elements that start a new line.
+var structuredBlocks = map[string]bool{
+	"div": true, "p": true, "li": true, "ol": true, "ul": true, "br": true,
+	"tr": true, "table": true, "details": true, "summary": true,
+}
+
+// parseYomichan reads a Yomichan (Yomitan) dictionary: a zip archive with an
+// index.json and term banks, term_bank_1.json, term_bank_2.json, ... Each term
+// is an array of expression, reading, definition tags, deinflection rules,
+// score, glossary, sequence and term tags; format 1 dictionaries list the
+// glossary strings from the sixth element on instead. Glossary items may be
+// strings, text or structured-content objects or, for inflected forms, an
+// [uninflected form, rules] pair.
+func parseYomichan(files map[string][]byte) (*Data, error) {
+	var (
+		index     yomichanIndex
+		banks     []string
+		haveIndex bool
+	)
+	for name, content := range files {
+		base := path.Base(name)
+		switch {
+		case base == "index.json":
+			if err := json.Unmarshal(content, &index); err != nil {
+				return nil, fmt.Errorf("failed to parse index.json: %w", err)
+			}
+			haveIndex = true
+		case strings.HasPrefix(base, "term_bank_") && strings.HasSuffix(base, ".json"):
+			banks = append(banks, name)
+		}
+	}
+	if !haveIndex {
+		return nil, fmt.Errorf("Yomichan archive is missing index.json")
+	}
+	// Keep the dictionary's order: term_bank_2 before term_bank_10.
+	sort.Slice(banks, func(i, j int) bool {
+		if len(banks[i]) != len(banks[j]) {
+			return len(banks[i]) < len(banks[j])
+		}
+		return banks[i] < banks[j]
+	})
+	format := index.Format
+	if format == 0 {
+		format = index.Version
+	}
+
+	result := &Data{Title: index.Title}
+	for _, name := range banks {
+		var terms [][]json.RawMessage
+		if err := json.Unmarshal(files[name], &terms); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		for _, term := range terms {
+			if len(term) < 6 {
+				continue
+			}
+			var expression, reading, tags string
+			json.Unmarshal(term[0], &expression)
+			json.Unmarshal(term[1], &reading)
+			json.Unmarshal(term[2], &tags)
+			if expression == "" {
+				continue
+			}
+			entry := Entry{Headword: expression, PartOfSpeech: strings.TrimSpace(tags)}
+			if reading != expression {
+				entry.Reading = reading
+			}
+
+			glossary := term[5:6]
+			if format == 1 {
+				glossary = term[5:]
+			} else {
+				var items []json.RawMessage
+				if err := json.Unmarshal(term[5], &items); err == nil {
+					glossary = items
+				}
+			}
+			for _, item := range glossary {
+				if lemma := addGlossary(&entry, item); lemma != "" {
+					result.Inflections = append(result.Inflections, Inflection{Form: expression, Lemma: lemma})
+				}
+			}
+
+			if len(entry.Definitions) > 0 {
+				result.Entries = append(result.Entries, entry)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// addGlossary adds a glossary item to the entry. For a deinflection item it
+// adds nothing and returns the uninflected form.
+func addGlossary(entry *Entry, item json.RawMessage) string {
+	var text string
+	if json.Unmarshal(item, &text) == nil {
+		entry.Definitions = append(entry.Definitions, splitLines(text)...)
+		return ""
+	}
+
+	var pair []json.RawMessage
+	if json.Unmarshal(item, &pair) == nil {
+		var lemma string
+		if len(pair) > 0 && json.Unmarshal(pair[0], &lemma) == nil {
+			return lemma
+		}
+		return ""
+	}
+
+	var object struct {
+		Type    string          `json:"type"`
+		Text    string          `json:"text"`
+		Content json.RawMessage `json:"content"`
+	}
+	if json.Unmarshal(item, &object) != nil {
+		return ""
+	}
+	switch object.Type {
+	case "text":
+		entry.Definitions = append(entry.Definitions, splitLines(object.Text)...)
+	case "structured-content":
+		var b strings.Builder
+		renderStructured(&b, object.Content, entry)
+		entry.Definitions = append(entry.Definitions, splitLines(b.String())...)
+	}
+	return ""
+}
+
+// renderStructured writes the text of structured content, one block per
+// line. Elements marked as example sentences go to the entry's examples.
+func renderStructured(b *strings.Builder, content json.RawMessage, entry *Entry) {
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		b.WriteString(text)
+		return
+	}
+
+	var children []json.RawMessage
+	if json.Unmarshal(content, &children) == nil {
+		for _, child := range children {
+			renderStructured(b, child, entry)
+		}
+		return
+	}
+
+	var element struct {
+		Tag     string          `json:"tag"`
+		Content json.RawMessage `json:"content"`
+		Data    map[string]any  `json:"data"`
+	}
+	if json.Unmarshal(content, &element) != nil || element.Tag == "img" || element.Tag == "rt" {
+		return
+	}
+	if element.Data["content"] == "example-sentence" {
+		var example strings.Builder
+		renderStructured(&example, element.Content, entry)
+		if lines := splitLines(example.String()); len(lines) > 0 {
+			entry.Examples = append(entry.Examples, strings.Join(lines, " "))
+		}
+		return
+	}
+
+	block := structuredBlocks[element.Tag]
+	if block {
+		b.WriteString("\n")
+	}
+	if element.Content != nil {
+		renderStructured(b, element.Content, entry)
+	}
+	if block {
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// AudioCue holds the schema definition for the AudioCue entity.
+// A cue ties a span of the reading's audio, in milliseconds, to the passage
+// of the body read in it. start and end are character offsets into the body
+// and follow the passage when the body is edited, like highlights.
+type AudioCue struct {
+	ent.Schema
+}
+
+// Fields of the AudioCue.
+func (AudioCue) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("reading_id", uuid.UUID{}),
+		field.Int("position").
+			NonNegative(),
+		field.Int("start_ms").
+			NonNegative(),
+		field.Int("end_ms").
+			NonNegative(),
+		field.Int("start").
+			StorageKey("start_offset").
+			NonNegative(),
+		field.Int("end").
+			StorageKey("end_offset").
+			NonNegative(),
+		field.Text("text"),
+		field.String("prefix").
+			Default("").
+			Annotations(entgql.Skip()),
+		field.String("suffix").
+			Default("").
+			Annotations(entgql.Skip()),
+		field.Bool("orphaned").
+			Default(false),
+	}
+}
+
+// Edges of the AudioCue.
+func (AudioCue) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("reading", Reading.Type).
+			Ref("audio_cues").
+			Field("reading_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the AudioCue.
+func (AudioCue) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("reading_id", "position"),
+	}
+}
@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Bookmark holds the schema definition for the Bookmark entity.
+// It saves exactly one post, reading or comment for a user to come back to.
+type Bookmark struct {
+	ent.Schema
+}
+
+// Fields of the Bookmark.
+func (Bookmark) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("user_id", uuid.UUID{}),
+		field.UUID("post_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("reading_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("comment_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Bookmark.
+func (Bookmark) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("bookmarks").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.From("post", Post.Type).
+			Ref("bookmarks").
+			Field("post_id").
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("bookmarks").
+			Field("reading_id").
+			Unique(),
+		edge.From("comment", Comment.Type).
+			Ref("bookmarks").
+			Field("comment_id").
+			Unique(),
+	}
+}
+
+// Indexes of the Bookmark.
+func (Bookmark) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "post_id").
+			Unique(),
+		index.Fields("user_id", "reading_id").
+			Unique(),
+		index.Fields("user_id", "comment_id").
+			Unique(),
+		index.Fields("post_id"),
+		index.Fields("reading_id"),
+		index.Fields("comment_id"),
+		index.Fields("user_id", "created_at"),
+	}
+}
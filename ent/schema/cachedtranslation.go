@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// CachedTranslation holds the schema definition for the CachedTranslation entity.
+// It caches a translation provider's result, keyed by the language pair and
+// a hash of the normalized source text.
+type CachedTranslation struct {
+	ent.Schema
+}
+
+// Fields of the CachedTranslation.
+func (CachedTranslation) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.String("source_language"),
+		field.String("target_language"),
+		field.String("text_hash").
+			NotEmpty(),
+		field.Text("text"),
+		field.Text("translation"),
+		field.String("provider"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the CachedTranslation.
+func (CachedTranslation) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("source_language", "target_language", "text_hash").
+			Unique(),
+	}
+}
@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Comment holds the schema definition for the Comment entity.
+// A comment belongs to either a post or a reading; replies carry the post or
+// reading of their parent as well. A deleted comment that still has replies
+// is kept as a tombstone with deleted_at set and its author and body cleared.
+type Comment struct {
+	ent.Schema
+}
+
+// Fields of the Comment.
+func (Comment) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("user_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("post_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("reading_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("parent_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Text("body"),
+		field.Time("edited_at").
+			Optional().
+			Nillable(),
+		field.Time("deleted_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Comment.
+func (Comment) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("comments").
+			Field("user_id").
+			Unique(),
+		edge.From("post", Post.Type).
+			Ref("comments").
+			Field("post_id").
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("comments").
+			Field("reading_id").
+			Unique(),
+		edge.To("reactions", Reaction.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("bookmarks", Bookmark.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("replies", Comment.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			).
+			From("parent").
+			Field("parent_id").
+			Unique(),
+	}
+}
+
+// Indexes of the Comment.
+func (Comment) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("post_id", "parent_id", "created_at"),
+		index.Fields("reading_id", "parent_id", "created_at"),
+		index.Fields("parent_id", "created_at"),
+	}
+}
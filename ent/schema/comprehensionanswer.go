@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// ComprehensionAnswer holds the schema definition for the ComprehensionAnswer entity.
+// It is the answer given to one question in an attempt. Only the field
+// matching the question's kind is set.
+type ComprehensionAnswer struct {
+	ent.Schema
+}
+
+// Fields of the ComprehensionAnswer.
+func (ComprehensionAnswer) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("attempt_id", uuid.UUID{}),
+		field.UUID("question_id", uuid.UUID{}),
+		field.Int("choice").
+			Optional().
+			Nillable(),
+		field.Bool("value").
+			Optional().
+			Nillable(),
+		field.Text("text").
+			Optional().
+			Nillable(),
+		field.Bool("correct"),
+		field.Int("points").
+			NonNegative(),
+	}
+}
+
+// Edges of the ComprehensionAnswer.
+func (ComprehensionAnswer) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("attempt", ComprehensionAttempt.Type).
+			Ref("answers").
+			Field("attempt_id").
+			Required().
+			Unique(),
+		edge.From("question", ComprehensionQuestion.Type).
+			Ref("answers").
+			Field("question_id").
+			Required().
+			Unique(),
+	}
+}
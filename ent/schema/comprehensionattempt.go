@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ComprehensionAttempt holds the schema definition for the ComprehensionAttempt entity.
+// It records one submission of answers to a reading's comprehension
+// questions. score and max_score are kept as they were when the attempt was
+// scored, even if the questions change later.
+type ComprehensionAttempt struct {
+	ent.Schema
+}
+
+// Fields of the ComprehensionAttempt.
+func (ComprehensionAttempt) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("reading_id", uuid.UUID{}),
+		field.UUID("user_id", uuid.UUID{}),
+		field.Int("score").
+			NonNegative(),
+		field.Int("max_score").
+			NonNegative(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the ComprehensionAttempt.
+func (ComprehensionAttempt) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("reading", Reading.Type).
+			Ref("comprehension_attempts").
+			Field("reading_id").
+			Required().
+			Unique(),
+		edge.From("user", User.Type).
+			Ref("comprehension_attempts").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.To("answers", ComprehensionAnswer.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+	}
+}
+
+// Indexes of the ComprehensionAttempt.
+func (ComprehensionAttempt) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("reading_id", "user_id"),
+	}
+}
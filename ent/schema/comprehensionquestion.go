@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ComprehensionQuestion holds the schema definition for the ComprehensionQuestion entity.
+// The reading's owner asks it to check that students understood the text.
+// Which answer fields are used depends on the kind: choices and
+// correct_choice for multiple choice, correct_value for true/false and
+// accepted_answers for short answers.
+type ComprehensionQuestion struct {
+	ent.Schema
+}
+
+// Fields of the ComprehensionQuestion.
+func (ComprehensionQuestion) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("reading_id", uuid.UUID{}),
+		field.Int("position").
+			NonNegative(),
+		field.Enum("kind").
+			Values("MULTIPLE_CHOICE", "TRUE_FALSE", "SHORT_ANSWER"),
+		field.Text("prompt").
+			NotEmpty(),
+		field.JSON("choices", []string{}).
+			Optional(),
+		field.Int("correct_choice").
+			Optional().
+			Nillable(),
+		field.Bool("correct_value").
+			Optional().
+			Nillable(),
+		field.JSON("accepted_answers", []string{}).
+			Optional(),
+		field.Int("points").
+			Positive().
+			Default(1),
+		field.Text("explanation").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the ComprehensionQuestion.
+func (ComprehensionQuestion) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("reading", Reading.Type).
+			Ref("comprehension_questions").
+			Field("reading_id").
+			Required().
+			Unique(),
+		edge.To("answers", ComprehensionAnswer.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+	}
+}
+
+// Indexes of the ComprehensionQuestion.
+func (ComprehensionQuestion) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("reading_id", "position"),
+	}
+}
@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Correction holds the schema definition for the Correction entity.
+// It is one user's correction of a sentence of another user's post. sentence
+// is the index of the sentence in the post body and start and end are its
+// character offsets when the correction was made; original keeps the text
+// that was corrected so the correction still reads well after the post is
+// edited.
+type Correction struct {
+	ent.Schema
+}
+
+// Fields of the Correction.
+func (Correction) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("post_id", uuid.UUID{}),
+		field.UUID("user_id", uuid.UUID{}),
+		field.Int("sentence").
+			NonNegative(),
+		field.Int("start").
+			StorageKey("start_offset").
+			NonNegative(),
+		field.Int("end").
+			StorageKey("end_offset").
+			NonNegative(),
+		field.Text("original"),
+		field.Text("corrected"),
+		field.Text("explanation").
+			Optional().
+			Nillable(),
+		field.Time("accepted_at").
+			Optional().
+			Nillable(),
+		field.Time("thanked_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Correction.
+func (Correction) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("post", Post.Type).
+			Ref("corrections").
+			Field("post_id").
+			Required().
+			Unique(),
+		edge.From("user", User.Type).
+			Ref("corrections").
+			Field("user_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the Correction.
+func (Correction) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("post_id", "sentence"),
+		index.Fields("user_id", "created_at"),
+	}
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"time"
+
+	"LinganoGO/cefr"
+
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Course holds the schema definition for the Course entity.
+// It is an ordered series of readings and flashcard decks that a teacher
+// publishes and learners enroll in.
+type Course struct {
+	ent.Schema
+}
+
+// Fields of the Course.
+func (Course) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("owner_id", uuid.UUID{}),
+		field.String("title").
+			NotEmpty().
+			Annotations(entgql.OrderField("TITLE")),
+		field.Text("description").
+			Optional(),
+		field.String("language").
+			Optional(),
+		field.Enum("level").
+			GoType(cefr.Level("")).
+			Optional().
+			Nillable().
+			Annotations(entgql.OrderField("LEVEL")),
+		field.Enum("state").
+			Values("DRAFT", "PUBLISHED", "ARCHIVED").
+			Default("DRAFT"),
+		field.Time("published_at").
+			Optional().
+			Nillable().
+			Annotations(entgql.OrderField("PUBLISHED_AT")),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Course.
+func (Course) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("courses").
+			Field("owner_id").
+			Required().
+			Unique(),
+		edge.To("items", CourseItem.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("enrollments", CourseEnrollment.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+	}
+}
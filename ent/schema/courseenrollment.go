@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// CourseEnrollment holds the schema definition for the CourseEnrollment entity.
+// Progress on reading items comes from the learner's reading progress;
+// completed_deck_items records the deck items the learner went through.
+type CourseEnrollment struct {
+	ent.Schema
+}
+
+// Fields of the CourseEnrollment.
+func (CourseEnrollment) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("user_id", uuid.UUID{}),
+		field.UUID("course_id", uuid.UUID{}),
+		field.JSON("completed_deck_items", []uuid.UUID{}).
+			Optional(),
+		field.Time("enrolled_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("completed_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the CourseEnrollment.
+func (CourseEnrollment) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("enrollments").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.From("course", Course.Type).
+			Ref("enrollments").
+			Field("course_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the CourseEnrollment.
+func (CourseEnrollment) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "course_id").
+			Unique(),
+	}
+}
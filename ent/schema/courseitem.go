@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// CourseItem holds the schema definition for the CourseItem entity.
+// It is a step of a course: either a reading or a deck of flashcards.
+type CourseItem struct {
+	ent.Schema
+}
+
+// Fields of the CourseItem.
+func (CourseItem) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("course_id", uuid.UUID{}),
+		field.Int("position").
+			NonNegative(),
+		field.Enum("kind").
+			Values("READING", "DECK"),
+		field.String("title").
+			Optional(),
+		field.UUID("reading_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the CourseItem.
+func (CourseItem) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("course", Course.Type).
+			Ref("items").
+			Field("course_id").
+			Required().
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("course_items").
+			Field("reading_id").
+			Unique(),
+		edge.To("flashcards", Flashcard.Type),
+	}
+}
+
+// Indexes of the CourseItem.
+func (CourseItem) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("course_id", "position"),
+	}
+}
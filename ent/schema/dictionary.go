@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Dictionary holds the schema definition for the Dictionary entity.
+// Dictionaries are shared by all users and translate from the source to the
+// target language.
+type Dictionary struct {
+	ent.Schema
+}
+
+// Fields of the Dictionary.
+func (Dictionary) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.String("name").
+			NotEmpty(),
+		field.String("source_language").
+			NotEmpty(),
+		field.String("target_language").
+			NotEmpty(),
+		field.Enum("format").
+			Values("STARDICT", "YOMICHAN", "TSV"),
+		field.Int("entry_count").
+			NonNegative().
+			Default(0),
+		field.UUID("user_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Dictionary.
+func (Dictionary) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("dictionaries").
+			Field("user_id").
+			Unique(),
+		edge.To("entries", DictionaryEntry.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("inflections", InflectionForm.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+	}
+}
+
+// Indexes of the Dictionary.
+func (Dictionary) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("source_language", "target_language"),
+	}
+}
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// DictionaryEntry holds the schema definition for the DictionaryEntry entity.
+// Entries are looked up by their normalized headword; rank keeps the order
+// they had in the dictionary.
+type DictionaryEntry struct {
+	ent.Schema
+}
+
+// Fields of the DictionaryEntry.
+func (DictionaryEntry) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("dictionary_id", uuid.UUID{}),
+		field.String("headword").
+			NotEmpty(),
+		field.String("normalized_headword").
+			NotEmpty().
+			Annotations(entgql.Skip()),
+		field.String("reading").
+			Optional().
+			Nillable(),
+		field.String("part_of_speech").
+			Optional().
+			Nillable(),
+		field.JSON("definitions", []string{}),
+		field.JSON("examples", []string{}).
+			Optional(),
+		field.Int("rank").
+			NonNegative().
+			Annotations(entgql.Skip()),
+	}
+}
+
+// Edges of the DictionaryEntry.
+func (DictionaryEntry) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("dictionary", Dictionary.Type).
+			Ref("entries").
+			Field("dictionary_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the DictionaryEntry.
+func (DictionaryEntry) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("normalized_headword", "dictionary_id"),
+	}
+}
@@ -39,6 +39,12 @@ func (Flashcard) Fields() []ent.Field {
 			Annotations(entgql.OrderField("FAVORITED")),
 		field.UUID("user_id", uuid.UUID{}).
 			Annotations(entgql.OrderField("USER_ID")),
+		field.UUID("vocabulary_item_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("reading_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
 	}
 }
 
@@ -50,5 +56,15 @@ func (Flashcard) Edges() []ent.Edge {
 			Field("user_id").
 			Required().
 			Unique(),
+		edge.From("vocabulary_item", VocabularyItem.Type).
+			Ref("flashcards").
+			Field("vocabulary_item_id").
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("flashcards").
+			Field("reading_id").
+			Unique(),
+		edge.From("course_items", CourseItem.Type).
+			Ref("flashcards"),
 	}
 }
\ No newline at end of file
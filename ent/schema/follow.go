@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Follow holds the schema definition for the Follow entity.
+// The follower sees the activity of the followee in their feed.
+type Follow struct {
+	ent.Schema
+}
+
+// Fields of the Follow.
+func (Follow) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("follower_id", uuid.UUID{}),
+		field.UUID("followee_id", uuid.UUID{}),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Follow.
+func (Follow) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("follower", User.Type).
+			Ref("following").
+			Field("follower_id").
+			Required().
+			Unique(),
+		edge.From("followee", User.Type).
+			Ref("followers").
+			Field("followee_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the Follow.
+func (Follow) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("follower_id", "followee_id").
+			Unique(),
+		index.Fields("followee_id", "created_at"),
+	}
+}
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Highlight holds the schema definition for the Highlight entity.
+// It marks a passage of a reading, optionally with a note. start and end are
+// character offsets into the reading body; quote, prefix and suffix are used
+// to find the passage again when the body is edited.
+type Highlight struct {
+	ent.Schema
+}
+
+// Fields of the Highlight.
+func (Highlight) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("user_id", uuid.UUID{}),
+		field.UUID("reading_id", uuid.UUID{}),
+		field.Int("start").
+			StorageKey("start_offset").
+			NonNegative().
+			Annotations(entgql.OrderField("START")),
+		field.Int("end").
+			StorageKey("end_offset").
+			NonNegative(),
+		field.Text("quote"),
+		field.String("prefix").
+			Default("").
+			Annotations(entgql.Skip()),
+		field.String("suffix").
+			Default("").
+			Annotations(entgql.Skip()),
+		field.Enum("color").
+			Values("YELLOW", "GREEN", "BLUE", "PINK", "PURPLE").
+			Default("YELLOW"),
+		field.Text("note").
+			Optional().
+			Nillable(),
+		field.Bool("orphaned").
+			Default(false),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entgql.OrderField("CREATED_AT")),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Highlight.
+func (Highlight) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("highlights").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("highlights").
+			Field("reading_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the Highlight.
+func (Highlight) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("reading_id", "user_id"),
+	}
+}
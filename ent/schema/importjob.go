@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// ImportJob holds the schema definition for the ImportJob entity.
+// It tracks the extraction of an uploaded file into readings.
+type ImportJob struct {
+	ent.Schema
+}
+
+// Fields of the ImportJob.
+func (ImportJob) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("user_id", uuid.UUID{}),
+		field.String("filename"),
+		field.Enum("format").
+			Values("EPUB", "HTML", "MARKDOWN", "PDF", "SRT", "VTT", "TEXT"),
+		field.Int64("size").
+			NonNegative().
+			Default(0),
+		field.Enum("status").
+			Values("PENDING", "RUNNING", "COMPLETED", "FAILED").
+			Default("PENDING"),
+		field.Float("progress").
+			Min(0).
+			Max(100).
+			Default(0),
+		field.Text("error").
+			Optional().
+			Nillable(),
+		field.UUID("reading_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entgql.OrderField("CREATED_AT")),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the ImportJob.
+func (ImportJob) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("import_jobs").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("import_jobs").
+			Field("reading_id").
+			Unique(),
+	}
+}
@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// InflectionForm holds the schema definition for the InflectionForm entity.
+// It maps a normalized inflected form of a word to its lemma. Forms come from
+// inflection tables imported per language or from a dictionary, in which case
+// they are deleted along with it.
+type InflectionForm struct {
+	ent.Schema
+}
+
+// Fields of the InflectionForm.
+func (InflectionForm) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.String("language").
+			NotEmpty(),
+		field.String("form").
+			NotEmpty(),
+		field.String("lemma").
+			NotEmpty(),
+		field.UUID("dictionary_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the InflectionForm.
+func (InflectionForm) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("dictionary", Dictionary.Type).
+			Ref("inflections").
+			Field("dictionary_id").
+			Unique(),
+	}
+}
+
+// Indexes of the InflectionForm.
+func (InflectionForm) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("language", "form"),
+	}
+}
@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Notification holds the schema definition for the Notification entity.
+// It tells a user about something another user (the actor) did that
+// concerns them, such as reviewing one of their readings. read_at is set
+// once the user has seen it.
+type Notification struct {
+	ent.Schema
+}
+
+// Fields of the Notification.
+func (Notification) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("user_id", uuid.UUID{}),
+		field.Enum("kind").
+			Values("READING_APPROVED", "READING_REJECTED", "READING_UNPUBLISHED"),
+		field.UUID("actor_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("reading_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Text("message"),
+		field.Time("read_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Notification.
+func (Notification) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("notifications").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.From("actor", User.Type).
+			Ref("sent_notifications").
+			Field("actor_id").
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("notifications").
+			Field("reading_id").
+			Unique(),
+	}
+}
+
+// Indexes of the Notification.
+func (Notification) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+	}
+}
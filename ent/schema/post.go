@@ -1,13 +1,20 @@
 package schema
 
 import (
+	"time"
+
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/google/uuid"
 )
 
+// Post holds the schema definition for the Post entity.
+// A draft with publish_at set is published by the post scheduler once that
+// time has come; published_at is set while the post is published.
 type Post struct {
 	ent.Schema
 }
@@ -23,6 +30,21 @@ func (Post) Fields() []ent.Field {
 			NotEmpty(),
 		field.UUID("user_id", uuid.UUID{}).
 			Annotations(entgql.OrderField("USER_ID")),
+		field.String("language").
+			Optional().
+			Nillable(),
+		field.Time("publish_at").
+			Optional().
+			Nillable(),
+		field.Time("published_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
 	}
 }
 
@@ -33,5 +55,33 @@ func (Post) Edges() []ent.Edge {
 			Field("user_id").
 			Required().
 			Unique(),
+		edge.To("comments", Comment.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("corrections", Correction.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("reactions", Reaction.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("bookmarks", Bookmark.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
 	}
-}
\ No newline at end of file
+}
+
+func (Post) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("draft", "publish_at"),
+		index.Fields("language", "draft"),
+		index.Fields("user_id", "published_at"),
+	}
+}
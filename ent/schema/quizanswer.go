@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// QuizAnswer holds the schema definition for the QuizAnswer entity.
+// It is the answer given to one blank of a quiz. The sentence and the
+// blanked word are copied so the attempt still reads the same after the
+// reading is edited and its sentences are segmented again.
+type QuizAnswer struct {
+	ent.Schema
+}
+
+// Fields of the QuizAnswer.
+func (QuizAnswer) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("attempt_id", uuid.UUID{}),
+		field.Int("position").
+			NonNegative(),
+		field.Text("sentence"),
+		field.String("word").
+			NotEmpty(),
+		field.String("answer"),
+		field.Bool("correct"),
+	}
+}
+
+// Edges of the QuizAnswer.
+func (QuizAnswer) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("attempt", QuizAttempt.Type).
+			Ref("answers").
+			Field("attempt_id").
+			Required().
+			Unique(),
+	}
+}
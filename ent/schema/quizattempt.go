@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// QuizAttempt holds the schema definition for the QuizAttempt entity.
+// Cloze quizzes are generated on demand and never stored; an attempt records
+// the blanks a reader answered and how many they got right.
+type QuizAttempt struct {
+	ent.Schema
+}
+
+// Fields of the QuizAttempt.
+func (QuizAttempt) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("reading_id", uuid.UUID{}),
+		field.UUID("user_id", uuid.UUID{}),
+		field.Enum("kind").
+			Values("MULTIPLE_CHOICE", "TYPED"),
+		field.Int("score").
+			NonNegative(),
+		field.Int("total").
+			NonNegative(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the QuizAttempt.
+func (QuizAttempt) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("reading", Reading.Type).
+			Ref("quiz_attempts").
+			Field("reading_id").
+			Required().
+			Unique(),
+		edge.From("user", User.Type).
+			Ref("quiz_attempts").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.To("answers", QuizAnswer.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+	}
+}
+
+// Indexes of the QuizAttempt.
+func (QuizAttempt) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("reading_id", "user_id"),
+	}
+}
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Reaction holds the schema definition for the Reaction entity.
+// It is a user's emoji reaction to exactly one post, reading or comment; a
+// user has at most one reaction per target.
+type Reaction struct {
+	ent.Schema
+}
+
+// Fields of the Reaction.
+func (Reaction) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("user_id", uuid.UUID{}),
+		field.UUID("post_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("reading_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.UUID("comment_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Enum("kind").
+			Values("LIKE", "LOVE", "LAUGH", "WOW", "SAD", "THANKS"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Reaction.
+func (Reaction) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("reactions").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.From("post", Post.Type).
+			Ref("reactions").
+			Field("post_id").
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("reactions").
+			Field("reading_id").
+			Unique(),
+		edge.From("comment", Comment.Type).
+			Ref("reactions").
+			Field("comment_id").
+			Unique(),
+	}
+}
+
+// Indexes of the Reaction.
+func (Reaction) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "post_id").
+			Unique(),
+		index.Fields("user_id", "reading_id").
+			Unique(),
+		index.Fields("user_id", "comment_id").
+			Unique(),
+		index.Fields("post_id"),
+		index.Fields("reading_id"),
+		index.Fields("comment_id"),
+	}
+}
@@ -1,14 +1,23 @@
 package schema
 
 import (
+	"time"
+
+	"LinganoGO/cefr"
+
 	"entgo.io/contrib/entgql"
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/google/uuid"
 )
 
 // Reading holds the schema definition for the Reading entity.
+// publication_status tracks the editorial review a reading goes through
+// before it is published; public is kept in step with it and is true only
+// for published readings.
 type Reading struct {
 	ent.Schema
 }
@@ -31,6 +40,69 @@ func (Reading) Fields() []ent.Field {
 		field.Bool("public").
 			Default(false).
 			Annotations(entgql.OrderField("PUBLIC")),
+		field.Enum("publication_status").
+			Values("PRIVATE", "SUBMITTED", "APPROVED", "REJECTED", "PUBLISHED").
+			Default("PRIVATE"),
+		field.Time("submitted_at").
+			Optional().
+			Nillable(),
+		field.Time("published_at").
+			Optional().
+			Nillable(),
+		field.Text("body").
+			Default(""),
+		field.Enum("format").
+			Values("PLAIN", "MARKDOWN").
+			Default("PLAIN"),
+		field.String("language").
+			Optional().
+			Annotations(entgql.OrderField("LANGUAGE")),
+		field.Enum("level").
+			GoType(cefr.Level("")).
+			Optional().
+			Nillable().
+			Annotations(entgql.OrderField("LEVEL")),
+		field.Text("source_url").
+			Optional(),
+		field.String("author").
+			Optional(),
+		field.Enum("estimated_level").
+			GoType(cefr.Level("")).
+			Optional().
+			Nillable().
+			Annotations(entgql.OrderField("ESTIMATED_LEVEL")),
+		field.Float("difficulty_score").
+			Optional().
+			Nillable(),
+		field.UUID("parent_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Int("chapter_index").
+			Optional().
+			Nillable().
+			NonNegative(),
+		field.String("audio_url").
+			Optional().
+			Nillable(),
+		field.String("audio_key").
+			Optional().
+			Nillable().
+			Annotations(entgql.Skip()),
+		field.JSON("token_counts", map[string]int{}).
+			Optional().
+			Annotations(entgql.Skip()),
+		field.Int("word_count").
+			NonNegative().
+			Default(0).
+			Annotations(entgql.OrderField("WORD_COUNT")),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entgql.OrderField("CREATED_AT")),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Annotations(entgql.OrderField("UPDATED_AT")),
 	}
 }
 
@@ -42,5 +114,98 @@ func (Reading) Edges() []ent.Edge {
 			Field("user_id").
 			Required().
 			Unique(),
+		edge.To("progress", ReadingProgress.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("vocabulary_items", VocabularyItem.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.SetNull,
+			}),
+		edge.To("flashcards", Flashcard.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.SetNull,
+			}),
+		edge.To("chapters", Reading.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			).
+			From("parent").
+			Field("parent_id").
+			Unique(),
+		edge.To("import_jobs", ImportJob.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.SetNull,
+			}),
+		edge.To("highlights", Highlight.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("course_items", CourseItem.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("sentences", ReadingSentence.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("audio_cues", AudioCue.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("comprehension_questions", ComprehensionQuestion.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("comprehension_attempts", ComprehensionAttempt.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("quiz_attempts", QuizAttempt.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("revisions", ReadingRevision.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("reviews", ReadingReview.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("notifications", Notification.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("comments", Comment.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("reactions", Reaction.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("bookmarks", Bookmark.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+	}
+}
+
+// Indexes of the Reading.
+func (Reading) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "published_at"),
 	}
 }
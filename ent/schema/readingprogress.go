@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ReadingProgress holds the schema definition for the ReadingProgress entity.
+// There is at most one progress record per user and reading.
+type ReadingProgress struct {
+	ent.Schema
+}
+
+// Fields of the ReadingProgress.
+func (ReadingProgress) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("user_id", uuid.UUID{}),
+		field.UUID("reading_id", uuid.UUID{}),
+		field.Int("position").
+			NonNegative().
+			Default(0),
+		field.Enum("position_unit").
+			Values("CHARACTER", "PAGE").
+			Default("CHARACTER"),
+		field.Float("percent").
+			Min(0).
+			Max(100).
+			Default(0).
+			Annotations(entgql.OrderField("PERCENT")),
+		field.Int("time_spent_seconds").
+			NonNegative().
+			Default(0),
+		field.Time("last_opened_at").
+			Default(time.Now).
+			Annotations(entgql.OrderField("LAST_OPENED_AT")),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the ReadingProgress.
+func (ReadingProgress) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("reading_progress").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("progress").
+			Field("reading_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the ReadingProgress.
+func (ReadingProgress) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "reading_id").
+			Unique(),
+	}
+}
@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ReadingReview holds the schema definition for the ReadingReview entity.
+// It is an admin's decision on a reading submitted for publication, with an
+// optional comment for the author.
+type ReadingReview struct {
+	ent.Schema
+}
+
+// Fields of the ReadingReview.
+func (ReadingReview) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("reading_id", uuid.UUID{}),
+		field.UUID("reviewer_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Enum("decision").
+			Values("APPROVED", "REJECTED"),
+		field.Text("comment").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the ReadingReview.
+func (ReadingReview) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("reading", Reading.Type).
+			Ref("reviews").
+			Field("reading_id").
+			Required().
+			Unique(),
+		edge.From("reviewer", User.Type).
+			Ref("reading_reviews").
+			Field("reviewer_id").
+			Unique(),
+	}
+}
+
+// Indexes of the ReadingReview.
+func (ReadingReview) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("reading_id", "created_at"),
+	}
+}
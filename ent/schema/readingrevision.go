@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ReadingRevision holds the schema definition for the ReadingRevision entity.
+// A revision is a copy of a reading's content as it was at some point.
+// Revisions are numbered from 1 for each reading; restored_from is set on
+// revisions made by restoring an earlier one.
+type ReadingRevision struct {
+	ent.Schema
+}
+
+// Fields of the ReadingRevision.
+func (ReadingRevision) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("reading_id", uuid.UUID{}),
+		field.Int("number").
+			Positive(),
+		field.String("title").
+			NotEmpty(),
+		field.Text("body"),
+		field.Enum("format").
+			Values("PLAIN", "MARKDOWN"),
+		field.Int("restored_from").
+			Optional().
+			Nillable().
+			Positive(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the ReadingRevision.
+func (ReadingRevision) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("reading", Reading.Type).
+			Ref("revisions").
+			Field("reading_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the ReadingRevision.
+func (ReadingRevision) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("reading_id", "number").
+			Unique(),
+	}
+}
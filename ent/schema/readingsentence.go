@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ReadingSentence holds the schema definition for the ReadingSentence entity.
+// Sentences are derived from the reading body and kept in sync with it; start
+// and end are character offsets into the body.
+type ReadingSentence struct {
+	ent.Schema
+}
+
+// Fields of the ReadingSentence.
+func (ReadingSentence) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("reading_id", uuid.UUID{}),
+		field.Int("position").
+			NonNegative(),
+		field.Int("start").
+			StorageKey("start_offset").
+			NonNegative(),
+		field.Int("end").
+			StorageKey("end_offset").
+			NonNegative(),
+		field.Text("text"),
+	}
+}
+
+// Edges of the ReadingSentence.
+func (ReadingSentence) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("reading", Reading.Type).
+			Ref("sentences").
+			Field("reading_id").
+			Required().
+			Unique(),
+		edge.To("translations", SentenceTranslation.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+	}
+}
+
+// Indexes of the ReadingSentence.
+func (ReadingSentence) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("reading_id", "position"),
+	}
+}
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// SentenceTranslation holds the schema definition for the SentenceTranslation entity.
+// A sentence can have any number of translations: entered by users, imported
+// from aligned files or produced by a translation provider.
+type SentenceTranslation struct {
+	ent.Schema
+}
+
+// Fields of the SentenceTranslation.
+func (SentenceTranslation) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("sentence_id", uuid.UUID{}),
+		field.UUID("user_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.String("language").
+			NotEmpty(),
+		field.Text("text"),
+		field.Enum("source").
+			Values("USER", "IMPORT", "PROVIDER"),
+		field.String("provider").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the SentenceTranslation.
+func (SentenceTranslation) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("sentence", ReadingSentence.Type).
+			Ref("translations").
+			Field("sentence_id").
+			Required().
+			Unique(),
+		edge.From("user", User.Type).
+			Ref("sentence_translations").
+			Field("user_id").
+			Unique(),
+	}
+}
+
+// Indexes of the SentenceTranslation.
+func (SentenceTranslation) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("sentence_id", "language"),
+	}
+}
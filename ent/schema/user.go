@@ -52,6 +52,17 @@ func (User) Fields() []ent.Field {
 			Optional(),
 		field.JSON("preferences", map[string]interface{}{}).
 			Optional(),
+		// Languages the user speaks natively, used to match them with posts
+		// they can correct.
+		field.Strings("native_languages").
+			Optional(),
+		// The language the user is learning; the feed falls back to popular
+		// content in it when the user follows no one.
+		field.String("target_language").
+			Optional().
+			Nillable(),
+		// Deprecated: saved words are stored as VocabularyItem rows. The
+		// column is kept so older clients keep working.
 		field.JSON("saved_words", map[string]interface{}{}).
 			Optional(),
 		field.Time("created_at").
@@ -80,5 +91,83 @@ func (User) Edges() []ent.Edge {
 			Annotations(entsql.Annotation{
 				OnDelete: entsql.Cascade,
 			}),
+		edge.To("reading_progress", ReadingProgress.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("vocabulary", VocabularyItem.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("import_jobs", ImportJob.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("highlights", Highlight.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("courses", Course.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("enrollments", CourseEnrollment.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("sentence_translations", SentenceTranslation.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("dictionaries", Dictionary.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.SetNull,
+			}),
+		edge.To("comprehension_attempts", ComprehensionAttempt.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("quiz_attempts", QuizAttempt.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("reading_reviews", ReadingReview.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.SetNull,
+			}),
+		edge.To("notifications", Notification.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("sent_notifications", Notification.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.SetNull,
+			}),
+		edge.To("comments", Comment.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.SetNull,
+			}),
+		edge.To("corrections", Correction.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("reactions", Reaction.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("bookmarks", Bookmark.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.Cascade,
+			}),
+		edge.To("following", Follow.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
+		edge.To("followers", Follow.Type).
+			Annotations(
+				entsql.Annotation{OnDelete: entsql.Cascade},
+				entgql.Skip(),
+			),
 	}
 }
@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// VocabularyItem holds the schema definition for the VocabularyItem entity.
+// It is a word a user saved, together with how well they know it: status 0 is
+// new, 1 to 4 are learning stages and 5 is known.
+type VocabularyItem struct {
+	ent.Schema
+}
+
+// Fields of the VocabularyItem.
+func (VocabularyItem) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+		field.UUID("user_id", uuid.UUID{}),
+		field.String("term").
+			NotEmpty().
+			Annotations(entgql.OrderField("TERM")),
+		field.String("normalized_term").
+			NotEmpty().
+			Annotations(entgql.Skip()),
+		field.String("language").
+			Default(""),
+		field.String("lemma").
+			Optional(),
+		field.String("translation").
+			Optional(),
+		field.Text("notes").
+			Optional(),
+		field.Int("status").
+			Range(0, 5).
+			Default(0).
+			Annotations(entgql.OrderField("STATUS")),
+		field.Bool("ignored").
+			Default(false),
+		field.Text("sentence").
+			Optional(),
+		field.UUID("reading_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Time("status_updated_at").
+			Default(time.Now),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entgql.OrderField("CREATED_AT")),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Annotations(entgql.OrderField("UPDATED_AT")),
+	}
+}
+
+// Edges of the VocabularyItem.
+func (VocabularyItem) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("vocabulary").
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.From("reading", Reading.Type).
+			Ref("vocabulary_items").
+			Field("reading_id").
+			Unique(),
+		edge.To("flashcards", Flashcard.Type).
+			Annotations(entsql.Annotation{
+				OnDelete: entsql.SetNull,
+			}),
+	}
+}
+
+// Indexes of the VocabularyItem.
+func (VocabularyItem) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "language", "normalized_term").
+			Unique(),
+	}
+}
@@ -0,0 +1,219 @@
+// Package estimator scores how hard a text is to read and maps the score to a
+// CEFR level. It looks at how many words fall outside the language's most
+// frequent words, how long the sentences are and how dense the vocabulary is.
+package estimator
+
+import (
+	"bufio"
+	"embed"
+	"path"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"LinganoGO/cefr"
+	"LinganoGO/tokenizer"
+)
+
+//go:embed frequency/*.txt
+var frequencyFS embed.FS
+
+// functionWordRank is the rank under which words are treated as function
+// words (articles, pronouns, auxiliaries, ...) when computing lexical density.
+const functionWordRank = 60
+
+// longWordLength is used instead of frequency lists for languages without
+// one: words at least this long are counted as rare.
+const longWordLength = 8
+
+// thresholds are the upper score bounds of each level but the last.
+var thresholds = []struct {
+	level cefr.Level
+	max   float64
+}{
+	{cefr.A1, 0.26},
+	{cefr.A2, 0.37},
+	{cefr.B1, 0.45},
+	{cefr.B2, 0.55},
+	{cefr.C1, 0.65},
+}
+
+var (
+	loadRanks sync.Once
+	ranks     map[string]map[string]int
+)
+
+// Estimate describes the difficulty of a text.
+type Estimate struct {
+	Level cefr.Level
+	// Score goes from 0 (easiest) to 1 (hardest).
+	Score                 float64
+	WordCount             int
+	SentenceCount         int
+	AverageSentenceLength float64
+	// RareWordRatio is the share of words outside the frequency list.
+	RareWordRatio float64
+	// LexicalDensity is the share of content words, as opposed to function words.
+	LexicalDensity float64
+}
+
+// EstimateText scores text written in language. It returns false when the text
+// contains no words.
+func EstimateText(text, language string) (Estimate, bool) {
+	tokens := tokenizer.Tokenize(text, language)
+
+	var words []tokenizer.Token
+	sentences := 0
+	inSentence := false
+	for _, t := range tokens {
+		if t.IsWord {
+			words = append(words, t)
+			inSentence = true
+			continue
+		}
+		if inSentence && strings.ContainsAny(t.Text, ".!?…。！？") {
+			sentences++
+			inSentence = false
+		}
+	}
+	if inSentence {
+		sentences++
+	}
+	if len(words) == 0 {
+		return Estimate{}, false
+	}
+
+	e := Estimate{
+		WordCount:     len(words),
+		SentenceCount: sentences,
+	}
+	e.AverageSentenceLength = float64(len(words)) / float64(sentences)
+
+	rare, content := 0, 0
+	if HasFrequencyList(language) {
+		for _, w := range words {
+			rank := Rank(language, w.Normalized)
+			if rank == 0 {
+				rare++
+			}
+			if rank == 0 || rank > functionWordRank {
+				content++
+			}
+		}
+	} else {
+		unique := make(map[string]bool)
+		for _, w := range words {
+			if utf8.RuneCountInString(w.Normalized) >= longWordLength {
+				rare++
+			}
+			if !unique[w.Normalized] {
+				unique[w.Normalized] = true
+				content++
+			}
+		}
+	}
+	e.RareWordRatio = float64(rare) / float64(len(words))
+	e.LexicalDensity = float64(content) / float64(len(words))
+
+	lengthScore := clamp((e.AverageSentenceLength-5)/25, 0, 1)
+	e.Score = clamp(0.55*e.RareWordRatio+0.2*e.LexicalDensity+0.25*lengthScore, 0, 1)
+	e.Level = LevelForScore(e.Score)
+
+	return e, true
+}
+
+// LevelForScore maps a difficulty score to a CEFR level.
+func LevelForScore(score float64) cefr.Level {
+	for _, t := range thresholds {
+		if score < t.max {
+			return t.level
+		}
+	}
+	return cefr.C2
+}
+
+// ScoreForLevel returns the difficulty score in the middle of a level's band.
+func ScoreForLevel(level cefr.Level) float64 {
+	lower := 0.0
+	for _, t := range thresholds {
+		if t.level == level {
+			return (lower + t.max) / 2
+		}
+		lower = t.max
+	}
+	return (lower + 1) / 2
+}
+
+// HasFrequencyList reports whether a frequency list is embedded for language.
+func HasFrequencyList(language string) bool {
+	_, ok := frequencyRanks()[baseLanguage(language)]
+	return ok
+}
+
+// Rank returns the 1-based frequency rank of a normalized word, or 0 if the
+// word is not in the language's list.
+func Rank(language, word string) int {
+	return frequencyRanks()[baseLanguage(language)][word]
+}
+
+// Words returns the words of a language's frequency list, most frequent first.
+func Words(language string) []string {
+	list := frequencyRanks()[baseLanguage(language)]
+	words := make([]string, len(list))
+	for word, rank := range list {
+		words[rank-1] = word
+	}
+	return words
+}
+
+func frequencyRanks() map[string]map[string]int {
+	loadRanks.Do(func() {
+		ranks = make(map[string]map[string]int)
+
+		files, err := frequencyFS.ReadDir("frequency")
+		if err != nil {
+			return
+		}
+		for _, f := range files {
+			language := strings.TrimSuffix(f.Name(), path.Ext(f.Name()))
+			file, err := frequencyFS.Open(path.Join("frequency", f.Name()))
+			if err != nil {
+				continue
+			}
+
+			list := make(map[string]int)
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				word := tokenizer.Normalize(scanner.Text(), language)
+				if word == "" {
+					continue
+				}
+				if _, ok := list[word]; !ok {
+					list[word] = len(list) + 1
+				}
+			}
+			file.Close()
+			ranks[language] = list
+		}
+	})
+	return ranks
+}
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+// baseLanguage reduces a language tag such as "en-GB" to "en".
+func baseLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return language
+}
@@ -3,28 +3,240 @@
 package model
 
 import (
+	"LinganoGO/cefr"
+	"LinganoGO/ent"
+	"LinganoGO/ent/comprehensionquestion"
+	"LinganoGO/ent/course"
+	"LinganoGO/ent/courseitem"
+	"LinganoGO/ent/dictionary"
+	"LinganoGO/ent/highlight"
+	"LinganoGO/ent/importjob"
+	"LinganoGO/ent/quizattempt"
+	"LinganoGO/ent/reaction"
+	"LinganoGO/ent/reading"
+	"LinganoGO/ent/readingprogress"
 	"bytes"
 	"fmt"
 	"io"
 	"strconv"
 )
 
+// An answer to a comprehension question: choice for multiple choice questions,
+// value for true/false questions and text for short answers
+type ComprehensionAnswerInput struct {
+	QuestionID string  `json:"questionID"`
+	Choice     *int    `json:"choice,omitempty"`
+	Value      *bool   `json:"value,omitempty"`
+	Text       *string `json:"text,omitempty"`
+}
+
+// ComprehensionResults sums up the attempts on a reading's questions for its
+// owner. The averages and per question results count the latest attempt of each
+// student; attempts lists every attempt, newest first.
+type ComprehensionResults struct {
+	ReadingID         string                      `json:"readingID"`
+	AttemptCount      int                         `json:"attemptCount"`
+	StudentCount      int                         `json:"studentCount"`
+	AveragePercentage float64                     `json:"averagePercentage"`
+	Questions         []*QuestionResult           `json:"questions"`
+	Attempts          []*ent.ComprehensionAttempt `json:"attempts"`
+}
+
+// ContentTarget names the post, reading or comment a reaction or bookmark is
+// for. Exactly one of postID, readingID and commentID must be set.
+type ContentTarget struct {
+	PostID    *string `json:"postID,omitempty"`
+	ReadingID *string `json:"readingID,omitempty"`
+	CommentID *string `json:"commentID,omitempty"`
+}
+
+// Filter for browsing published courses. The level bounds are inclusive.
+type CourseFilter struct {
+	Language *string     `json:"language,omitempty"`
+	MinLevel *cefr.Level `json:"minLevel,omitempty"`
+	MaxLevel *cefr.Level `json:"maxLevel,omitempty"`
+	Search   *string     `json:"search,omitempty"`
+}
+
+// A learner's progress on one course item
+type CourseItemProgress struct {
+	Item      *ent.CourseItem `json:"item"`
+	Completed bool            `json:"completed"`
+	Percent   float64         `json:"percent"`
+}
+
+// A learner's progress through a course. Reading items are complete when the
+// learner finished the reading; deck items when they marked the deck done.
+type CourseProgress struct {
+	Course         *ent.Course           `json:"course"`
+	Enrollment     *ent.CourseEnrollment `json:"enrollment,omitempty"`
+	TotalItems     int                   `json:"totalItems"`
+	CompletedItems int                   `json:"completedItems"`
+	Percent        float64               `json:"percent"`
+	Items          []*CourseItemProgress `json:"items"`
+	NextItem       *ent.CourseItem       `json:"nextItem,omitempty"`
+}
+
+// New times for an audio cue
+type CueTiming struct {
+	ID      string `json:"id"`
+	StartMs int    `json:"startMs"`
+	EndMs   int    `json:"endMs"`
+}
+
+// DiffChunk is a run of text kept, inserted or deleted between two texts, such
+// as two revisions of a reading or a sentence and its correction
+type DiffChunk struct {
+	Kind DiffKind `json:"kind"`
+	Text string   `json:"text"`
+}
+
+// FeedItem is an activity in a user's feed: actor published a post, reading or
+// course, or commented. Exactly one of post, reading, course and comment is
+// set. Passing cursor as after continues the feed after this item.
+type FeedItem struct {
+	Cursor    string       `json:"cursor"`
+	Kind      FeedItemKind `json:"kind"`
+	Actor     *ent.User    `json:"actor"`
+	Post      *ent.Post    `json:"post,omitempty"`
+	Reading   *ent.Reading `json:"reading,omitempty"`
+	Course    *ent.Course  `json:"course,omitempty"`
+	Comment   *ent.Comment `json:"comment,omitempty"`
+	CreatedAt string       `json:"createdAt"`
+}
+
+// FeedPage is a page of a user's feed. The feed merges the activity of the
+// users they follow, newest first. For users who follow no one it shows
+// popular posts and readings in their target language instead, most reacted
+// to first, and popular is true.
+type FeedPage struct {
+	Items       []*FeedItem `json:"items"`
+	EndCursor   *string     `json:"endCursor,omitempty"`
+	HasNextPage bool        `json:"hasNextPage"`
+	Popular     bool        `json:"popular"`
+}
+
+type ImportDictionaryInput struct {
+	UserID         string             `json:"userID"`
+	Name           *string            `json:"name,omitempty"`
+	SourceLanguage string             `json:"sourceLanguage"`
+	TargetLanguage string             `json:"targetLanguage"`
+	Format         *dictionary.Format `json:"format,omitempty"`
+}
+
+// Options for importReading. title, language and author override the metadata
+// found in the file; format overrides detection from the file name and content.
+type ImportReadingOptions struct {
+	UserID   string            `json:"userID"`
+	Title    *string           `json:"title,omitempty"`
+	Language *string           `json:"language,omitempty"`
+	Author   *string           `json:"author,omitempty"`
+	Level    *cefr.Level       `json:"level,omitempty"`
+	Public   *bool             `json:"public,omitempty"`
+	Format   *importjob.Format `json:"format,omitempty"`
+}
+
+// Options for importReadingFromURL. title and language override the ones found
+// in the page.
+type ImportURLOptions struct {
+	UserID   string      `json:"userID"`
+	Title    *string     `json:"title,omitempty"`
+	Language *string     `json:"language,omitempty"`
+	Level    *cefr.Level `json:"level,omitempty"`
+	Public   *bool       `json:"public,omitempty"`
+}
+
+// NewComment comments on a post or a public reading, or replies to a comment.
+// Exactly one of postID, readingID and parentID must be set.
+type NewComment struct {
+	UserID    string  `json:"userID"`
+	PostID    *string `json:"postID,omitempty"`
+	ReadingID *string `json:"readingID,omitempty"`
+	ParentID  *string `json:"parentID,omitempty"`
+	Body      string  `json:"body"`
+}
+
+// A comprehension question added to a reading by its owner. Only the answer
+// fields matching the kind are used.
+type NewComprehensionQuestion struct {
+	ReadingID       string                     `json:"readingID"`
+	UserID          string                     `json:"userID"`
+	Kind            comprehensionquestion.Kind `json:"kind"`
+	Prompt          string                     `json:"prompt"`
+	Choices         []string                   `json:"choices,omitempty"`
+	CorrectChoice   *int                       `json:"correctChoice,omitempty"`
+	CorrectValue    *bool                      `json:"correctValue,omitempty"`
+	AcceptedAnswers []string                   `json:"acceptedAnswers,omitempty"`
+	Points          *int                       `json:"points,omitempty"`
+	Explanation     *string                    `json:"explanation,omitempty"`
+}
+
+// NewCorrection corrects the sentence of a post at index sentence, as listed in
+// Post.sentences.
+type NewCorrection struct {
+	UserID      string  `json:"userID"`
+	PostID      string  `json:"postID"`
+	Sentence    int     `json:"sentence"`
+	Corrected   string  `json:"corrected"`
+	Explanation *string `json:"explanation,omitempty"`
+}
+
+type NewCourse struct {
+	UserID      string      `json:"userID"`
+	Title       string      `json:"title"`
+	Description *string     `json:"description,omitempty"`
+	Language    *string     `json:"language,omitempty"`
+	Level       *cefr.Level `json:"level,omitempty"`
+}
+
+// A new course item. READING items need readingID, DECK items need flashcardIDs.
+// position defaults to the end of the course.
+type NewCourseItem struct {
+	CourseID     string          `json:"courseID"`
+	UserID       string          `json:"userID"`
+	Kind         courseitem.Kind `json:"kind"`
+	Title        *string         `json:"title,omitempty"`
+	ReadingID    *string         `json:"readingID,omitempty"`
+	FlashcardIDs []string        `json:"flashcardIDs,omitempty"`
+	Position     *int            `json:"position,omitempty"`
+}
+
 type NewFlashcard struct {
 	Question string `json:"question"`
 	Answer   string `json:"answer"`
 	UserID   string `json:"userID"`
 }
 
+type NewHighlight struct {
+	UserID    string           `json:"userID"`
+	ReadingID string           `json:"readingID"`
+	Start     int              `json:"start"`
+	End       int              `json:"end"`
+	Color     *highlight.Color `json:"color,omitempty"`
+	Note      *string          `json:"note,omitempty"`
+}
+
+// NewPost creates a post. publishAt is an RFC 3339 time at which a draft is
+// published and requires draft to be true.
 type NewPost struct {
-	Body   string `json:"body"`
-	UserID string `json:"userID"`
-	Draft  *bool  `json:"draft,omitempty"`
+	Body      string  `json:"body"`
+	UserID    string  `json:"userID"`
+	Draft     bool    `json:"draft"`
+	PublishAt *string `json:"publishAt,omitempty"`
+	Language  *string `json:"language,omitempty"`
 }
 
+// A new reading. Asking for a public reading submits it for review.
 type NewReading struct {
-	Title  string `json:"title"`
-	UserID string `json:"userID"`
-	Public *bool  `json:"public,omitempty"`
+	Title     string          `json:"title"`
+	UserID    string          `json:"userID"`
+	Public    *bool           `json:"public,omitempty"`
+	Body      *string         `json:"body,omitempty"`
+	Format    *reading.Format `json:"format,omitempty"`
+	Language  *string         `json:"language,omitempty"`
+	Level     *cefr.Level     `json:"level,omitempty"`
+	SourceURL *string         `json:"sourceURL,omitempty"`
+	Author    *string         `json:"author,omitempty"`
 }
 
 type NewUser struct {
@@ -33,6 +245,632 @@ type NewUser struct {
 	Password string `json:"password"`
 }
 
+// PostSentence is a sentence of a post body. start and end are character
+// offsets into the body.
+type PostSentence struct {
+	Index int    `json:"index"`
+	Text  string `json:"text"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// QuestionResult tells how a question was answered across students
+type QuestionResult struct {
+	Question     *ent.ComprehensionQuestion `json:"question"`
+	AnswerCount  int                        `json:"answerCount"`
+	CorrectCount int                        `json:"correctCount"`
+	CorrectRate  float64                    `json:"correctRate"`
+}
+
+// Quiz is a cloze quiz generated from the sentences of a reading for one viewer.
+// Quizzes are not stored: each blank is answered by its sentence and offsets.
+type Quiz struct {
+	Reading *ent.Reading     `json:"reading"`
+	Kind    quizattempt.Kind `json:"kind"`
+	Items   []*QuizItem      `json:"items"`
+}
+
+// The answer to one blank of a cloze quiz, identified by its sentence and the
+// offsets returned in QuizItem
+type QuizAnswerInput struct {
+	SentenceID string `json:"sentenceID"`
+	Start      int    `json:"start"`
+	End        int    `json:"end"`
+	Answer     string `json:"answer"`
+}
+
+// QuizItem is a sentence with one word blanked out. start and end are character
+// offsets of the word in the sentence text and prompt is the sentence with the
+// word replaced by underscores. choices holds the word and its distractors in
+// random order for multiple choice quizzes and is empty for typed ones.
+type QuizItem struct {
+	Sentence *ent.ReadingSentence `json:"sentence"`
+	Start    int                  `json:"start"`
+	End      int                  `json:"end"`
+	Prompt   string               `json:"prompt"`
+	Choices  []string             `json:"choices"`
+}
+
+// ReactionCount is how many users reacted to a post, reading or comment with
+// one kind of reaction. viewerHasReacted is true when the viewer's reaction is
+// of this kind. Counts are ordered from the most to the least used kind.
+type ReactionCount struct {
+	Kind             reaction.Kind `json:"kind"`
+	Count            int           `json:"count"`
+	ViewerHasReacted bool          `json:"viewerHasReacted"`
+}
+
+// Filter for public readings. The level bounds are inclusive and apply to the
+// level set by the author or, when there is none, the estimated level.
+type ReadingFilter struct {
+	Language *string     `json:"language,omitempty"`
+	MinLevel *cefr.Level `json:"minLevel,omitempty"`
+	MaxLevel *cefr.Level `json:"maxLevel,omitempty"`
+}
+
+// Progress update for a reading. timeSpentSeconds is the time spent since the
+// previous update and is added to the total.
+type ReadingProgressInput struct {
+	ReadingID        string                        `json:"readingID"`
+	UserID           string                        `json:"userID"`
+	Position         *int                          `json:"position,omitempty"`
+	PositionUnit     *readingprogress.PositionUnit `json:"positionUnit,omitempty"`
+	Percent          *float64                      `json:"percent,omitempty"`
+	TimeSpentSeconds *int                          `json:"timeSpentSeconds,omitempty"`
+	Finished         *bool                         `json:"finished,omitempty"`
+}
+
+// A reading recommended to a learner. coverage is the percentage of running
+// words they know, ignoring words they marked as ignored; newWordCount and
+// learningWordCount count distinct words.
+type ReadingRecommendation struct {
+	Reading           *ent.Reading `json:"reading"`
+	Coverage          float64      `json:"coverage"`
+	NewWordCount      int          `json:"newWordCount"`
+	LearningWordCount int          `json:"learningWordCount"`
+	UniqueWordCount   int          `json:"uniqueWordCount"`
+}
+
+// ReadingRevisionDiff compares two revisions of a reading. Within a change,
+// deleted text comes before inserted text.
+type ReadingRevisionDiff struct {
+	From  *ent.ReadingRevision `json:"from"`
+	To    *ent.ReadingRevision `json:"to"`
+	Title []*DiffChunk         `json:"title"`
+	Body  []*DiffChunk         `json:"body"`
+}
+
+// ReadingToken is a word or the text between two words. Offsets are character
+// offsets into the reading body; normalized and status are only set for words.
+// reading is set for words in a non-Latin script when the viewer turned a
+// reading aid on. A reading that spans several words (Japanese compounds such
+// as 今日) is given to the first of them.
+type ReadingToken struct {
+	Text       string      `json:"text"`
+	Normalized *string     `json:"normalized,omitempty"`
+	Start      int         `json:"start"`
+	End        int         `json:"end"`
+	IsWord     bool        `json:"isWord"`
+	Status     *WordStatus `json:"status,omitempty"`
+	Reading    *string     `json:"reading,omitempty"`
+}
+
+// ReadingTokens is a reading split into tokens for a given viewer
+type ReadingTokens struct {
+	ReadingID       string          `json:"readingID"`
+	Language        *string         `json:"language,omitempty"`
+	Tokens          []*ReadingToken `json:"tokens"`
+	WordCount       int             `json:"wordCount"`
+	UniqueWordCount int             `json:"uniqueWordCount"`
+	KnownCount      int             `json:"knownCount"`
+	LearningCount   int             `json:"learningCount"`
+	NewCount        int             `json:"newCount"`
+	IgnoredCount    int             `json:"ignoredCount"`
+	KnownPercentage float64         `json:"knownPercentage"`
+}
+
+type SaveWordInput struct {
+	UserID      string  `json:"userID"`
+	Term        string  `json:"term"`
+	Language    string  `json:"language"`
+	Lemma       *string `json:"lemma,omitempty"`
+	Translation *string `json:"translation,omitempty"`
+	Notes       *string `json:"notes,omitempty"`
+	Status      *int    `json:"status,omitempty"`
+	ReadingID   *string `json:"readingID,omitempty"`
+	Sentence    *string `json:"sentence,omitempty"`
+}
+
+// SentencePair is a sentence of a reading aligned with its translation, if it
+// has one
+type SentencePair struct {
+	Sentence    *ent.ReadingSentence     `json:"sentence"`
+	Translation *ent.SentenceTranslation `json:"translation,omitempty"`
+}
+
+// Translation is a word or sentence translated on demand. provider names the
+// translation provider that made it.
+type Translation struct {
+	Text        string `json:"text"`
+	Translation string `json:"translation"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Provider    string `json:"provider"`
+}
+
+// Changes to a comprehension question. position moves the question, shifting
+// the others. An empty explanation removes it.
+type UpdateComprehensionQuestion struct {
+	Kind            *comprehensionquestion.Kind `json:"kind,omitempty"`
+	Prompt          *string                     `json:"prompt,omitempty"`
+	Choices         []string                    `json:"choices,omitempty"`
+	CorrectChoice   *int                        `json:"correctChoice,omitempty"`
+	CorrectValue    *bool                       `json:"correctValue,omitempty"`
+	AcceptedAnswers []string                    `json:"acceptedAnswers,omitempty"`
+	Points          *int                        `json:"points,omitempty"`
+	Explanation     *string                     `json:"explanation,omitempty"`
+	Position        *int                        `json:"position,omitempty"`
+}
+
+type UpdateCourse struct {
+	Title       *string       `json:"title,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Language    *string       `json:"language,omitempty"`
+	Level       *cefr.Level   `json:"level,omitempty"`
+	State       *course.State `json:"state,omitempty"`
+}
+
+// Changes to a highlight. An empty note removes the note.
+type UpdateHighlight struct {
+	Color *highlight.Color `json:"color,omitempty"`
+	Note  *string          `json:"note,omitempty"`
+}
+
+type UpdateReading struct {
+	Title     *string         `json:"title,omitempty"`
+	Body      *string         `json:"body,omitempty"`
+	Format    *reading.Format `json:"format,omitempty"`
+	Language  *string         `json:"language,omitempty"`
+	Level     *cefr.Level     `json:"level,omitempty"`
+	SourceURL *string         `json:"sourceURL,omitempty"`
+	Author    *string         `json:"author,omitempty"`
+	Finished  *bool           `json:"finished,omitempty"`
+	Public    *bool           `json:"public,omitempty"`
+}
+
+type VocabularyFilter struct {
+	Language  *string `json:"language,omitempty"`
+	Statuses  []int   `json:"statuses,omitempty"`
+	Ignored   *bool   `json:"ignored,omitempty"`
+	ReadingID *string `json:"readingID,omitempty"`
+	Search    *string `json:"search,omitempty"`
+	Limit     *int    `json:"limit,omitempty"`
+	Offset    *int    `json:"offset,omitempty"`
+}
+
+// WordLookup holds the dictionary entries found for a term. lemmas lists the
+// base forms, other than the term itself, that entries were found under.
+type WordLookup struct {
+	Term    string                 `json:"term"`
+	Lemmas  []string               `json:"lemmas"`
+	Entries []*ent.DictionaryEntry `json:"entries"`
+}
+
+// Format of an aligned translation file. TSV files have the source sentence in
+// the first column and its translation in the second.
+type AlignmentFormat string
+
+const (
+	AlignmentFormatTmx AlignmentFormat = "TMX"
+	AlignmentFormatTsv AlignmentFormat = "TSV"
+)
+
+var AllAlignmentFormat = []AlignmentFormat{
+	AlignmentFormatTmx,
+	AlignmentFormatTsv,
+}
+
+func (e AlignmentFormat) IsValid() bool {
+	switch e {
+	case AlignmentFormatTmx, AlignmentFormatTsv:
+		return true
+	}
+	return false
+}
+
+func (e AlignmentFormat) String() string {
+	return string(e)
+}
+
+func (e *AlignmentFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AlignmentFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AlignmentFormat", str)
+	}
+	return nil
+}
+
+func (e AlignmentFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *AlignmentFormat) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e AlignmentFormat) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Format of a cue file. VTT and SRT cues are matched to the reading body by
+// their text; JSON is a list of {startTime, endTime, start, end} objects with
+// times in seconds and character offsets into the body.
+type CueFormat string
+
+const (
+	CueFormatVtt  CueFormat = "VTT"
+	CueFormatSrt  CueFormat = "SRT"
+	CueFormatJSON CueFormat = "JSON"
+)
+
+var AllCueFormat = []CueFormat{
+	CueFormatVtt,
+	CueFormatSrt,
+	CueFormatJSON,
+}
+
+func (e CueFormat) IsValid() bool {
+	switch e {
+	case CueFormatVtt, CueFormatSrt, CueFormatJSON:
+		return true
+	}
+	return false
+}
+
+func (e CueFormat) String() string {
+	return string(e)
+}
+
+func (e *CueFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CueFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CueFormat", str)
+	}
+	return nil
+}
+
+func (e CueFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CueFormat) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CueFormat) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Whether a diff compares whole lines or single words
+type DiffGranularity string
+
+const (
+	DiffGranularityLine DiffGranularity = "LINE"
+	DiffGranularityWord DiffGranularity = "WORD"
+)
+
+var AllDiffGranularity = []DiffGranularity{
+	DiffGranularityLine,
+	DiffGranularityWord,
+}
+
+func (e DiffGranularity) IsValid() bool {
+	switch e {
+	case DiffGranularityLine, DiffGranularityWord:
+		return true
+	}
+	return false
+}
+
+func (e DiffGranularity) String() string {
+	return string(e)
+}
+
+func (e *DiffGranularity) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DiffGranularity(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DiffGranularity", str)
+	}
+	return nil
+}
+
+func (e DiffGranularity) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DiffGranularity) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DiffGranularity) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// What happened to a chunk of text between two revisions
+type DiffKind string
+
+const (
+	DiffKindEqual  DiffKind = "EQUAL"
+	DiffKindInsert DiffKind = "INSERT"
+	DiffKindDelete DiffKind = "DELETE"
+)
+
+var AllDiffKind = []DiffKind{
+	DiffKindEqual,
+	DiffKindInsert,
+	DiffKindDelete,
+}
+
+func (e DiffKind) IsValid() bool {
+	switch e {
+	case DiffKindEqual, DiffKindInsert, DiffKindDelete:
+		return true
+	}
+	return false
+}
+
+func (e DiffKind) String() string {
+	return string(e)
+}
+
+func (e *DiffKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DiffKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DiffKind", str)
+	}
+	return nil
+}
+
+func (e DiffKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DiffKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DiffKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Activity shown by a feed item
+type FeedItemKind string
+
+const (
+	FeedItemKindPostPublished    FeedItemKind = "POST_PUBLISHED"
+	FeedItemKindReadingPublished FeedItemKind = "READING_PUBLISHED"
+	FeedItemKindCoursePublished  FeedItemKind = "COURSE_PUBLISHED"
+	FeedItemKindCommented        FeedItemKind = "COMMENTED"
+)
+
+var AllFeedItemKind = []FeedItemKind{
+	FeedItemKindPostPublished,
+	FeedItemKindReadingPublished,
+	FeedItemKindCoursePublished,
+	FeedItemKindCommented,
+}
+
+func (e FeedItemKind) IsValid() bool {
+	switch e {
+	case FeedItemKindPostPublished, FeedItemKindReadingPublished, FeedItemKindCoursePublished, FeedItemKindCommented:
+		return true
+	}
+	return false
+}
+
+func (e FeedItemKind) String() string {
+	return string(e)
+}
+
+func (e *FeedItemKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FeedItemKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FeedItemKind", str)
+	}
+	return nil
+}
+
+func (e FeedItemKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FeedItemKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FeedItemKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// How generateFlashcards turns a vocabulary item into a card:
+// BASIC asks for the meaning of the term, REVERSE for the term given its
+// translation, CLOZE blanks the term out of the sentence it was saved from and
+// EXAMPLE shows the term in that sentence and asks for its meaning.
+type FlashcardTemplate string
+
+const (
+	FlashcardTemplateBasic   FlashcardTemplate = "BASIC"
+	FlashcardTemplateReverse FlashcardTemplate = "REVERSE"
+	FlashcardTemplateCloze   FlashcardTemplate = "CLOZE"
+	FlashcardTemplateExample FlashcardTemplate = "EXAMPLE"
+)
+
+var AllFlashcardTemplate = []FlashcardTemplate{
+	FlashcardTemplateBasic,
+	FlashcardTemplateReverse,
+	FlashcardTemplateCloze,
+	FlashcardTemplateExample,
+}
+
+func (e FlashcardTemplate) IsValid() bool {
+	switch e {
+	case FlashcardTemplateBasic, FlashcardTemplateReverse, FlashcardTemplateCloze, FlashcardTemplateExample:
+		return true
+	}
+	return false
+}
+
+func (e FlashcardTemplate) String() string {
+	return string(e)
+}
+
+func (e *FlashcardTemplate) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FlashcardTemplate(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FlashcardTemplate", str)
+	}
+	return nil
+}
+
+func (e FlashcardTemplate) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FlashcardTemplate) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FlashcardTemplate) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// ReadingAid is the reading shown over words written in a non-Latin script.
+// KANA gives Japanese kanji their reading in hiragana (furigana) and romanizes
+// other scripts (pinyin for Chinese); LATIN romanizes everything, Japanese kana
+// included.
+type ReadingAid string
+
+const (
+	ReadingAidNone  ReadingAid = "NONE"
+	ReadingAidKana  ReadingAid = "KANA"
+	ReadingAidLatin ReadingAid = "LATIN"
+)
+
+var AllReadingAid = []ReadingAid{
+	ReadingAidNone,
+	ReadingAidKana,
+	ReadingAidLatin,
+}
+
+func (e ReadingAid) IsValid() bool {
+	switch e {
+	case ReadingAidNone, ReadingAidKana, ReadingAidLatin:
+		return true
+	}
+	return false
+}
+
+func (e ReadingAid) String() string {
+	return string(e)
+}
+
+func (e *ReadingAid) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReadingAid(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReadingAid", str)
+	}
+	return nil
+}
+
+func (e ReadingAid) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReadingAid) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReadingAid) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 // User role enumeration
 type Role string
 
@@ -88,3 +926,63 @@ func (e Role) MarshalJSON() ([]byte, error) {
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
+
+// A learner's knowledge of a word
+type WordStatus string
+
+const (
+	WordStatusNew      WordStatus = "NEW"
+	WordStatusLearning WordStatus = "LEARNING"
+	WordStatusKnown    WordStatus = "KNOWN"
+	WordStatusIgnored  WordStatus = "IGNORED"
+)
+
+var AllWordStatus = []WordStatus{
+	WordStatusNew,
+	WordStatusLearning,
+	WordStatusKnown,
+	WordStatusIgnored,
+}
+
+func (e WordStatus) IsValid() bool {
+	switch e {
+	case WordStatusNew, WordStatusLearning, WordStatusKnown, WordStatusIgnored:
+		return true
+	}
+	return false
+}
+
+func (e WordStatus) String() string {
+	return string(e)
+}
+
+func (e *WordStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WordStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WordStatus", str)
+	}
+	return nil
+}
+
+func (e WordStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WordStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WordStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -10,15 +10,63 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	readings    []*ent.Reading
-	userService *services.UserService
-	postService  *services.PostService
+	readings               []*ent.Reading
+	userService            *services.UserService
+	postService            *services.PostService
+	readingService         *services.ReadingService
+	readingProgressService *services.ReadingProgressService
+	readingTokenService    *services.ReadingTokenService
+	vocabularyService      *services.VocabularyService
+	flashcardService       *services.FlashcardService
+	importService          *services.ImportService
+	highlightService       *services.HighlightService
+	courseService          *services.CourseService
+	recommendationService  *services.RecommendationService
+	sentenceService        *services.SentenceService
+	audioService           *services.AudioService
+	translationService     *services.TranslationService
+	dictionaryService      *services.DictionaryService
+	comprehensionService   *services.ComprehensionService
+	quizService            *services.QuizService
+	readingRevisionService *services.ReadingRevisionService
+	publicationService     *services.PublicationService
+	notificationService    *services.NotificationService
+	commentService         *services.CommentService
+	correctionService      *services.CorrectionService
+	reactionService        *services.ReactionService
+	bookmarkService        *services.BookmarkService
+	followService          *services.FollowService
+	feedService            *services.FeedService
 }
 
 // NewResolver creates a new resolver with initialized services
 func NewResolver() *Resolver {
 	return &Resolver{
-		userService: services.NewUserService(),
-		postService: services.NewPostService(),
+		userService:            services.NewUserService(),
+		postService:            services.NewPostService(),
+		readingService:         services.NewReadingService(),
+		readingProgressService: services.NewReadingProgressService(),
+		readingTokenService:    services.NewReadingTokenService(),
+		vocabularyService:      services.NewVocabularyService(),
+		flashcardService:       services.NewFlashcardService(),
+		importService:          services.NewImportService(),
+		highlightService:       services.NewHighlightService(),
+		courseService:          services.NewCourseService(),
+		recommendationService:  services.NewRecommendationService(),
+		sentenceService:        services.NewSentenceService(),
+		audioService:           services.NewAudioService(),
+		translationService:     services.NewTranslationService(),
+		dictionaryService:      services.NewDictionaryService(),
+		comprehensionService:   services.NewComprehensionService(),
+		quizService:            services.NewQuizService(),
+		readingRevisionService: services.NewReadingRevisionService(),
+		publicationService:     services.NewPublicationService(),
+		notificationService:    services.NewNotificationService(),
+		commentService:         services.NewCommentService(),
+		correctionService:      services.NewCorrectionService(),
+		reactionService:        services.NewReactionService(),
+		bookmarkService:        services.NewBookmarkService(),
+		followService:          services.NewFollowService(),
+		feedService:            services.NewFeedService(),
 	}
 }
@@ -8,16 +8,316 @@ import (
 	"LinganoGO/config"
 	"LinganoGO/ent"
 	"LinganoGO/ent/flashcard"
+	"LinganoGO/ent/quizattempt"
+	"LinganoGO/ent/reaction"
 	"LinganoGO/ent/reading"
+	"LinganoGO/ent/readingreview"
 	"LinganoGO/graph/model"
+	"LinganoGO/services"
 	"context"
 	"fmt"
 	"time"
 
+	"entgo.io/contrib/entgql"
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ID is the resolver for the id field.
+func (r *audioCueResolver) ID(ctx context.Context, obj *ent.AudioCue) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ID is the resolver for the id field.
+func (r *bookmarkResolver) ID(ctx context.Context, obj *ent.Bookmark) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *bookmarkResolver) CreatedAt(ctx context.Context, obj *ent.Bookmark) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *commentResolver) ID(ctx context.Context, obj *ent.Comment) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Deleted is the resolver for the deleted field.
+func (r *commentResolver) Deleted(ctx context.Context, obj *ent.Comment) (bool, error) {
+	return obj.DeletedAt != nil, nil
+}
+
+// EditedAt is the resolver for the editedAt field.
+func (r *commentResolver) EditedAt(ctx context.Context, obj *ent.Comment) (*string, error) {
+	if obj.EditedAt == nil {
+		return nil, nil
+	}
+	formatted := obj.EditedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
+// Replies is the resolver for the replies field.
+func (r *commentResolver) Replies(ctx context.Context, obj *ent.Comment, limit *int, offset *int) ([]*ent.Comment, error) {
+	n, skip := 20, 0
+	if limit != nil {
+		n = *limit
+	}
+	if offset != nil {
+		skip = *offset
+	}
+
+	comments, err := r.commentService.GetReplies(ctx, obj.ID, n, skip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	return comments, nil
+}
+
+// ReplyCount is the resolver for the replyCount field.
+func (r *commentResolver) ReplyCount(ctx context.Context, obj *ent.Comment) (int, error) {
+	count, err := r.commentService.CountReplies(ctx, obj.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count replies: %w", err)
+	}
+	return count, nil
+}
+
+// Reactions is the resolver for the reactions field.
+func (r *commentResolver) Reactions(ctx context.Context, obj *ent.Comment, viewerID *string) ([]*model.ReactionCount, error) {
+	var viewer *uuid.UUID
+	if viewerID != nil {
+		viewerUUID, err := uuid.Parse(*viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid viewer ID: %w", err)
+		}
+		viewer = &viewerUUID
+	}
+
+	counts, err := r.reactionService.GetReactionCounts(ctx, services.CommentTarget(obj.ID), viewer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions: %w", err)
+	}
+	return counts, nil
+}
+
+// ReactionCount is the resolver for the reactionCount field.
+func (r *commentResolver) ReactionCount(ctx context.Context, obj *ent.Comment) (int, error) {
+	count, err := r.reactionService.CountReactions(ctx, services.CommentTarget(obj.ID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	return count, nil
+}
+
+// Bookmarked is the resolver for the bookmarked field.
+func (r *commentResolver) Bookmarked(ctx context.Context, obj *ent.Comment, viewerID *string) (bool, error) {
+	var viewer *uuid.UUID
+	if viewerID != nil {
+		viewerUUID, err := uuid.Parse(*viewerID)
+		if err != nil {
+			return false, fmt.Errorf("invalid viewer ID: %w", err)
+		}
+		viewer = &viewerUUID
+	}
+
+	bookmarked, err := r.bookmarkService.IsBookmarked(ctx, services.CommentTarget(obj.ID), viewer)
+	if err != nil {
+		return false, fmt.Errorf("failed to get bookmark: %w", err)
+	}
+	return bookmarked, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *commentResolver) CreatedAt(ctx context.Context, obj *ent.Comment) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *commentResolver) UpdatedAt(ctx context.Context, obj *ent.Comment) (string, error) {
+	return obj.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *comprehensionAnswerResolver) ID(ctx context.Context, obj *ent.ComprehensionAnswer) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ID is the resolver for the id field.
+func (r *comprehensionAttemptResolver) ID(ctx context.Context, obj *ent.ComprehensionAttempt) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Percentage is the resolver for the percentage field.
+func (r *comprehensionAttemptResolver) Percentage(ctx context.Context, obj *ent.ComprehensionAttempt) (float64, error) {
+	return services.AttemptPercentage(obj), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *comprehensionAttemptResolver) CreatedAt(ctx context.Context, obj *ent.ComprehensionAttempt) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *comprehensionQuestionResolver) ID(ctx context.Context, obj *ent.ComprehensionQuestion) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *comprehensionQuestionResolver) CreatedAt(ctx context.Context, obj *ent.ComprehensionQuestion) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *comprehensionQuestionResolver) UpdatedAt(ctx context.Context, obj *ent.ComprehensionQuestion) (string, error) {
+	return obj.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *correctionResolver) ID(ctx context.Context, obj *ent.Correction) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Diff is the resolver for the diff field.
+func (r *correctionResolver) Diff(ctx context.Context, obj *ent.Correction) ([]*model.DiffChunk, error) {
+	post, err := obj.Post(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	return services.CorrectionDiff(obj, post), nil
+}
+
+// Accepted is the resolver for the accepted field.
+func (r *correctionResolver) Accepted(ctx context.Context, obj *ent.Correction) (bool, error) {
+	return obj.AcceptedAt != nil, nil
+}
+
+// AcceptedAt is the resolver for the acceptedAt field.
+func (r *correctionResolver) AcceptedAt(ctx context.Context, obj *ent.Correction) (*string, error) {
+	if obj.AcceptedAt == nil {
+		return nil, nil
+	}
+	formatted := obj.AcceptedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
+// Thanked is the resolver for the thanked field.
+func (r *correctionResolver) Thanked(ctx context.Context, obj *ent.Correction) (bool, error) {
+	return obj.ThankedAt != nil, nil
+}
+
+// ThankedAt is the resolver for the thankedAt field.
+func (r *correctionResolver) ThankedAt(ctx context.Context, obj *ent.Correction) (*string, error) {
+	if obj.ThankedAt == nil {
+		return nil, nil
+	}
+	formatted := obj.ThankedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *correctionResolver) CreatedAt(ctx context.Context, obj *ent.Correction) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *courseResolver) ID(ctx context.Context, obj *ent.Course) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// PublishedAt is the resolver for the publishedAt field.
+func (r *courseResolver) PublishedAt(ctx context.Context, obj *ent.Course) (*string, error) {
+	if obj.PublishedAt == nil {
+		return nil, nil
+	}
+	formatted := obj.PublishedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
+// Items is the resolver for the items field.
+func (r *courseResolver) Items(ctx context.Context, obj *ent.Course) ([]*ent.CourseItem, error) {
+	items, err := r.courseService.GetItems(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course items: %w", err)
+	}
+
+	return items, nil
+}
+
+// EnrollmentCount is the resolver for the enrollmentCount field.
+func (r *courseResolver) EnrollmentCount(ctx context.Context, obj *ent.Course) (int, error) {
+	count, err := r.courseService.CountEnrollments(ctx, obj.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count enrollments: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *courseResolver) CreatedAt(ctx context.Context, obj *ent.Course) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *courseResolver) UpdatedAt(ctx context.Context, obj *ent.Course) (string, error) {
+	return obj.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *courseEnrollmentResolver) ID(ctx context.Context, obj *ent.CourseEnrollment) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// EnrolledAt is the resolver for the enrolledAt field.
+func (r *courseEnrollmentResolver) EnrolledAt(ctx context.Context, obj *ent.CourseEnrollment) (string, error) {
+	return obj.EnrolledAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// CompletedAt is the resolver for the completedAt field.
+func (r *courseEnrollmentResolver) CompletedAt(ctx context.Context, obj *ent.CourseEnrollment) (*string, error) {
+	if obj.CompletedAt == nil {
+		return nil, nil
+	}
+	formatted := obj.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
+// ID is the resolver for the id field.
+func (r *courseItemResolver) ID(ctx context.Context, obj *ent.CourseItem) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Title is the resolver for the title field.
+func (r *courseItemResolver) Title(ctx context.Context, obj *ent.CourseItem) (string, error) {
+	if obj.Title != "" || obj.ReadingID == nil {
+		return obj.Title, nil
+	}
+
+	reading, err := obj.Reading(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get reading: %w", err)
+	}
+
+	return reading.Title, nil
+}
+
+// ID is the resolver for the id field.
+func (r *dictionaryResolver) ID(ctx context.Context, obj *ent.Dictionary) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *dictionaryResolver) CreatedAt(ctx context.Context, obj *ent.Dictionary) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *dictionaryEntryResolver) ID(ctx context.Context, obj *ent.DictionaryEntry) (string, error) {
+	return obj.ID.String(), nil
+}
+
 // ID is the resolver for the id field.
 func (r *flashcardResolver) ID(ctx context.Context, obj *ent.Flashcard) (string, error) {
 	return obj.ID.String(), nil
@@ -37,6 +337,45 @@ func (r *flashcardResolver) LastReviewedAt(ctx context.Context, obj *ent.Flashca
 	return &formatted, nil
 }
 
+// ID is the resolver for the id field.
+func (r *highlightResolver) ID(ctx context.Context, obj *ent.Highlight) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *highlightResolver) CreatedAt(ctx context.Context, obj *ent.Highlight) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *highlightResolver) UpdatedAt(ctx context.Context, obj *ent.Highlight) (string, error) {
+	return obj.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *importJobResolver) ID(ctx context.Context, obj *ent.ImportJob) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *importJobResolver) CreatedAt(ctx context.Context, obj *ent.ImportJob) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *importJobResolver) UpdatedAt(ctx context.Context, obj *ent.ImportJob) (string, error) {
+	return obj.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// FinishedAt is the resolver for the finishedAt field.
+func (r *importJobResolver) FinishedAt(ctx context.Context, obj *ent.ImportJob) (*string, error) {
+	if obj.FinishedAt == nil {
+		return nil, nil
+	}
+	formatted := obj.FinishedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
 // CreateUser is the resolver for the createUser field.
 func (r *mutationResolver) CreateUser(ctx context.Context, input model.NewUser) (*ent.User, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
@@ -44,334 +383,2512 @@ func (r *mutationResolver) CreateUser(ctx context.Context, input model.NewUser)
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	user, err := r.userService.CreateUser(ctx, input.Name, input.Email, string(hashedPassword))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+	user, err := r.userService.CreateUser(ctx, input.Name, input.Email, string(hashedPassword))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetReadingAid is the resolver for the setReadingAid field.
+func (r *mutationResolver) SetReadingAid(ctx context.Context, userID string, readingAid model.ReadingAid) (*ent.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	user, err := r.userService.SetReadingAid(ctx, userUUID, readingAid)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// CreateReading is the resolver for the createReading field.
+func (r *mutationResolver) CreateReading(ctx context.Context, input model.NewReading) (*ent.Reading, error) {
+	reading, err := r.readingService.CreateReadingWithContent(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// UpdateReading is the resolver for the updateReading field.
+func (r *mutationResolver) UpdateReading(ctx context.Context, id string, userID string, input model.UpdateReading) (*ent.Reading, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reading, err := r.readingService.UpdateReading(ctx, readingUUID, userUUID, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// RestoreReadingRevision is the resolver for the restoreReadingRevision field.
+func (r *mutationResolver) RestoreReadingRevision(ctx context.Context, id string, userID string, number int) (*ent.Reading, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reading, err := r.readingRevisionService.RestoreRevision(ctx, readingUUID, userUUID, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore revision: %w", err)
+	}
+
+	return reading, nil
+}
+
+// UpdateReadingPublicStatus is the resolver for the updateReadingPublicStatus field.
+func (r *mutationResolver) UpdateReadingPublicStatus(ctx context.Context, id string, public bool, userID *string) (*ent.Reading, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	if userID == nil {
+		return nil, fmt.Errorf("failed to update reading: %w", services.ErrForbidden)
+	}
+	userUUID, err := uuid.Parse(*userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reading, err := r.readingService.UpdateReading(ctx, readingUUID, userUUID, model.UpdateReading{Public: &public})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// SubmitReadingForReview is the resolver for the submitReadingForReview field.
+func (r *mutationResolver) SubmitReadingForReview(ctx context.Context, id string, userID string) (*ent.Reading, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reading, err := r.publicationService.Submit(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// ReviewReading is the resolver for the reviewReading field.
+func (r *mutationResolver) ReviewReading(ctx context.Context, id string, userID string, decision readingreview.Decision, comment *string) (*ent.ReadingReview, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	review, err := r.publicationService.Review(ctx, readingUUID, userUUID, decision, comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review reading: %w", err)
+	}
+
+	return review, nil
+}
+
+// PublishReading is the resolver for the publishReading field.
+func (r *mutationResolver) PublishReading(ctx context.Context, id string, userID string) (*ent.Reading, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reading, err := r.publicationService.Publish(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// UnpublishReading is the resolver for the unpublishReading field.
+func (r *mutationResolver) UnpublishReading(ctx context.Context, id string, userID string, comment *string) (*ent.Reading, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reading, err := r.publicationService.Unpublish(ctx, readingUUID, userUUID, comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpublish reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// MarkNotificationsRead is the resolver for the markNotificationsRead field.
+func (r *mutationResolver) MarkNotificationsRead(ctx context.Context, userID string, ids []string) (int, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	var notificationIDs []uuid.UUID
+	if ids != nil {
+		notificationIDs = make([]uuid.UUID, len(ids))
+		for i, id := range ids {
+			notificationIDs[i], err = uuid.Parse(id)
+			if err != nil {
+				return 0, fmt.Errorf("invalid notification ID: %w", err)
+			}
+		}
+	}
+
+	count, err := r.notificationService.MarkRead(ctx, userUUID, notificationIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return count, nil
+}
+
+// ImportReading is the resolver for the importReading field.
+func (r *mutationResolver) ImportReading(ctx context.Context, file graphql.Upload, options model.ImportReadingOptions) (*ent.ImportJob, error) {
+	job, err := r.importService.ImportReading(ctx, file, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import reading: %w", err)
+	}
+
+	return job, nil
+}
+
+// ImportReadingFromURL is the resolver for the importReadingFromURL field.
+func (r *mutationResolver) ImportReadingFromURL(ctx context.Context, url string, options model.ImportURLOptions) (*ent.Reading, error) {
+	reading, err := r.importService.ImportReadingFromURL(ctx, url, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import reading from URL: %w", err)
+	}
+
+	return reading, nil
+}
+
+// RecordReadingProgress is the resolver for the recordReadingProgress field.
+func (r *mutationResolver) RecordReadingProgress(ctx context.Context, input model.ReadingProgressInput) (*ent.ReadingProgress, error) {
+	progress, err := r.readingProgressService.RecordProgress(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record reading progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// SaveWord is the resolver for the saveWord field.
+func (r *mutationResolver) SaveWord(ctx context.Context, input model.SaveWordInput) (*ent.VocabularyItem, error) {
+	item, err := r.vocabularyService.SaveWord(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save word: %w", err)
+	}
+
+	return item, nil
+}
+
+// UpdateWordStatus is the resolver for the updateWordStatus field.
+func (r *mutationResolver) UpdateWordStatus(ctx context.Context, id string, userID string, status *int, ignored *bool) (*ent.VocabularyItem, error) {
+	itemUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vocabulary item ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	item, err := r.vocabularyService.UpdateWordStatus(ctx, itemUUID, userUUID, status, ignored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update word status: %w", err)
+	}
+
+	return item, nil
+}
+
+// CreateHighlight is the resolver for the createHighlight field.
+func (r *mutationResolver) CreateHighlight(ctx context.Context, input model.NewHighlight) (*ent.Highlight, error) {
+	highlight, err := r.highlightService.CreateHighlight(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create highlight: %w", err)
+	}
+
+	return highlight, nil
+}
+
+// UpdateHighlight is the resolver for the updateHighlight field.
+func (r *mutationResolver) UpdateHighlight(ctx context.Context, id string, userID string, input model.UpdateHighlight) (*ent.Highlight, error) {
+	highlightUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid highlight ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	highlight, err := r.highlightService.UpdateHighlight(ctx, highlightUUID, userUUID, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update highlight: %w", err)
+	}
+
+	return highlight, nil
+}
+
+// DeleteHighlight is the resolver for the deleteHighlight field.
+func (r *mutationResolver) DeleteHighlight(ctx context.Context, id string, userID string) (bool, error) {
+	highlightUUID, err := uuid.Parse(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid highlight ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.highlightService.DeleteHighlight(ctx, highlightUUID, userUUID); err != nil {
+		return false, fmt.Errorf("failed to delete highlight: %w", err)
+	}
+
+	return true, nil
+}
+
+// AddComprehensionQuestion is the resolver for the addComprehensionQuestion field.
+func (r *mutationResolver) AddComprehensionQuestion(ctx context.Context, input model.NewComprehensionQuestion) (*ent.ComprehensionQuestion, error) {
+	question, err := r.comprehensionService.AddQuestion(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comprehension question: %w", err)
+	}
+
+	return question, nil
+}
+
+// UpdateComprehensionQuestion is the resolver for the updateComprehensionQuestion field.
+func (r *mutationResolver) UpdateComprehensionQuestion(ctx context.Context, id string, userID string, input model.UpdateComprehensionQuestion) (*ent.ComprehensionQuestion, error) {
+	questionUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid question ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	question, err := r.comprehensionService.UpdateQuestion(ctx, questionUUID, userUUID, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comprehension question: %w", err)
+	}
+
+	return question, nil
+}
+
+// DeleteComprehensionQuestion is the resolver for the deleteComprehensionQuestion field.
+func (r *mutationResolver) DeleteComprehensionQuestion(ctx context.Context, id string, userID string) (bool, error) {
+	questionUUID, err := uuid.Parse(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid question ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.comprehensionService.DeleteQuestion(ctx, questionUUID, userUUID); err != nil {
+		return false, fmt.Errorf("failed to delete comprehension question: %w", err)
+	}
+
+	return true, nil
+}
+
+// SubmitComprehensionAnswers is the resolver for the submitComprehensionAnswers field.
+func (r *mutationResolver) SubmitComprehensionAnswers(ctx context.Context, readingID string, userID string, answers []*model.ComprehensionAnswerInput) (*ent.ComprehensionAttempt, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	attempt, err := r.comprehensionService.SubmitAnswers(ctx, readingUUID, userUUID, answers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit answers: %w", err)
+	}
+
+	return attempt, nil
+}
+
+// SubmitQuizAnswers is the resolver for the submitQuizAnswers field.
+func (r *mutationResolver) SubmitQuizAnswers(ctx context.Context, readingID string, userID string, kind quizattempt.Kind, answers []*model.QuizAnswerInput) (*ent.QuizAttempt, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	attempt, err := r.quizService.SubmitQuiz(ctx, readingUUID, userUUID, kind, answers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit quiz: %w", err)
+	}
+
+	return attempt, nil
+}
+
+// TranslateSentence is the resolver for the translateSentence field.
+func (r *mutationResolver) TranslateSentence(ctx context.Context, id string, userID string, language string, text string) (*ent.SentenceTranslation, error) {
+	sentenceUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentence ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	translation, err := r.sentenceService.TranslateSentence(ctx, sentenceUUID, userUUID, language, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate sentence: %w", err)
+	}
+
+	return translation, nil
+}
+
+// ImportSentenceTranslations is the resolver for the importSentenceTranslations field.
+func (r *mutationResolver) ImportSentenceTranslations(ctx context.Context, readingID string, userID string, language string, file graphql.Upload, format *model.AlignmentFormat) (int, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	count, err := r.sentenceService.ImportTranslations(ctx, readingUUID, userUUID, language, file, format)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import sentence translations: %w", err)
+	}
+
+	return count, nil
+}
+
+// MachineTranslateReading is the resolver for the machineTranslateReading field.
+func (r *mutationResolver) MachineTranslateReading(ctx context.Context, readingID string, userID string, language string) ([]*model.SentencePair, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	pairs, err := r.sentenceService.MachineTranslateReading(ctx, readingUUID, userUUID, language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate reading: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// AttachReadingAudio is the resolver for the attachReadingAudio field.
+func (r *mutationResolver) AttachReadingAudio(ctx context.Context, readingID string, userID string, file graphql.Upload) (*ent.Reading, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reading, err := r.audioService.AttachAudio(ctx, readingUUID, userUUID, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach audio: %w", err)
+	}
+
+	return reading, nil
+}
+
+// RemoveReadingAudio is the resolver for the removeReadingAudio field.
+func (r *mutationResolver) RemoveReadingAudio(ctx context.Context, readingID string, userID string) (*ent.Reading, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reading, err := r.audioService.RemoveAudio(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove audio: %w", err)
+	}
+
+	return reading, nil
+}
+
+// ImportAudioCues is the resolver for the importAudioCues field.
+func (r *mutationResolver) ImportAudioCues(ctx context.Context, readingID string, userID string, file graphql.Upload, format *model.CueFormat) ([]*ent.AudioCue, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	cues, err := r.audioService.ImportCues(ctx, readingUUID, userUUID, file, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import audio cues: %w", err)
+	}
+
+	return cues, nil
+}
+
+// RetimeAudioCues is the resolver for the retimeAudioCues field.
+func (r *mutationResolver) RetimeAudioCues(ctx context.Context, readingID string, userID string, timings []*model.CueTiming, shiftMs *int) ([]*ent.AudioCue, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	cues, err := r.audioService.RetimeCues(ctx, readingUUID, userUUID, timings, shiftMs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retime audio cues: %w", err)
+	}
+
+	return cues, nil
+}
+
+// ImportDictionary is the resolver for the importDictionary field.
+func (r *mutationResolver) ImportDictionary(ctx context.Context, file graphql.Upload, input model.ImportDictionaryInput) (*ent.Dictionary, error) {
+	dictionary, err := r.dictionaryService.ImportDictionary(ctx, file, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import dictionary: %w", err)
+	}
+
+	return dictionary, nil
+}
+
+// DeleteDictionary is the resolver for the deleteDictionary field.
+func (r *mutationResolver) DeleteDictionary(ctx context.Context, id string, userID string) (bool, error) {
+	dictionaryUUID, err := uuid.Parse(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid dictionary ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.dictionaryService.DeleteDictionary(ctx, dictionaryUUID, userUUID); err != nil {
+		return false, fmt.Errorf("failed to delete dictionary: %w", err)
+	}
+
+	return true, nil
+}
+
+// ImportInflectionTable is the resolver for the importInflectionTable field.
+func (r *mutationResolver) ImportInflectionTable(ctx context.Context, userID string, language string, file graphql.Upload) (int, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	count, err := r.dictionaryService.ImportInflectionTable(ctx, userUUID, language, file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import inflection table: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateCourse is the resolver for the createCourse field.
+func (r *mutationResolver) CreateCourse(ctx context.Context, input model.NewCourse) (*ent.Course, error) {
+	course, err := r.courseService.CreateCourse(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create course: %w", err)
+	}
+
+	return course, nil
+}
+
+// UpdateCourse is the resolver for the updateCourse field.
+func (r *mutationResolver) UpdateCourse(ctx context.Context, id string, userID string, input model.UpdateCourse) (*ent.Course, error) {
+	courseUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid course ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	course, err := r.courseService.UpdateCourse(ctx, courseUUID, userUUID, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update course: %w", err)
+	}
+
+	return course, nil
+}
+
+// DeleteCourse is the resolver for the deleteCourse field.
+func (r *mutationResolver) DeleteCourse(ctx context.Context, id string, userID string) (bool, error) {
+	courseUUID, err := uuid.Parse(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid course ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.courseService.DeleteCourse(ctx, courseUUID, userUUID); err != nil {
+		return false, fmt.Errorf("failed to delete course: %w", err)
+	}
+
+	return true, nil
+}
+
+// AddCourseItem is the resolver for the addCourseItem field.
+func (r *mutationResolver) AddCourseItem(ctx context.Context, input model.NewCourseItem) (*ent.CourseItem, error) {
+	item, err := r.courseService.AddItem(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add course item: %w", err)
+	}
+
+	return item, nil
+}
+
+// RemoveCourseItem is the resolver for the removeCourseItem field.
+func (r *mutationResolver) RemoveCourseItem(ctx context.Context, id string, userID string) (bool, error) {
+	itemUUID, err := uuid.Parse(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid course item ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.courseService.RemoveItem(ctx, itemUUID, userUUID); err != nil {
+		return false, fmt.Errorf("failed to remove course item: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReorderCourseItems is the resolver for the reorderCourseItems field.
+func (r *mutationResolver) ReorderCourseItems(ctx context.Context, courseID string, userID string, itemIDs []string) ([]*ent.CourseItem, error) {
+	courseUUID, err := uuid.Parse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid course ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	itemUUIDs := make([]uuid.UUID, len(itemIDs))
+	for i, id := range itemIDs {
+		itemUUIDs[i], err = uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid course item ID: %w", err)
+		}
+	}
+
+	items, err := r.courseService.ReorderItems(ctx, courseUUID, userUUID, itemUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder course items: %w", err)
+	}
+
+	return items, nil
+}
+
+// EnrollInCourse is the resolver for the enrollInCourse field.
+func (r *mutationResolver) EnrollInCourse(ctx context.Context, courseID string, userID string) (*ent.CourseEnrollment, error) {
+	courseUUID, err := uuid.Parse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid course ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	enrollment, err := r.courseService.Enroll(ctx, courseUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll in course: %w", err)
+	}
+
+	return enrollment, nil
+}
+
+// LeaveCourse is the resolver for the leaveCourse field.
+func (r *mutationResolver) LeaveCourse(ctx context.Context, courseID string, userID string) (bool, error) {
+	courseUUID, err := uuid.Parse(courseID)
+	if err != nil {
+		return false, fmt.Errorf("invalid course ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.courseService.Leave(ctx, courseUUID, userUUID); err != nil {
+		return false, fmt.Errorf("failed to leave course: %w", err)
+	}
+
+	return true, nil
+}
+
+// CompleteCourseItem is the resolver for the completeCourseItem field.
+func (r *mutationResolver) CompleteCourseItem(ctx context.Context, id string, userID string) (*model.CourseProgress, error) {
+	itemUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid course item ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	progress, err := r.courseService.CompleteItem(ctx, itemUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete course item: %w", err)
+	}
+
+	return progress, nil
+}
+
+// CreateFlashcard is the resolver for the createFlashcard field.
+func (r *mutationResolver) CreateFlashcard(ctx context.Context, input model.NewFlashcard) (*ent.Flashcard, error) {
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	client := config.GetEntClient()
+	flashcard, err := client.Flashcard.
+		Create().
+		SetQuestion(input.Question).
+		SetAnswer(input.Answer).
+		SetUserID(userUUID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create flashcard: %w", err)
+	}
+
+	return flashcard, nil
+}
+
+// GenerateFlashcards is the resolver for the generateFlashcards field.
+func (r *mutationResolver) GenerateFlashcards(ctx context.Context, userID string, fromVocabulary []string, template *model.FlashcardTemplate) ([]*ent.Flashcard, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	itemIDs := make([]uuid.UUID, len(fromVocabulary))
+	for i, id := range fromVocabulary {
+		itemIDs[i], err = uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vocabulary item ID: %w", err)
+		}
+	}
+
+	kind := model.FlashcardTemplateBasic
+	if template != nil {
+		kind = *template
+	}
+
+	flashcards, err := r.flashcardService.GenerateFlashcards(ctx, userUUID, itemIDs, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate flashcards: %w", err)
+	}
+
+	return flashcards, nil
+}
+
+// UpdateFlashcard is the resolver for the updateFlashcard field.
+func (r *mutationResolver) UpdateFlashcard(ctx context.Context, id string, question string, answer string) (*ent.Flashcard, error) {
+	flashcardUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flashcard ID: %w", err)
+	}
+
+	client := config.GetEntClient()
+	flashcard, err := client.Flashcard.
+		UpdateOneID(flashcardUUID).
+		SetQuestion(question).
+		SetAnswer(answer).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update flashcard: %w", err)
+	}
+
+	return flashcard, nil
+}
+
+// UpdateFlashcardLastReviewed is the resolver for the updateFlashcardLastReviewed field.
+func (r *mutationResolver) UpdateFlashcardLastReviewed(ctx context.Context, id string) (*ent.Flashcard, error) {
+	flashcardUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flashcard ID: %w", err)
+	}
+
+	client := config.GetEntClient()
+	flashcard, err := client.Flashcard.
+		UpdateOneID(flashcardUUID).
+		SetLastReviewedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update flashcard: %w", err)
+	}
+
+	return flashcard, nil
+}
+
+// DeleteFlashcard is the resolver for the deleteFlashcard field.
+func (r *mutationResolver) DeleteFlashcard(ctx context.Context, id string) (bool, error) {
+	flashcardUUID, err := uuid.Parse(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid flashcard ID: %w", err)
+	}
+
+	client := config.GetEntClient()
+	err = client.Flashcard.DeleteOneID(flashcardUUID).Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete flashcard: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreatePost is the resolver for the createPost field.
+func (r *mutationResolver) CreatePost(ctx context.Context, input model.NewPost) (*ent.Post, error) {
+	post, err := r.postService.CreatePost(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+	return post, nil
+}
+
+// UpdatePost is the resolver for the updatePost field.
+func (r *mutationResolver) UpdatePost(ctx context.Context, id string, userID string, body string, draft bool, publishAt *string, language *string) (*ent.Post, error) {
+	postUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid post ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	var publishTime *time.Time
+	if publishAt != nil {
+		parsed, err := time.Parse(time.RFC3339, *publishAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid publishAt: %w", err)
+		}
+		publishTime = &parsed
+	}
+
+	post, err := r.postService.UpdatePost(ctx, postUUID, userUUID, body, draft, publishTime, language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update post: %w", err)
+	}
+	return post, nil
+}
+
+// DeletePost is the resolver for the deletePost field.
+func (r *mutationResolver) DeletePost(ctx context.Context, id string, userID string) (bool, error) {
+	postUUID, err := uuid.Parse(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid post ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.postService.DeletePost(ctx, postUUID, userUUID); err != nil {
+		return false, fmt.Errorf("failed to delete post: %w", err)
+	}
+	return true, nil
+}
+
+// CreateComment is the resolver for the createComment field.
+func (r *mutationResolver) CreateComment(ctx context.Context, input model.NewComment) (*ent.Comment, error) {
+	comment, err := r.commentService.CreateComment(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// UpdateComment is the resolver for the updateComment field.
+func (r *mutationResolver) UpdateComment(ctx context.Context, id string, userID string, body string) (*ent.Comment, error) {
+	commentUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid comment ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	comment, err := r.commentService.UpdateComment(ctx, commentUUID, userUUID, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// DeleteComment is the resolver for the deleteComment field.
+func (r *mutationResolver) DeleteComment(ctx context.Context, id string, userID string) (bool, error) {
+	commentUUID, err := uuid.Parse(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid comment ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.commentService.DeleteComment(ctx, commentUUID, userUUID); err != nil {
+		return false, fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return true, nil
+}
+
+// SetNativeLanguages is the resolver for the setNativeLanguages field.
+func (r *mutationResolver) SetNativeLanguages(ctx context.Context, userID string, languages []string) (*ent.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	user, err := r.userService.SetNativeLanguages(ctx, userUUID, languages)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// SetTargetLanguage is the resolver for the setTargetLanguage field.
+func (r *mutationResolver) SetTargetLanguage(ctx context.Context, userID string, language *string) (*ent.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	user, err := r.userService.SetTargetLanguage(ctx, userUUID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// FollowUser is the resolver for the followUser field.
+func (r *mutationResolver) FollowUser(ctx context.Context, userID string, followeeID string) (*ent.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	followeeUUID, err := uuid.Parse(followeeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid followee ID: %w", err)
+	}
+
+	followee, err := r.followService.Follow(ctx, userUUID, followeeUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow user: %w", err)
+	}
+
+	return followee, nil
+}
+
+// UnfollowUser is the resolver for the unfollowUser field.
+func (r *mutationResolver) UnfollowUser(ctx context.Context, userID string, followeeID string) (*ent.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	followeeUUID, err := uuid.Parse(followeeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid followee ID: %w", err)
+	}
+
+	followee, err := r.followService.Unfollow(ctx, userUUID, followeeUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unfollow user: %w", err)
+	}
+
+	return followee, nil
+}
+
+// SubmitCorrection is the resolver for the submitCorrection field.
+func (r *mutationResolver) SubmitCorrection(ctx context.Context, input model.NewCorrection) (*ent.Correction, error) {
+	correction, err := r.correctionService.SubmitCorrection(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit correction: %w", err)
+	}
+
+	return correction, nil
+}
+
+// AcceptCorrection is the resolver for the acceptCorrection field.
+func (r *mutationResolver) AcceptCorrection(ctx context.Context, id string, userID string) (*ent.Correction, error) {
+	correctionUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid correction ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	correction, err := r.correctionService.AcceptCorrection(ctx, correctionUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept correction: %w", err)
+	}
+
+	return correction, nil
+}
+
+// ThankCorrection is the resolver for the thankCorrection field.
+func (r *mutationResolver) ThankCorrection(ctx context.Context, id string, userID string) (*ent.Correction, error) {
+	correctionUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid correction ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	correction, err := r.correctionService.ThankCorrection(ctx, correctionUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to thank corrector: %w", err)
+	}
+
+	return correction, nil
+}
+
+// ToggleReaction is the resolver for the toggleReaction field.
+func (r *mutationResolver) ToggleReaction(ctx context.Context, userID string, target model.ContentTarget, kind reaction.Kind) (bool, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+	contentTarget, err := services.ParseTarget(target)
+	if err != nil {
+		return false, fmt.Errorf("invalid target: %w", err)
+	}
+
+	reacted, err := r.reactionService.ToggleReaction(ctx, userUUID, contentTarget, kind)
+	if err != nil {
+		return false, fmt.Errorf("failed to toggle reaction: %w", err)
+	}
+	return reacted, nil
+}
+
+// ToggleBookmark is the resolver for the toggleBookmark field.
+func (r *mutationResolver) ToggleBookmark(ctx context.Context, userID string, target model.ContentTarget) (bool, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+	contentTarget, err := services.ParseTarget(target)
+	if err != nil {
+		return false, fmt.Errorf("invalid target: %w", err)
+	}
+
+	bookmarked, err := r.bookmarkService.ToggleBookmark(ctx, userUUID, contentTarget)
+	if err != nil {
+		return false, fmt.Errorf("failed to toggle bookmark: %w", err)
+	}
+	return bookmarked, nil
+}
+
+// ID is the resolver for the id field.
+func (r *notificationResolver) ID(ctx context.Context, obj *ent.Notification) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Read is the resolver for the read field.
+func (r *notificationResolver) Read(ctx context.Context, obj *ent.Notification) (bool, error) {
+	return obj.ReadAt != nil, nil
+}
+
+// ReadAt is the resolver for the readAt field.
+func (r *notificationResolver) ReadAt(ctx context.Context, obj *ent.Notification) (*string, error) {
+	if obj.ReadAt == nil {
+		return nil, nil
+	}
+	formatted := obj.ReadAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *notificationResolver) CreatedAt(ctx context.Context, obj *ent.Notification) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *postResolver) ID(ctx context.Context, obj *ent.Post) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Sentences is the resolver for the sentences field.
+func (r *postResolver) Sentences(ctx context.Context, obj *ent.Post) ([]*model.PostSentence, error) {
+	return services.PostSentences(obj), nil
+}
+
+// Corrections is the resolver for the corrections field.
+func (r *postResolver) Corrections(ctx context.Context, obj *ent.Post) ([]*ent.Correction, error) {
+	corrections, err := r.correctionService.GetCorrections(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get corrections: %w", err)
+	}
+	return corrections, nil
+}
+
+// PublishAt is the resolver for the publishAt field.
+func (r *postResolver) PublishAt(ctx context.Context, obj *ent.Post) (*string, error) {
+	if obj.PublishAt == nil {
+		return nil, nil
+	}
+	formatted := obj.PublishAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
+// PublishedAt is the resolver for the publishedAt field.
+func (r *postResolver) PublishedAt(ctx context.Context, obj *ent.Post) (*string, error) {
+	if obj.PublishedAt == nil {
+		return nil, nil
+	}
+	formatted := obj.PublishedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *postResolver) CreatedAt(ctx context.Context, obj *ent.Post) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *postResolver) UpdatedAt(ctx context.Context, obj *ent.Post) (string, error) {
+	return obj.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// Comments is the resolver for the comments field.
+func (r *postResolver) Comments(ctx context.Context, obj *ent.Post, limit *int, offset *int) ([]*ent.Comment, error) {
+	n, skip := 20, 0
+	if limit != nil {
+		n = *limit
+	}
+	if offset != nil {
+		skip = *offset
+	}
+
+	comments, err := r.commentService.GetPostComments(ctx, obj.ID, n, skip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	return comments, nil
+}
+
+// CommentCount is the resolver for the commentCount field.
+func (r *postResolver) CommentCount(ctx context.Context, obj *ent.Post) (int, error) {
+	count, err := r.commentService.CountPostComments(ctx, obj.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+	return count, nil
+}
+
+// Reactions is the resolver for the reactions field.
+func (r *postResolver) Reactions(ctx context.Context, obj *ent.Post, viewerID *string) ([]*model.ReactionCount, error) {
+	var viewer *uuid.UUID
+	if viewerID != nil {
+		viewerUUID, err := uuid.Parse(*viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid viewer ID: %w", err)
+		}
+		viewer = &viewerUUID
+	}
+
+	counts, err := r.reactionService.GetReactionCounts(ctx, services.PostTarget(obj.ID), viewer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions: %w", err)
+	}
+	return counts, nil
+}
+
+// ReactionCount is the resolver for the reactionCount field.
+func (r *postResolver) ReactionCount(ctx context.Context, obj *ent.Post) (int, error) {
+	count, err := r.reactionService.CountReactions(ctx, services.PostTarget(obj.ID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	return count, nil
+}
+
+// Bookmarked is the resolver for the bookmarked field.
+func (r *postResolver) Bookmarked(ctx context.Context, obj *ent.Post, viewerID *string) (bool, error) {
+	var viewer *uuid.UUID
+	if viewerID != nil {
+		viewerUUID, err := uuid.Parse(*viewerID)
+		if err != nil {
+			return false, fmt.Errorf("invalid viewer ID: %w", err)
+		}
+		viewer = &viewerUUID
+	}
+
+	bookmarked, err := r.bookmarkService.IsBookmarked(ctx, services.PostTarget(obj.ID), viewer)
+	if err != nil {
+		return false, fmt.Errorf("failed to get bookmark: %w", err)
+	}
+	return bookmarked, nil
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id string) (*ent.User, error) {
+	userUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	client := config.GetEntClient()
+	user, err := client.User.Get(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context) ([]*ent.User, error) {
+	client := config.GetEntClient()
+	users, err := client.User.Query().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+// Admins is the resolver for the admins field.
+func (r *queryResolver) Admins(ctx context.Context) ([]*ent.User, error) {
+	client := config.GetEntClient()
+	users, err := client.User.Query().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	return users, nil
+}
+
+// Readings is the resolver for the readings field.
+func (r *queryResolver) Readings(ctx context.Context) ([]*ent.Reading, error) {
+	client := config.GetEntClient()
+	readings, err := client.Reading.Query().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get readings: %w", err)
+	}
+
+	return readings, nil
+}
+
+// Reading is the resolver for the reading field.
+func (r *queryResolver) Reading(ctx context.Context, id string, userID *string) (*ent.Reading, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+
+	var viewer *uuid.UUID
+	if userID != nil {
+		userUUID, err := uuid.Parse(*userID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %w", err)
+		}
+		viewer = &userUUID
+	}
+
+	reading, err := r.readingService.GetReadingForUser(ctx, readingUUID, viewer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// PublicReadings is the resolver for the publicReadings field.
+func (r *queryResolver) PublicReadings(ctx context.Context, filter *model.ReadingFilter, orderByLevel *entgql.OrderDirection) ([]*ent.Reading, error) {
+	readings, err := r.readingService.GetPublicReadingsFiltered(ctx, filter, orderByLevel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public readings: %w", err)
+	}
+
+	return readings, nil
+}
+
+// UserReadings is the resolver for the userReadings field.
+func (r *queryResolver) UserReadings(ctx context.Context, userID string) ([]*ent.Reading, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	client := config.GetEntClient()
+	readings, err := client.Reading.Query().
+		Where(reading.UserID(userUUID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user readings: %w", err)
+	}
+
+	return readings, nil
+}
+
+// ReadingProgress is the resolver for the readingProgress field.
+func (r *queryResolver) ReadingProgress(ctx context.Context, readingID string, userID string) (*ent.ReadingProgress, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	progress, err := r.readingProgressService.GetProgress(ctx, userUUID, readingUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// ReadingTokens is the resolver for the readingTokens field.
+func (r *queryResolver) ReadingTokens(ctx context.Context, readingID string, userID string) (*model.ReadingTokens, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	tokens, err := r.readingTokenService.GetReadingTokens(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// ReadingRevisions is the resolver for the readingRevisions field.
+func (r *queryResolver) ReadingRevisions(ctx context.Context, id string, userID string) ([]*ent.ReadingRevision, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	revisions, err := r.readingRevisionService.GetRevisions(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// ReadingRevisionDiff is the resolver for the readingRevisionDiff field.
+func (r *queryResolver) ReadingRevisionDiff(ctx context.Context, id string, userID string, from int, to int, granularity *model.DiffGranularity) (*model.ReadingRevisionDiff, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	by := model.DiffGranularityLine
+	if granularity != nil {
+		by = *granularity
+	}
+
+	diff, err := r.readingRevisionService.DiffRevisions(ctx, readingUUID, userUUID, from, to, by)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff revisions: %w", err)
+	}
+
+	return diff, nil
+}
+
+// ReviewQueue is the resolver for the reviewQueue field.
+func (r *queryResolver) ReviewQueue(ctx context.Context, userID string, status *reading.PublicationStatus) ([]*ent.Reading, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	queued := reading.PublicationStatusSUBMITTED
+	if status != nil {
+		queued = *status
+	}
+
+	readings, err := r.publicationService.ReviewQueue(ctx, userUUID, queued)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	return readings, nil
+}
+
+// ReadingReviews is the resolver for the readingReviews field.
+func (r *queryResolver) ReadingReviews(ctx context.Context, id string, userID string) ([]*ent.ReadingReview, error) {
+	readingUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	reviews, err := r.publicationService.GetReviews(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// Notifications is the resolver for the notifications field.
+func (r *queryResolver) Notifications(ctx context.Context, userID string, unreadOnly *bool, limit *int) ([]*ent.Notification, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	n := 50
+	if limit != nil {
+		n = *limit
+	}
+
+	notifications, err := r.notificationService.GetNotifications(ctx, userUUID, unreadOnly != nil && *unreadOnly, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// UnreadNotificationCount is the resolver for the unreadNotificationCount field.
+func (r *queryResolver) UnreadNotificationCount(ctx context.Context, userID string) (int, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	count, err := r.notificationService.CountUnread(ctx, userUUID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// RecommendedReadings is the resolver for the recommendedReadings field.
+func (r *queryResolver) RecommendedReadings(ctx context.Context, userID string, language string, targetCoverage *float64, limit *int) ([]*model.ReadingRecommendation, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	recommendations, err := r.recommendationService.RecommendReadings(ctx, userUUID, language, targetCoverage, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recommend readings: %w", err)
+	}
+
+	return recommendations, nil
+}
+
+// UserReadingProgress is the resolver for the userReadingProgress field.
+func (r *queryResolver) UserReadingProgress(ctx context.Context, userID string) ([]*ent.ReadingProgress, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	progress, err := r.readingProgressService.GetProgressByUser(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user reading progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// Flashcards is the resolver for the flashcards field.
+func (r *queryResolver) Flashcards(ctx context.Context) ([]*ent.Flashcard, error) {
+	client := config.GetEntClient()
+	flashcards, err := client.Flashcard.Query().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flashcards: %w", err)
+	}
+
+	return flashcards, nil
+}
+
+// UserFlashcards is the resolver for the userFlashcards field.
+func (r *queryResolver) UserFlashcards(ctx context.Context, userID string) ([]*ent.Flashcard, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	client := config.GetEntClient()
+	flashcards, err := client.Flashcard.Query().
+		Where(flashcard.UserID(userUUID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user flashcards: %w", err)
+	}
+
+	return flashcards, nil
+}
+
+// FlashcardsForReview is the resolver for the flashcardsForReview field.
+func (r *queryResolver) FlashcardsForReview(ctx context.Context, userID string, daysSince *int) ([]*ent.Flashcard, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	client := config.GetEntClient()
+	query := client.Flashcard.Query().Where(flashcard.UserID(userUUID))
+
+	if daysSince != nil {
+		reviewThreshold := time.Now().AddDate(0, 0, -(*daysSince))
+		query = query.Where(flashcard.LastReviewedAtLT(reviewThreshold))
+	}
+
+	flashcards, err := query.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get flashcards for review: %w", err)
+	}
+
+	return flashcards, nil
+}
+
+// MyVocabulary is the resolver for the myVocabulary field.
+func (r *queryResolver) MyVocabulary(ctx context.Context, userID string, filter *model.VocabularyFilter) ([]*ent.VocabularyItem, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	items, err := r.vocabularyService.GetVocabulary(ctx, userUUID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vocabulary: %w", err)
+	}
+
+	return items, nil
+}
+
+// ImportJob is the resolver for the importJob field.
+func (r *queryResolver) ImportJob(ctx context.Context, id string, userID string) (*ent.ImportJob, error) {
+	jobUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	job, err := r.importService.GetImportJob(ctx, jobUUID, userUUID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	return job, nil
+}
+
+// Highlights is the resolver for the highlights field.
+func (r *queryResolver) Highlights(ctx context.Context, readingID string, userID string) ([]*ent.Highlight, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	highlights, err := r.highlightService.GetHighlights(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get highlights: %w", err)
+	}
+
+	return highlights, nil
+}
+
+// ComprehensionQuestions is the resolver for the comprehensionQuestions field.
+func (r *queryResolver) ComprehensionQuestions(ctx context.Context, readingID string, userID string) ([]*ent.ComprehensionQuestion, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	questions, err := r.comprehensionService.GetQuestions(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comprehension questions: %w", err)
+	}
+
+	return questions, nil
+}
+
+// ComprehensionAttempts is the resolver for the comprehensionAttempts field.
+func (r *queryResolver) ComprehensionAttempts(ctx context.Context, readingID string, userID string) ([]*ent.ComprehensionAttempt, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	attempts, err := r.comprehensionService.GetAttempts(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comprehension attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// ComprehensionResults is the resolver for the comprehensionResults field.
+func (r *queryResolver) ComprehensionResults(ctx context.Context, readingID string, userID string) (*model.ComprehensionResults, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	results, err := r.comprehensionService.GetResults(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comprehension results: %w", err)
+	}
+
+	return results, nil
+}
+
+// GenerateQuiz is the resolver for the generateQuiz field.
+func (r *queryResolver) GenerateQuiz(ctx context.Context, readingID string, userID string, count *int, kind *quizattempt.Kind) (*model.Quiz, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	n := 10
+	if count != nil {
+		n = *count
+	}
+	quizKind := quizattempt.KindMULTIPLE_CHOICE
+	if kind != nil {
+		quizKind = *kind
+	}
+
+	quiz, err := r.quizService.GenerateQuiz(ctx, readingUUID, userUUID, n, quizKind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate quiz: %w", err)
+	}
+
+	return quiz, nil
+}
+
+// QuizAttempts is the resolver for the quizAttempts field.
+func (r *queryResolver) QuizAttempts(ctx context.Context, readingID string, userID string) ([]*ent.QuizAttempt, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	attempts, err := r.quizService.GetAttempts(ctx, readingUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// ReadingSentences is the resolver for the readingSentences field.
+func (r *queryResolver) ReadingSentences(ctx context.Context, readingID string, userID *string) ([]*ent.ReadingSentence, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+
+	var viewer *uuid.UUID
+	if userID != nil {
+		userUUID, err := uuid.Parse(*userID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %w", err)
+		}
+		viewer = &userUUID
+	}
+
+	sentences, err := r.sentenceService.GetSentences(ctx, readingUUID, viewer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sentences: %w", err)
+	}
+
+	return sentences, nil
+}
+
+// AlignedSentences is the resolver for the alignedSentences field.
+func (r *queryResolver) AlignedSentences(ctx context.Context, readingID string, userID string, language string) ([]*model.SentencePair, error) {
+	readingUUID, err := uuid.Parse(readingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	pairs, err := r.sentenceService.GetAlignedSentences(ctx, readingUUID, userUUID, language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aligned sentences: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// Translate is the resolver for the translate field.
+func (r *queryResolver) Translate(ctx context.Context, text string, from string, to string, userID string) (*model.Translation, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	translation, err := r.translationService.Translate(ctx, userUUID, text, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate: %w", err)
+	}
+
+	return translation, nil
+}
+
+// LookupWord is the resolver for the lookupWord field.
+func (r *queryResolver) LookupWord(ctx context.Context, term string, from string, to string) (*model.WordLookup, error) {
+	lookup, err := r.dictionaryService.LookupWord(ctx, term, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up word: %w", err)
+	}
+
+	return lookup, nil
+}
+
+// Dictionaries is the resolver for the dictionaries field.
+func (r *queryResolver) Dictionaries(ctx context.Context, sourceLanguage *string, targetLanguage *string) ([]*ent.Dictionary, error) {
+	dictionaries, err := r.dictionaryService.GetDictionaries(ctx, sourceLanguage, targetLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dictionaries: %w", err)
+	}
+
+	return dictionaries, nil
+}
+
+// Courses is the resolver for the courses field.
+func (r *queryResolver) Courses(ctx context.Context, filter *model.CourseFilter) ([]*ent.Course, error) {
+	courses, err := r.courseService.GetPublishedCourses(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courses: %w", err)
+	}
+
+	return courses, nil
+}
+
+// Course is the resolver for the course field.
+func (r *queryResolver) Course(ctx context.Context, id string, userID *string) (*ent.Course, error) {
+	courseUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid course ID: %w", err)
+	}
+
+	var viewer *uuid.UUID
+	if userID != nil {
+		userUUID, err := uuid.Parse(*userID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %w", err)
+		}
+		viewer = &userUUID
+	}
+
+	course, err := r.courseService.GetCourseForUser(ctx, courseUUID, viewer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course: %w", err)
+	}
+
+	return course, nil
+}
+
+// UserCourses is the resolver for the userCourses field.
+func (r *queryResolver) UserCourses(ctx context.Context, userID string) ([]*ent.Course, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	courses, err := r.courseService.GetCoursesByOwner(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user courses: %w", err)
+	}
+
+	return courses, nil
+}
+
+// EnrolledCourses is the resolver for the enrolledCourses field.
+func (r *queryResolver) EnrolledCourses(ctx context.Context, userID string) ([]*ent.Course, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	courses, err := r.courseService.GetEnrolledCourses(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrolled courses: %w", err)
+	}
+
+	return courses, nil
+}
+
+// CourseProgress is the resolver for the courseProgress field.
+func (r *queryResolver) CourseProgress(ctx context.Context, courseID string, userID string) (*model.CourseProgress, error) {
+	courseUUID, err := uuid.Parse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid course ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	progress, err := r.courseService.GetProgress(ctx, courseUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// NextCourseItem is the resolver for the nextCourseItem field.
+func (r *queryResolver) NextCourseItem(ctx context.Context, courseID string, userID string) (*ent.CourseItem, error) {
+	courseUUID, err := uuid.Parse(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid course ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	progress, err := r.courseService.GetProgress(ctx, courseUUID, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course progress: %w", err)
+	}
+
+	return progress.NextItem, nil
+}
+
+// Posts is the resolver for the posts field.
+func (r *queryResolver) Posts(ctx context.Context) ([]*ent.Post, error) {
+	posts, err := r.postService.GetPublishedPosts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// UserPosts is the resolver for the userPosts field.
+func (r *queryResolver) UserPosts(ctx context.Context, userID string, viewerID *string) ([]*ent.Post, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	var viewer *uuid.UUID
+	if viewerID != nil {
+		viewerUUID, err := uuid.Parse(*viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid viewer ID: %w", err)
+		}
+		viewer = &viewerUUID
+	}
+
+	posts, err := r.postService.GetPostsByUser(ctx, userUUID, viewer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user posts: %w", err)
+	}
+	return posts, nil
+}
+
+// Comment is the resolver for the comment field.
+func (r *queryResolver) Comment(ctx context.Context, id string) (*ent.Comment, error) {
+	commentUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid comment ID: %w", err)
+	}
+
+	comment, err := r.commentService.GetComment(ctx, commentUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// PostsToCorrect is the resolver for the postsToCorrect field.
+func (r *queryResolver) PostsToCorrect(ctx context.Context, userID string, limit *int) ([]*ent.Post, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	n := 20
+	if limit != nil {
+		n = *limit
+	}
+
+	posts, err := r.correctionService.PostsToCorrect(ctx, userUUID, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts to correct: %w", err)
+	}
+
+	return posts, nil
+}
+
+// CorrectionHelpers is the resolver for the correctionHelpers field.
+func (r *queryResolver) CorrectionHelpers(ctx context.Context, postID string, limit *int) ([]*ent.User, error) {
+	postUUID, err := uuid.Parse(postID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid post ID: %w", err)
+	}
+
+	n := 20
+	if limit != nil {
+		n = *limit
+	}
+
+	helpers, err := r.correctionService.CorrectionHelpers(ctx, postUUID, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get correction helpers: %w", err)
+	}
+
+	return helpers, nil
+}
+
+// MyBookmarks is the resolver for the myBookmarks field.
+func (r *queryResolver) MyBookmarks(ctx context.Context, userID string, limit *int, offset *int) ([]*ent.Bookmark, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	n, skip := 20, 0
+	if limit != nil {
+		n = *limit
+	}
+	if offset != nil {
+		skip = *offset
+	}
+
+	bookmarks, err := r.bookmarkService.GetBookmarks(ctx, userUUID, n, skip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookmarks: %w", err)
+	}
+	return bookmarks, nil
+}
+
+// Feed is the resolver for the feed field.
+func (r *queryResolver) Feed(ctx context.Context, userID string, after *string, first *int) (*model.FeedPage, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	n := 20
+	if first != nil {
+		n = *first
+	}
+
+	feed, err := r.feedService.GetFeed(ctx, userUUID, after, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// Followers is the resolver for the followers field.
+func (r *queryResolver) Followers(ctx context.Context, userID string, limit *int, offset *int) ([]*ent.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	n, skip := 20, 0
+	if limit != nil {
+		n = *limit
+	}
+	if offset != nil {
+		skip = *offset
+	}
+
+	users, err := r.followService.GetFollowers(ctx, userUUID, n, skip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers: %w", err)
+	}
+	return users, nil
+}
+
+// Following is the resolver for the following field.
+func (r *queryResolver) Following(ctx context.Context, userID string, limit *int, offset *int) ([]*ent.User, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	n, skip := 20, 0
+	if limit != nil {
+		n = *limit
+	}
+	if offset != nil {
+		skip = *offset
+	}
+
+	users, err := r.followService.GetFollowing(ctx, userUUID, n, skip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followed users: %w", err)
+	}
+	return users, nil
+}
+
+// ID is the resolver for the id field.
+func (r *quizAnswerResolver) ID(ctx context.Context, obj *ent.QuizAnswer) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// ID is the resolver for the id field.
+func (r *quizAttemptResolver) ID(ctx context.Context, obj *ent.QuizAttempt) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// Percentage is the resolver for the percentage field.
+func (r *quizAttemptResolver) Percentage(ctx context.Context, obj *ent.QuizAttempt) (float64, error) {
+	return services.QuizPercentage(obj), nil
+}
+
+// CreatedAt is the resolver for the createdAt field.
+func (r *quizAttemptResolver) CreatedAt(ctx context.Context, obj *ent.QuizAttempt) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// ID is the resolver for the id field.
+func (r *readingResolver) ID(ctx context.Context, obj *ent.Reading) (string, error) {
+	return obj.ID.String(), nil
+}
+
+// SubmittedAt is the resolver for the submittedAt field.
+func (r *readingResolver) SubmittedAt(ctx context.Context, obj *ent.Reading) (*string, error) {
+	if obj.SubmittedAt == nil {
+		return nil, nil
+	}
+	formatted := obj.SubmittedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
+
+// PublishedAt is the resolver for the publishedAt field.
+func (r *readingResolver) PublishedAt(ctx context.Context, obj *ent.Reading) (*string, error) {
+	if obj.PublishedAt == nil {
+		return nil, nil
 	}
+	formatted := obj.PublishedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
+}
 
-	return user, nil
+// CreatedAt is the resolver for the createdAt field.
+func (r *readingResolver) CreatedAt(ctx context.Context, obj *ent.Reading) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
 }
 
-// CreateReading is the resolver for the createReading field.
-func (r *mutationResolver) CreateReading(ctx context.Context, input model.NewReading) (*ent.Reading, error) {
-	userUUID, err := uuid.Parse(input.UserID)
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *readingResolver) UpdatedAt(ctx context.Context, obj *ent.Reading) (string, error) {
+	return obj.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
+
+// Progress is the resolver for the progress field.
+func (r *readingResolver) Progress(ctx context.Context, obj *ent.Reading, userID string) (*ent.ReadingProgress, error) {
+	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	client := config.GetEntClient()
-	reading, err := client.Reading.
-		Create().
-		SetTitle(input.Title).
-		SetUserID(userUUID).
-		SetFinished(false).
-		SetPublic(false).
-		Save(ctx)
+	progress, err := r.readingProgressService.GetProgress(ctx, userUUID, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create reading: %w", err)
+		return nil, fmt.Errorf("failed to get reading progress: %w", err)
 	}
 
-	return reading, nil
+	return progress, nil
 }
 
-// UpdateReadingPublicStatus is the resolver for the updateReadingPublicStatus field.
-func (r *mutationResolver) UpdateReadingPublicStatus(ctx context.Context, id string, public bool) (*ent.Reading, error) {
-	readingUUID, err := uuid.Parse(id)
+// Chapters is the resolver for the chapters field.
+func (r *readingResolver) Chapters(ctx context.Context, obj *ent.Reading) ([]*ent.Reading, error) {
+	chapters, err := r.importService.GetChapters(ctx, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid reading ID: %w", err)
+		return nil, fmt.Errorf("failed to get chapters: %w", err)
 	}
 
-	client := config.GetEntClient()
-	reading, err := client.Reading.
-		UpdateOneID(readingUUID).
-		SetPublic(public).
-		Save(ctx)
+	return chapters, nil
+}
+
+// AudioCues is the resolver for the audioCues field.
+func (r *readingResolver) AudioCues(ctx context.Context, obj *ent.Reading) ([]*ent.AudioCue, error) {
+	cues, err := r.audioService.GetCues(ctx, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update reading: %w", err)
+		return nil, fmt.Errorf("failed to get audio cues: %w", err)
 	}
 
-	return reading, nil
+	return cues, nil
 }
 
-// CreateFlashcard is the resolver for the createFlashcard field.
-func (r *mutationResolver) CreateFlashcard(ctx context.Context, input model.NewFlashcard) (*ent.Flashcard, error) {
-	userUUID, err := uuid.Parse(input.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+// Comments is the resolver for the comments field.
+func (r *readingResolver) Comments(ctx context.Context, obj *ent.Reading, limit *int, offset *int) ([]*ent.Comment, error) {
+	n, skip := 20, 0
+	if limit != nil {
+		n = *limit
+	}
+	if offset != nil {
+		skip = *offset
 	}
 
-	client := config.GetEntClient()
-	flashcard, err := client.Flashcard.
-		Create().
-		SetQuestion(input.Question).
-		SetAnswer(input.Answer).
-		SetUserID(userUUID).
-		Save(ctx)
+	comments, err := r.commentService.GetReadingComments(ctx, obj.ID, n, skip)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create flashcard: %w", err)
+		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
-
-	return flashcard, nil
+	return comments, nil
 }
 
-// UpdateFlashcard is the resolver for the updateFlashcard field.
-func (r *mutationResolver) UpdateFlashcard(ctx context.Context, id string, question string, answer string) (*ent.Flashcard, error) {
-	flashcardUUID, err := uuid.Parse(id)
+// CommentCount is the resolver for the commentCount field.
+func (r *readingResolver) CommentCount(ctx context.Context, obj *ent.Reading) (int, error) {
+	count, err := r.commentService.CountReadingComments(ctx, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid flashcard ID: %w", err)
+		return 0, fmt.Errorf("failed to count comments: %w", err)
 	}
+	return count, nil
+}
 
-	client := config.GetEntClient()
-	flashcard, err := client.Flashcard.
-		UpdateOneID(flashcardUUID).
-		SetQuestion(question).
-		SetAnswer(answer).
-		Save(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to update flashcard: %w", err)
+// Reactions is the resolver for the reactions field.
+func (r *readingResolver) Reactions(ctx context.Context, obj *ent.Reading, viewerID *string) ([]*model.ReactionCount, error) {
+	var viewer *uuid.UUID
+	if viewerID != nil {
+		viewerUUID, err := uuid.Parse(*viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid viewer ID: %w", err)
+		}
+		viewer = &viewerUUID
 	}
 
-	return flashcard, nil
-}
-
-// UpdateFlashcardLastReviewed is the resolver for the updateFlashcardLastReviewed field.
-func (r *mutationResolver) UpdateFlashcardLastReviewed(ctx context.Context, id string) (*ent.Flashcard, error) {
-	flashcardUUID, err := uuid.Parse(id)
+	counts, err := r.reactionService.GetReactionCounts(ctx, services.ReadingTarget(obj.ID), viewer)
 	if err != nil {
-		return nil, fmt.Errorf("invalid flashcard ID: %w", err)
+		return nil, fmt.Errorf("failed to get reactions: %w", err)
 	}
+	return counts, nil
+}
 
-	client := config.GetEntClient()
-	flashcard, err := client.Flashcard.
-		UpdateOneID(flashcardUUID).
-		SetLastReviewedAt(time.Now()).
-		Save(ctx)
+// ReactionCount is the resolver for the reactionCount field.
+func (r *readingResolver) ReactionCount(ctx context.Context, obj *ent.Reading) (int, error) {
+	count, err := r.reactionService.CountReactions(ctx, services.ReadingTarget(obj.ID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to update flashcard: %w", err)
+		return 0, fmt.Errorf("failed to count reactions: %w", err)
 	}
-
-	return flashcard, nil
+	return count, nil
 }
 
-// DeleteFlashcard is the resolver for the deleteFlashcard field.
-func (r *mutationResolver) DeleteFlashcard(ctx context.Context, id string) (bool, error) {
-	flashcardUUID, err := uuid.Parse(id)
-	if err != nil {
-		return false, fmt.Errorf("invalid flashcard ID: %w", err)
+// Bookmarked is the resolver for the bookmarked field.
+func (r *readingResolver) Bookmarked(ctx context.Context, obj *ent.Reading, viewerID *string) (bool, error) {
+	var viewer *uuid.UUID
+	if viewerID != nil {
+		viewerUUID, err := uuid.Parse(*viewerID)
+		if err != nil {
+			return false, fmt.Errorf("invalid viewer ID: %w", err)
+		}
+		viewer = &viewerUUID
 	}
 
-	client := config.GetEntClient()
-	err = client.Flashcard.DeleteOneID(flashcardUUID).Exec(ctx)
+	bookmarked, err := r.bookmarkService.IsBookmarked(ctx, services.ReadingTarget(obj.ID), viewer)
 	if err != nil {
-		return false, fmt.Errorf("failed to delete flashcard: %w", err)
+		return false, fmt.Errorf("failed to get bookmark: %w", err)
 	}
+	return bookmarked, nil
+}
 
-	return true, nil
+// ID is the resolver for the id field.
+func (r *readingProgressResolver) ID(ctx context.Context, obj *ent.ReadingProgress) (string, error) {
+	return obj.ID.String(), nil
 }
 
-// CreatePost is the resolver for the createPost field.
-func (r *mutationResolver) CreatePost(ctx context.Context, input model.NewPost) (*ent.Post, error) {
-	post, err := r.postService.CreatePost(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create post: %w", err)
-	}
-	return post, nil
+// LastOpenedAt is the resolver for the lastOpenedAt field.
+func (r *readingProgressResolver) LastOpenedAt(ctx context.Context, obj *ent.ReadingProgress) (string, error) {
+	return obj.LastOpenedAt.Format("2006-01-02T15:04:05Z07:00"), nil
 }
 
-// UpdatePost is the resolver for the updatePost field.
-func (r *mutationResolver) UpdatePost(ctx context.Context, id string, body string, draft bool) (*ent.Post, error) {
-	panic(fmt.Errorf("not implemented: UpdatePost - updatePost"))
+// FinishedAt is the resolver for the finishedAt field.
+func (r *readingProgressResolver) FinishedAt(ctx context.Context, obj *ent.ReadingProgress) (*string, error) {
+	if obj.FinishedAt == nil {
+		return nil, nil
+	}
+	formatted := obj.FinishedAt.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted, nil
 }
 
-// DeletePost is the resolver for the deletePost field.
-func (r *mutationResolver) DeletePost(ctx context.Context, id string) (bool, error) {
-	panic(fmt.Errorf("not implemented: DeletePost - deletePost"))
+// Finished is the resolver for the finished field.
+func (r *readingProgressResolver) Finished(ctx context.Context, obj *ent.ReadingProgress) (bool, error) {
+	return obj.FinishedAt != nil, nil
 }
 
 // ID is the resolver for the id field.
-func (r *postResolver) ID(ctx context.Context, obj *ent.Post) (string, error) {
-	panic(fmt.Errorf("not implemented: ID - id"))
+func (r *readingReviewResolver) ID(ctx context.Context, obj *ent.ReadingReview) (string, error) {
+	return obj.ID.String(), nil
 }
 
-// User is the resolver for the user field.
-func (r *queryResolver) User(ctx context.Context, id string) (*ent.User, error) {
-	userUUID, err := uuid.Parse(id)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
-	}
+// CreatedAt is the resolver for the createdAt field.
+func (r *readingReviewResolver) CreatedAt(ctx context.Context, obj *ent.ReadingReview) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
 
-	client := config.GetEntClient()
-	user, err := client.User.Get(ctx, userUUID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
-	}
+// ID is the resolver for the id field.
+func (r *readingRevisionResolver) ID(ctx context.Context, obj *ent.ReadingRevision) (string, error) {
+	return obj.ID.String(), nil
+}
 
-	return user, nil
+// Format is the resolver for the format field.
+func (r *readingRevisionResolver) Format(ctx context.Context, obj *ent.ReadingRevision) (reading.Format, error) {
+	return reading.Format(obj.Format), nil
 }
 
-// Users is the resolver for the users field.
-func (r *queryResolver) Users(ctx context.Context) ([]*ent.User, error) {
-	client := config.GetEntClient()
-	users, err := client.User.Query().All(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get users: %w", err)
-	}
+// CreatedAt is the resolver for the createdAt field.
+func (r *readingRevisionResolver) CreatedAt(ctx context.Context, obj *ent.ReadingRevision) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
 
-	return users, nil
+// ID is the resolver for the id field.
+func (r *readingSentenceResolver) ID(ctx context.Context, obj *ent.ReadingSentence) (string, error) {
+	return obj.ID.String(), nil
 }
 
-// Admins is the resolver for the admins field.
-func (r *queryResolver) Admins(ctx context.Context) ([]*ent.User, error) {
-	client := config.GetEntClient()
-	users, err := client.User.Query().All(ctx)
+// Translations is the resolver for the translations field.
+func (r *readingSentenceResolver) Translations(ctx context.Context, obj *ent.ReadingSentence, language *string) ([]*ent.SentenceTranslation, error) {
+	translations, err := r.sentenceService.GetTranslations(ctx, obj.ID, language)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get users: %w", err)
+		return nil, fmt.Errorf("failed to get translations: %w", err)
 	}
 
-	return users, nil
+	return translations, nil
 }
 
-// Readings is the resolver for the readings field.
-func (r *queryResolver) Readings(ctx context.Context) ([]*ent.Reading, error) {
-	client := config.GetEntClient()
-	readings, err := client.Reading.Query().All(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get readings: %w", err)
-	}
+// ID is the resolver for the id field.
+func (r *sentenceTranslationResolver) ID(ctx context.Context, obj *ent.SentenceTranslation) (string, error) {
+	return obj.ID.String(), nil
+}
 
-	return readings, nil
+// CreatedAt is the resolver for the createdAt field.
+func (r *sentenceTranslationResolver) CreatedAt(ctx context.Context, obj *ent.SentenceTranslation) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
 }
 
-// PublicReadings is the resolver for the publicReadings field.
-func (r *queryResolver) PublicReadings(ctx context.Context) ([]*ent.Reading, error) {
-	client := config.GetEntClient()
-	readings, err := client.Reading.Query().
-		Where(reading.Public(true)).
-		All(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get public readings: %w", err)
-	}
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *sentenceTranslationResolver) UpdatedAt(ctx context.Context, obj *ent.SentenceTranslation) (string, error) {
+	return obj.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
 
-	return readings, nil
+// ID is the resolver for the id field.
+func (r *userResolver) ID(ctx context.Context, obj *ent.User) (string, error) {
+	return obj.ID.String(), nil
 }
 
-// UserReadings is the resolver for the userReadings field.
-func (r *queryResolver) UserReadings(ctx context.Context, userID string) ([]*ent.Reading, error) {
-	userUUID, err := uuid.Parse(userID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+// ReadingAid is the resolver for the readingAid field.
+func (r *userResolver) ReadingAid(ctx context.Context, obj *ent.User) (model.ReadingAid, error) {
+	return services.ReadingAidOf(obj), nil
+}
+
+// NativeLanguages is the resolver for the nativeLanguages field.
+func (r *userResolver) NativeLanguages(ctx context.Context, obj *ent.User) ([]string, error) {
+	if obj.NativeLanguages == nil {
+		return []string{}, nil
 	}
+	return obj.NativeLanguages, nil
+}
 
-	client := config.GetEntClient()
-	readings, err := client.Reading.Query().
-		Where(reading.UserID(userUUID)).
-		All(ctx)
+// FollowerCount is the resolver for the followerCount field.
+func (r *userResolver) FollowerCount(ctx context.Context, obj *ent.User) (int, error) {
+	count, err := r.followService.CountFollowers(ctx, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user readings: %w", err)
+		return 0, fmt.Errorf("failed to count followers: %w", err)
 	}
-
-	return readings, nil
+	return count, nil
 }
 
-// Flashcards is the resolver for the flashcards field.
-func (r *queryResolver) Flashcards(ctx context.Context) ([]*ent.Flashcard, error) {
-	client := config.GetEntClient()
-	flashcards, err := client.Flashcard.Query().All(ctx)
+// FollowingCount is the resolver for the followingCount field.
+func (r *userResolver) FollowingCount(ctx context.Context, obj *ent.User) (int, error) {
+	count, err := r.followService.CountFollowing(ctx, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get flashcards: %w", err)
+		return 0, fmt.Errorf("failed to count followed users: %w", err)
 	}
-
-	return flashcards, nil
+	return count, nil
 }
 
-// UserFlashcards is the resolver for the userFlashcards field.
-func (r *queryResolver) UserFlashcards(ctx context.Context, userID string) ([]*ent.Flashcard, error) {
-	userUUID, err := uuid.Parse(userID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+// Followed is the resolver for the followed field.
+func (r *userResolver) Followed(ctx context.Context, obj *ent.User, viewerID *string) (bool, error) {
+	var viewer *uuid.UUID
+	if viewerID != nil {
+		viewerUUID, err := uuid.Parse(*viewerID)
+		if err != nil {
+			return false, fmt.Errorf("invalid viewer ID: %w", err)
+		}
+		viewer = &viewerUUID
 	}
 
-	client := config.GetEntClient()
-	flashcards, err := client.Flashcard.Query().
-		Where(flashcard.UserID(userUUID)).
-		All(ctx)
+	followed, err := r.followService.IsFollowing(ctx, viewer, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user flashcards: %w", err)
+		return false, fmt.Errorf("failed to get follow: %w", err)
 	}
-
-	return flashcards, nil
+	return followed, nil
 }
 
-// FlashcardsForReview is the resolver for the flashcardsForReview field.
-func (r *queryResolver) FlashcardsForReview(ctx context.Context, userID string, daysSince *int) ([]*ent.Flashcard, error) {
-	userUUID, err := uuid.Parse(userID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
-	}
+// ID is the resolver for the id field.
+func (r *vocabularyItemResolver) ID(ctx context.Context, obj *ent.VocabularyItem) (string, error) {
+	return obj.ID.String(), nil
+}
 
-	client := config.GetEntClient()
-	query := client.Flashcard.Query().Where(flashcard.UserID(userUUID))
+// WordStatus is the resolver for the wordStatus field.
+func (r *vocabularyItemResolver) WordStatus(ctx context.Context, obj *ent.VocabularyItem) (model.WordStatus, error) {
+	return services.WordStatusOf(obj), nil
+}
 
-	if daysSince != nil {
-		reviewThreshold := time.Now().AddDate(0, 0, -(*daysSince))
-		query = query.Where(flashcard.LastReviewedAtLT(reviewThreshold))
-	}
+// StatusUpdatedAt is the resolver for the statusUpdatedAt field.
+func (r *vocabularyItemResolver) StatusUpdatedAt(ctx context.Context, obj *ent.VocabularyItem) (string, error) {
+	return obj.StatusUpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
 
-	flashcards, err := query.All(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get flashcards for review: %w", err)
-	}
+// CreatedAt is the resolver for the createdAt field.
+func (r *vocabularyItemResolver) CreatedAt(ctx context.Context, obj *ent.VocabularyItem) (string, error) {
+	return obj.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
+}
 
-	return flashcards, nil
+// UpdatedAt is the resolver for the updatedAt field.
+func (r *vocabularyItemResolver) UpdatedAt(ctx context.Context, obj *ent.VocabularyItem) (string, error) {
+	return obj.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"), nil
 }
 
-// Posts is the resolver for the posts field.
-func (r *queryResolver) Posts(ctx context.Context) ([]*ent.Post, error) {
-	client := config.GetEntClient()
-	posts, err := client.Post.Query().All(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get posts: %w", err)
-	}
+// AudioCue returns AudioCueResolver implementation.
+func (r *Resolver) AudioCue() AudioCueResolver { return &audioCueResolver{r} }
 
-	return posts, nil
-}
+// Bookmark returns BookmarkResolver implementation.
+func (r *Resolver) Bookmark() BookmarkResolver { return &bookmarkResolver{r} }
 
-// UserPosts is the resolver for the userPosts field.
-func (r *queryResolver) UserPosts(ctx context.Context, userID string) ([]*ent.Post, error) {
-	panic(fmt.Errorf("not implemented: UserPosts - userPosts"))
+// Comment returns CommentResolver implementation.
+func (r *Resolver) Comment() CommentResolver { return &commentResolver{r} }
+
+// ComprehensionAnswer returns ComprehensionAnswerResolver implementation.
+func (r *Resolver) ComprehensionAnswer() ComprehensionAnswerResolver {
+	return &comprehensionAnswerResolver{r}
 }
 
-// ID is the resolver for the id field.
-func (r *readingResolver) ID(ctx context.Context, obj *ent.Reading) (string, error) {
-	return obj.ID.String(), nil
+// ComprehensionAttempt returns ComprehensionAttemptResolver implementation.
+func (r *Resolver) ComprehensionAttempt() ComprehensionAttemptResolver {
+	return &comprehensionAttemptResolver{r}
 }
 
-// ID is the resolver for the id field.
-func (r *userResolver) ID(ctx context.Context, obj *ent.User) (string, error) {
-	return obj.ID.String(), nil
+// ComprehensionQuestion returns ComprehensionQuestionResolver implementation.
+func (r *Resolver) ComprehensionQuestion() ComprehensionQuestionResolver {
+	return &comprehensionQuestionResolver{r}
 }
 
+// Correction returns CorrectionResolver implementation.
+func (r *Resolver) Correction() CorrectionResolver { return &correctionResolver{r} }
+
+// Course returns CourseResolver implementation.
+func (r *Resolver) Course() CourseResolver { return &courseResolver{r} }
+
+// CourseEnrollment returns CourseEnrollmentResolver implementation.
+func (r *Resolver) CourseEnrollment() CourseEnrollmentResolver { return &courseEnrollmentResolver{r} }
+
+// CourseItem returns CourseItemResolver implementation.
+func (r *Resolver) CourseItem() CourseItemResolver { return &courseItemResolver{r} }
+
+// Dictionary returns DictionaryResolver implementation.
+func (r *Resolver) Dictionary() DictionaryResolver { return &dictionaryResolver{r} }
+
+// DictionaryEntry returns DictionaryEntryResolver implementation.
+func (r *Resolver) DictionaryEntry() DictionaryEntryResolver { return &dictionaryEntryResolver{r} }
+
 // Flashcard returns FlashcardResolver implementation.
 func (r *Resolver) Flashcard() FlashcardResolver { return &flashcardResolver{r} }
 
+// Highlight returns HighlightResolver implementation.
+func (r *Resolver) Highlight() HighlightResolver { return &highlightResolver{r} }
+
+// ImportJob returns ImportJobResolver implementation.
+func (r *Resolver) ImportJob() ImportJobResolver { return &importJobResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// Notification returns NotificationResolver implementation.
+func (r *Resolver) Notification() NotificationResolver { return &notificationResolver{r} }
+
 // Post returns PostResolver implementation.
 func (r *Resolver) Post() PostResolver { return &postResolver{r} }
 
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// QuizAnswer returns QuizAnswerResolver implementation.
+func (r *Resolver) QuizAnswer() QuizAnswerResolver { return &quizAnswerResolver{r} }
+
+// QuizAttempt returns QuizAttemptResolver implementation.
+func (r *Resolver) QuizAttempt() QuizAttemptResolver { return &quizAttemptResolver{r} }
+
 // Reading returns ReadingResolver implementation.
 func (r *Resolver) Reading() ReadingResolver { return &readingResolver{r} }
 
+// ReadingProgress returns ReadingProgressResolver implementation.
+func (r *Resolver) ReadingProgress() ReadingProgressResolver { return &readingProgressResolver{r} }
+
+// ReadingReview returns ReadingReviewResolver implementation.
+func (r *Resolver) ReadingReview() ReadingReviewResolver { return &readingReviewResolver{r} }
+
+// ReadingRevision returns ReadingRevisionResolver implementation.
+func (r *Resolver) ReadingRevision() ReadingRevisionResolver { return &readingRevisionResolver{r} }
+
+// ReadingSentence returns ReadingSentenceResolver implementation.
+func (r *Resolver) ReadingSentence() ReadingSentenceResolver { return &readingSentenceResolver{r} }
+
+// SentenceTranslation returns SentenceTranslationResolver implementation.
+func (r *Resolver) SentenceTranslation() SentenceTranslationResolver {
+	return &sentenceTranslationResolver{r}
+}
+
 // User returns UserResolver implementation.
 func (r *Resolver) User() UserResolver { return &userResolver{r} }
 
+// VocabularyItem returns VocabularyItemResolver implementation.
+func (r *Resolver) VocabularyItem() VocabularyItemResolver { return &vocabularyItemResolver{r} }
+
+type audioCueResolver struct{ *Resolver }
+type bookmarkResolver struct{ *Resolver }
+type commentResolver struct{ *Resolver }
+type comprehensionAnswerResolver struct{ *Resolver }
+type comprehensionAttemptResolver struct{ *Resolver }
+type comprehensionQuestionResolver struct{ *Resolver }
+type correctionResolver struct{ *Resolver }
+type courseResolver struct{ *Resolver }
+type courseEnrollmentResolver struct{ *Resolver }
+type courseItemResolver struct{ *Resolver }
+type dictionaryResolver struct{ *Resolver }
+type dictionaryEntryResolver struct{ *Resolver }
 type flashcardResolver struct{ *Resolver }
+type highlightResolver struct{ *Resolver }
+type importJobResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type notificationResolver struct{ *Resolver }
 type postResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type quizAnswerResolver struct{ *Resolver }
+type quizAttemptResolver struct{ *Resolver }
 type readingResolver struct{ *Resolver }
+type readingProgressResolver struct{ *Resolver }
+type readingReviewResolver struct{ *Resolver }
+type readingRevisionResolver struct{ *Resolver }
+type readingSentenceResolver struct{ *Resolver }
+type sentenceTranslationResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
+type vocabularyItemResolver struct{ *Resolver }
@@ -0,0 +1,132 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// SegmentPair is a source segment and its translation from an aligned file.
+type SegmentPair struct {
+	Source string
+	Target string
+}
+
+type tmxDocument struct {
+	Header struct {
+		SrcLang string `xml:"srclang,attr"`
+	} `xml:"header"`
+	Units []struct {
+		Variants []struct {
+			Lang    string `xml:"lang,attr"`
+			OldLang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+			Seg     struct {
+				Inner string `xml:",innerxml"`
+			} `xml:"seg"`
+		} `xml:"tuv"`
+	} `xml:"body>tu"`
+}
+
+// ParseTMX reads the translation units of a TMX file. The segments in the
+// source and target languages become pairs; a language matches its regional
+// variants, so "en" matches "en-GB". Units missing either language are
+// skipped.
+func ParseTMX(data []byte, source, target string) ([]SegmentPair, error) {
+	var doc tmxDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse TMX: %w", err)
+	}
+	if source == "" {
+		source = doc.Header.SrcLang
+	}
+
+	var pairs []SegmentPair
+	for _, unit := range doc.Units {
+		var pair SegmentPair
+		for _, v := range unit.Variants {
+			lang := v.Lang
+			if lang == "" {
+				lang = v.OldLang
+			}
+			text := tmxSegmentText(v.Seg.Inner)
+			switch {
+			case matchesLanguage(lang, source) && pair.Source == "":
+				pair.Source = text
+			case matchesLanguage(lang, target) && pair.Target == "":
+				pair.Target = text
+			}
+		}
+		if pair.Source != "" && pair.Target != "" {
+			pairs = append(pairs, pair)
+		}
+	}
+
+	return pairs, nil
+}
+
+// tmxSegmentText returns the text of a <seg>, dropping inline markup such as
+// <bpt> and <ph> along with the native codes they hold. <hi> only highlights
+// text, so its content is kept.
+func tmxSegmentText(inner string) string {
+	decoder := xml.NewDecoder(strings.NewReader(inner))
+	var (
+		b    strings.Builder
+		skip int
+	)
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != "hi" {
+				skip++
+			}
+		case xml.EndElement:
+			if t.Name.Local != "hi" {
+				skip--
+			}
+		case xml.CharData:
+			if skip == 0 {
+				b.Write(t)
+			}
+		}
+	}
+	return collapseSpaces(b.String())
+}
+
+// ParseTSV reads a two-column tab separated file of source segments and their
+// translations. Lines without a tab and empty segments are skipped.
+func ParseTSV(data []byte) ([]SegmentPair, error) {
+	var pairs []SegmentPair
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		source, target, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			continue
+		}
+		// Extra columns (notes, scores) are ignored.
+		target, _, _ = strings.Cut(target, "\t")
+		source, target = collapseSpaces(source), collapseSpaces(target)
+		if source != "" && target != "" {
+			pairs = append(pairs, SegmentPair{Source: source, Target: target})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read TSV: %w", err)
+	}
+
+	return pairs, nil
+}
+
+func matchesLanguage(lang, want string) bool {
+	lang, want = strings.ToLower(lang), strings.ToLower(want)
+	if want == "" || lang == "" {
+		return false
+	}
+	return lang == want || strings.HasPrefix(lang, want+"-") || strings.HasPrefix(lang, want+"_")
+}
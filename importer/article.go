@@ -0,0 +1,346 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// unlikelyContent matches class names and ids of page furniture.
+	unlikelyContent = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|cookie|disqus|extra|footer|gdpr|header|legends|menu|modal|nav|newsletter|pager|popup|promo|related|remark|replies|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|tags|tool|widget|ad-break|advert`)
+	// maybeContent rescues elements that match unlikelyContent but are
+	// probably the article, such as "article-header".
+	maybeContent = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	// positiveContent and negativeContent adjust a candidate's score.
+	positiveContent = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|story|text|blog`)
+	negativeContent = regexp.MustCompile(`(?i)-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|contact|foot|footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+	// titleSeparator splits a site name off a page title.
+	titleSeparator = regexp.MustCompile(`\s+[|\-–—·»:]\s+`)
+)
+
+// articleSkipped are removed before scoring, in addition to skippedElements.
+var articleSkipped = map[atom.Atom]bool{
+	atom.Header: true,
+	atom.Footer: true,
+	atom.Aside:  true,
+	atom.Menu:   true,
+	atom.Dialog: true,
+}
+
+// ExtractArticle extracts the main content of a web page, leaving out
+// navigation, sidebars, comments and other page furniture. It follows the
+// approach of Mozilla's Readability: paragraphs score their ancestors by
+// length and punctuation, scores are adjusted by class names and link
+// density, and the best scoring element together with related siblings
+// becomes the article.
+func ExtractArticle(data []byte) (*Document, error) {
+	root, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	doc := &Document{
+		Title:    articleTitle(root),
+		Author:   metaContent(root, "author", "article:author", "og:article:author"),
+		Language: htmlLanguage(root),
+	}
+
+	body := findElement(root, atom.Body)
+	if body == nil {
+		body = root
+	}
+	removeUnlikely(body)
+
+	text := ""
+	if top := topCandidate(body); top != nil {
+		text = articleText(top)
+	}
+	// Pages without paragraphs, such as a poem in a single <div>, are kept
+	// whole.
+	if utf8.RuneCountInString(text) < 250 {
+		if all := HTMLText(body); utf8.RuneCountInString(all) > utf8.RuneCountInString(text) {
+			text = all
+		}
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return nil, ErrNoText
+	}
+	doc.Chapters = []Chapter{{Title: doc.Title, Body: text}}
+	return doc, nil
+}
+
+// removeUnlikely detaches elements that are never part of an article.
+func removeUnlikely(n *html.Node) {
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if c.Type == html.ElementNode {
+			match := attr(c, "class") + " " + attr(c, "id")
+			role := attr(c, "role")
+			switch {
+			case skippedElements[c.DataAtom], articleSkipped[c.DataAtom],
+				role == "navigation" || role == "complementary" || role == "dialog",
+				attr(c, "aria-hidden") == "true",
+				c.DataAtom != atom.Body && c.DataAtom != atom.Article && c.DataAtom != atom.Main &&
+					unlikelyContent.MatchString(match) && !maybeContent.MatchString(match):
+				n.RemoveChild(c)
+			default:
+				removeUnlikely(c)
+			}
+		}
+		c = next
+	}
+}
+
+// topCandidate scores the ancestors of every paragraph and returns the best
+// one, or nil when the page has no paragraphs.
+func topCandidate(body *html.Node) *html.Node {
+	scores := make(map[*html.Node]float64)
+	var order []*html.Node
+
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			if c.DataAtom == atom.P || c.DataAtom == atom.Pre || c.DataAtom == atom.Td || c.DataAtom == atom.Blockquote {
+				scoreParagraph(c, scores, &order)
+			}
+			visit(c)
+		}
+	}
+	visit(body)
+
+	var (
+		top      *html.Node
+		topScore float64
+	)
+	for _, n := range order {
+		score := scores[n] * (1 - linkDensity(n))
+		scores[n] = score
+		if top == nil || score > topScore {
+			top, topScore = n, score
+		}
+	}
+	if top == nil {
+		return nil
+	}
+
+	// A single child of a wrapper scores its parent almost as high; prefer
+	// the wrapper so that sibling content is kept.
+	for top.Parent != nil && top.Parent != body {
+		parentScore, ok := scores[top.Parent]
+		if !ok || parentScore < topScore*0.75 {
+			break
+		}
+		top, topScore = top.Parent, parentScore
+	}
+
+	return withSiblings(top, topScore, scores)
+}
+
+// scoreParagraph adds a paragraph's score to its parent and, halved, to its
+// grandparent.
+func scoreParagraph(p *html.Node, scores map[*html.Node]float64, order *[]*html.Node) {
+	text := collapseSpaces(nodeText(p))
+	length := utf8.RuneCountInString(text)
+	if length < 25 {
+		return
+	}
+
+	score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "،")+strings.Count(text, "、")) +
+		math.Min(float64(length)/100, 3)
+
+	ancestor := p.Parent
+	for depth := 0; depth < 3 && ancestor != nil && ancestor.Type == html.ElementNode; depth++ {
+		if _, ok := scores[ancestor]; !ok {
+			scores[ancestor] = initialScore(ancestor)
+			*order = append(*order, ancestor)
+		}
+		switch depth {
+		case 0:
+			scores[ancestor] += score
+		case 1:
+			scores[ancestor] += score / 2
+		default:
+			scores[ancestor] += score / 6
+		}
+		ancestor = ancestor.Parent
+	}
+}
+
+// initialScore scores an element by its tag and class names before any
+// paragraph is counted.
+func initialScore(n *html.Node) float64 {
+	var score float64
+	switch n.DataAtom {
+	case atom.Article:
+		score = 10
+	case atom.Main, atom.Div:
+		score = 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score = 3
+	case atom.Address, atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form:
+		score = -3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score = -5
+	}
+
+	for _, value := range []string{attr(n, "class"), attr(n, "id")} {
+		if value == "" {
+			continue
+		}
+		if negativeContent.MatchString(value) {
+			score -= 25
+		}
+		if positiveContent.MatchString(value) {
+			score += 25
+		}
+	}
+	return score
+}
+
+// linkDensity is the share of an element's text that is inside links.
+func linkDensity(n *html.Node) float64 {
+	total := utf8.RuneCountInString(collapseSpaces(nodeText(n)))
+	if total == 0 {
+		return 0
+	}
+
+	var linked int
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.A {
+			linked += utf8.RuneCountInString(collapseSpaces(nodeText(n)))
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
+		}
+	}
+	visit(n)
+	return float64(linked) / float64(total)
+}
+
+// withSiblings returns the top candidate, wrapped in a new element together
+// with the siblings that look like they belong to the same article.
+func withSiblings(top *html.Node, topScore float64, scores map[*html.Node]float64) *html.Node {
+	if top.Parent == nil {
+		return top
+	}
+
+	threshold := math.Max(10, topScore*0.2)
+	class := attr(top, "class")
+	wrapper := &html.Node{Type: html.ElementNode, DataAtom: atom.Div, Data: "div"}
+	for s := top.Parent.FirstChild; s != nil; {
+		next := s.NextSibling
+		include := s == top
+		if !include && s.Type == html.ElementNode {
+			bonus := 0.0
+			if class != "" && attr(s, "class") == class {
+				bonus = topScore * 0.2
+			}
+			if score, ok := scores[s]; ok && score+bonus >= threshold {
+				include = true
+			} else if s.DataAtom == atom.P {
+				text := collapseSpaces(nodeText(s))
+				length := utf8.RuneCountInString(text)
+				density := linkDensity(s)
+				include = (length > 80 && density < 0.25) ||
+					(length > 0 && density == 0 && strings.ContainsAny(text, ".!?。"))
+			}
+		}
+		if include {
+			s.Parent.RemoveChild(s)
+			wrapper.AppendChild(s)
+		}
+		s = next
+	}
+	return wrapper
+}
+
+// articleText renders an article, dropping leftover blocks that are mostly
+// links, such as "read more" lists.
+func articleText(n *html.Node) string {
+	var prune func(n *html.Node)
+	prune = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; {
+			next := c.NextSibling
+			if c.Type == html.ElementNode && (c.DataAtom == atom.Ul || c.DataAtom == atom.Ol || c.DataAtom == atom.Div || c.DataAtom == atom.Table) && linkDensity(c) > 0.5 {
+				n.RemoveChild(c)
+			} else {
+				prune(c)
+			}
+			c = next
+		}
+	}
+	prune(n)
+	return HTMLText(n)
+}
+
+// articleTitle prefers the Open Graph title, then the page title without the
+// site name, then the first heading.
+func articleTitle(root *html.Node) string {
+	if title := metaContent(root, "og:title", "twitter:title"); title != "" {
+		return title
+	}
+
+	title := ""
+	if n := findElement(root, atom.Title); n != nil {
+		title = collapseSpaces(nodeText(n))
+	}
+	if loc := titleSeparator.FindAllStringIndex(title, -1); len(loc) > 0 {
+		// "Headline | Site" or "Site - Headline": keep the longer part.
+		first, last := title[:loc[0][0]], title[loc[len(loc)-1][1]:]
+		candidate := first
+		if utf8.RuneCountInString(last) > utf8.RuneCountInString(first) {
+			candidate = last
+		}
+		if len(strings.Fields(candidate)) >= 3 {
+			title = candidate
+		}
+	}
+	if title == "" {
+		return HTMLTitle(root)
+	}
+	return title
+}
+
+// metaContent returns the content of the first <meta> whose name or property
+// is one of names.
+func metaContent(root *html.Node, names ...string) string {
+	var found string
+	var visit func(n *html.Node) bool
+	visit = func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Meta {
+			key := attr(n, "property")
+			if key == "" {
+				key = attr(n, "name")
+			}
+			for _, name := range names {
+				if strings.EqualFold(key, name) {
+					if content := collapseSpaces(attr(n, "content")); content != "" && !strings.HasPrefix(content, "http") {
+						found = content
+						return true
+					}
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if visit(c) {
+				return true
+			}
+		}
+		return false
+	}
+	visit(root)
+	return found
+}
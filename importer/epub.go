@@ -0,0 +1,170 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxEPUBEntrySize caps how much of a single archive entry is read, to protect
+// against zip bombs.
+const maxEPUBEntrySize = 20 << 20
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type epubPackage struct {
+	Metadata struct {
+		Titles    []string `xml:"title"`
+		Creators  []string `xml:"creator"`
+		Languages []string `xml:"language"`
+	} `xml:"metadata"`
+	Manifest []struct {
+		ID        string `xml:"id,attr"`
+		Href      string `xml:"href,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef  string `xml:"idref,attr"`
+		Linear string `xml:"linear,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// extractEPUB reads an EPUB book and returns one chapter per spine document,
+// in reading order. Chapter titles come from the first heading of each
+// document.
+func extractEPUB(data []byte) (*Document, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open EPUB archive: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	var container epubContainer
+	if err := readZipXML(files, "META-INF/container.xml", &container); err != nil {
+		return nil, err
+	}
+	if len(container.Rootfiles) == 0 {
+		return nil, fmt.Errorf("EPUB container has no rootfile")
+	}
+	opfPath := container.Rootfiles[0].FullPath
+
+	var pkg epubPackage
+	if err := readZipXML(files, opfPath, &pkg); err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		Title:    first(pkg.Metadata.Titles),
+		Author:   first(pkg.Metadata.Creators),
+		Language: first(pkg.Metadata.Languages),
+	}
+
+	hrefs := make(map[string]string, len(pkg.Manifest))
+	for _, item := range pkg.Manifest {
+		if strings.Contains(item.MediaType, "html") {
+			hrefs[item.ID] = item.Href
+		}
+	}
+
+	base := path.Dir(opfPath)
+	for _, ref := range pkg.Spine {
+		href, ok := hrefs[ref.IDRef]
+		if !ok || ref.Linear == "no" {
+			continue
+		}
+		if i := strings.IndexByte(href, '#'); i >= 0 {
+			href = href[:i]
+		}
+		if unescaped, err := url.PathUnescape(href); err == nil {
+			href = unescaped
+		}
+		name := path.Join(base, href)
+
+		content, err := readZipFile(files, name)
+		if err != nil {
+			return nil, err
+		}
+		root, err := html.Parse(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EPUB chapter %s: %w", name, err)
+		}
+
+		doc.Chapters = append(doc.Chapters, Chapter{
+			Title: chapterTitle(root),
+			Body:  HTMLText(root),
+		})
+	}
+
+	return doc, nil
+}
+
+// chapterTitle returns the first heading of a chapter document, or its <title>.
+func chapterTitle(root *html.Node) string {
+	for _, a := range []atom.Atom{atom.H1, atom.H2, atom.H3} {
+		if n := findElement(root, a); n != nil {
+			if title := collapseSpaces(nodeText(n)); title != "" {
+				return title
+			}
+		}
+	}
+	if n := findElement(root, atom.Title); n != nil {
+		return collapseSpaces(nodeText(n))
+	}
+	return ""
+}
+
+func readZipXML(files map[string]*zip.File, name string, v any) error {
+	content, err := readZipFile(files, name)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+func readZipFile(files map[string]*zip.File, name string) ([]byte, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("EPUB is missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, maxEPUBEntrySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(content) > maxEPUBEntrySize {
+		return nil, fmt.Errorf("%s is too large", name)
+	}
+	return content, nil
+}
+
+func first(values []string) string {
+	for _, v := range values {
+		if v = collapseSpaces(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,228 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// ErrBlockedAddress is returned when a URL resolves to an address the fetcher
+// may not connect to, such as a loopback or private network address.
+var ErrBlockedAddress = errors.New("address is not allowed")
+
+// ErrPageTooLarge is returned when a fetched page exceeds the size limit.
+var ErrPageTooLarge = errors.New("page is too large")
+
+const (
+	// DefaultMaxPageSize is the largest page a Fetcher downloads by default.
+	DefaultMaxPageSize = 5 << 20
+	// DefaultFetchTimeout bounds a whole fetch, redirects and body included.
+	DefaultFetchTimeout = 15 * time.Second
+	maxRedirects        = 5
+)
+
+// blockedPrefixes are the ranges besides loopback, private, link-local,
+// multicast and unspecified addresses that are never fetched.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// Fetcher downloads web pages for import. It only connects to public
+// addresses unless an address is in its allowlist, and it checks every
+// connection, so redirects and DNS answers can't reach internal services.
+type Fetcher struct {
+	client  *http.Client
+	maxSize int64
+	allowed []netip.Prefix
+}
+
+// NewFetcher creates a Fetcher. allowlist holds IP addresses or CIDR ranges
+// that may be fetched even though they are private, e.g. "127.0.0.1" in tests
+// or "10.1.0.0/16" for an internal mirror.
+func NewFetcher(allowlist []string) (*Fetcher, error) {
+	f := &Fetcher{maxSize: DefaultMaxPageSize}
+	for _, entry := range allowlist {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowlist entry %q: %w", entry, err)
+			}
+			f.allowed = append(f.allowed, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist entry %q: %w", entry, err)
+		}
+		f.allowed = append(f.allowed, prefix.Masked())
+	}
+
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: f.checkConnection,
+	}
+	f.client = &http.Client{
+		Timeout: DefaultFetchTimeout,
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   5 * time.Second,
+			ResponseHeaderTimeout: 10 * time.Second,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return checkScheme(req.URL)
+		},
+	}
+	return f, nil
+}
+
+// Fetch downloads an HTML or plain text page and extracts its main content.
+// The returned document's chapter holds the article text and its SourceURL is
+// the page's address after redirects.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Document, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if err := checkScheme(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.8")
+	req.Header.Set("User-Agent", "LinganoGO/1.0 (+reading import)")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", u.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: %s", u.Redacted(), resp.Status)
+	}
+	if resp.ContentLength > f.maxSize {
+		return nil, ErrPageTooLarge
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "" && mediaType != "text/html" && mediaType != "application/xhtml+xml" && mediaType != "text/plain" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, mediaType)
+	}
+
+	// The limit applies to the bytes sent, which decoding to UTF-8 may grow
+	// or shrink.
+	raw := &countingReader{r: io.LimitReader(resp.Body, f.maxSize+1)}
+	body, err := charset.NewReader(raw, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode page: %w", err)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+	if raw.n > f.maxSize {
+		return nil, ErrPageTooLarge
+	}
+
+	var doc *Document
+	if mediaType == "text/plain" {
+		doc, err = Extract(data, FormatText, u.Path)
+	} else {
+		doc, err = ExtractArticle(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc.SourceURL = resp.Request.URL.String()
+	return doc, nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// checkConnection is the dialer's Control hook. It runs after DNS resolution
+// with the address actually being connected to.
+func (f *Fetcher) checkConnection(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range f.allowed {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	if isBlockedAddr(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+	}
+	return nil
+}
+
+func isBlockedAddr(addr netip.Addr) bool {
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return true
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkScheme(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("URL has no host")
+	}
+	return nil
+}
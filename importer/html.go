@@ -0,0 +1,218 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// skippedElements never contain readable text.
+var skippedElements = map[atom.Atom]bool{
+	atom.Head:     true,
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Iframe:   true,
+	atom.Svg:      true,
+	atom.Math:     true,
+	atom.Canvas:   true,
+	atom.Form:     true,
+	atom.Button:   true,
+	atom.Select:   true,
+	atom.Nav:      true,
+}
+
+// blockElements start a new paragraph.
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true,
+	atom.Main: true, atom.Header: true, atom.Footer: true, atom.Aside: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Dl: true, atom.Dt: true, atom.Dd: true,
+	atom.Blockquote: true, atom.Pre: true, atom.Hr: true, atom.Table: true, atom.Tr: true,
+	atom.Figure: true, atom.Figcaption: true, atom.Address: true, atom.Body: true,
+}
+
+// extractHTML turns an HTML page into plain text. Scripts, styles, forms and
+// other markup are dropped, which also sanitizes the content.
+func extractHTML(data []byte) (*Document, error) {
+	root, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	title := HTMLTitle(root)
+	return &Document{
+		Title:    title,
+		Language: htmlLanguage(root),
+		Chapters: []Chapter{{Title: title, Body: HTMLText(root)}},
+	}, nil
+}
+
+// HTMLTitle returns the text of a document's <title>, falling back to its
+// first <h1>.
+func HTMLTitle(root *html.Node) string {
+	if n := findElement(root, atom.Title); n != nil {
+		if title := collapseSpaces(nodeText(n)); title != "" {
+			return title
+		}
+	}
+	if n := findElement(root, atom.H1); n != nil {
+		return collapseSpaces(nodeText(n))
+	}
+	return ""
+}
+
+// HTMLText renders the readable text of an HTML tree as paragraphs separated
+// by blank lines.
+func HTMLText(n *html.Node) string {
+	w := &textWriter{}
+	w.render(n, false)
+	return strings.TrimSpace(w.b.String())
+}
+
+// textWriter accumulates text, collapsing whitespace and inserting line breaks
+// between block elements.
+type textWriter struct {
+	b            strings.Builder
+	pendingBreak int
+	pendingSpace bool
+}
+
+func (w *textWriter) render(n *html.Node, pre bool) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data, pre)
+		return
+	case html.ElementNode:
+		if skippedElements[n.DataAtom] {
+			return
+		}
+		if n.DataAtom == atom.Br {
+			w.lineBreak(1)
+			return
+		}
+		if n.DataAtom == atom.Img {
+			if alt := attr(n, "alt"); alt != "" && w.b.Len() > 0 {
+				w.text(alt, false)
+			}
+			return
+		}
+		if n.DataAtom == atom.Pre {
+			pre = true
+		}
+	}
+
+	block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+	if block {
+		w.lineBreak(2)
+	}
+	if n.DataAtom == atom.Li {
+		w.text("• ", true)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.render(c, pre)
+	}
+	if n.DataAtom == atom.Td || n.DataAtom == atom.Th {
+		w.pendingSpace = true
+	}
+	if block {
+		w.lineBreak(2)
+	}
+}
+
+func (w *textWriter) text(s string, pre bool) {
+	if !pre {
+		if s == "" {
+			return
+		}
+		if unicode.IsSpace(rune(s[0])) {
+			w.pendingSpace = true
+		}
+		trailing := unicode.IsSpace(rune(s[len(s)-1]))
+		s = strings.Join(strings.Fields(s), " ")
+		if s == "" {
+			return
+		}
+		w.flush()
+		w.b.WriteString(s)
+		w.pendingSpace = trailing
+		return
+	}
+
+	w.flush()
+	w.b.WriteString(s)
+}
+
+// flush writes the separator owed before the next piece of text.
+func (w *textWriter) flush() {
+	if w.b.Len() == 0 {
+		w.pendingBreak, w.pendingSpace = 0, false
+		return
+	}
+	switch {
+	case w.pendingBreak > 0:
+		w.b.WriteString(strings.Repeat("\n", w.pendingBreak))
+	case w.pendingSpace:
+		w.b.WriteByte(' ')
+	}
+	w.pendingBreak, w.pendingSpace = 0, false
+}
+
+func (w *textWriter) lineBreak(n int) {
+	if n > w.pendingBreak {
+		w.pendingBreak = n
+	}
+}
+
+// findElement returns the first element of the given type in document order.
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// nodeText concatenates all text below n without any formatting.
+func nodeText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(nodeText(c))
+	}
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func htmlLanguage(root *html.Node) string {
+	if n := findElement(root, atom.Html); n != nil {
+		if lang := attr(n, "lang"); lang != "" {
+			return lang
+		}
+		return attr(n, "xml:lang")
+	}
+	return ""
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,173 @@
+// Package importer extracts readable text from uploaded documents: EPUB books,
+// HTML pages, Markdown and plain text files, PDFs with a text layer and
+// SRT/WebVTT subtitles.
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Format is a document format the importer understands.
+type Format string
+
+const (
+	FormatEPUB     Format = "EPUB"
+	FormatHTML     Format = "HTML"
+	FormatMarkdown Format = "MARKDOWN"
+	FormatPDF      Format = "PDF"
+	FormatSRT      Format = "SRT"
+	FormatVTT      Format = "VTT"
+	FormatText     Format = "TEXT"
+)
+
+// ErrUnsupportedFormat is returned when a file's format can't be detected or
+// has no extractor.
+var ErrUnsupportedFormat = errors.New("unsupported document format")
+
+// ErrNoText is returned when a document contains no extractable text, for
+// example a PDF made of scanned images.
+var ErrNoText = errors.New("document contains no text")
+
+// ErrUndecodableText is returned when a document's text is set in fonts whose
+// characters can't be mapped to Unicode, for example a PDF embedding fonts
+// with a custom encoding and no ToUnicode CMap.
+var ErrUndecodableText = errors.New("document text uses fonts that can't be decoded")
+
+// Chapter is a part of a document that becomes one reading.
+type Chapter struct {
+	Title string
+	Body  string
+	// Markdown is true when Body is Markdown rather than plain text.
+	Markdown bool
+}
+
+// Document is the text extracted from a file, split into chapters in reading
+// order. Most formats produce a single chapter; EPUB books produce one per
+// spine item.
+type Document struct {
+	Title    string
+	Author   string
+	Language string
+	// SourceURL is the address a fetched document was retrieved from.
+	SourceURL string
+	Chapters  []Chapter
+}
+
+// DetectFormat guesses a file's format from its name, content type and first
+// bytes.
+func DetectFormat(filename, contentType string, data []byte) (Format, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".epub":
+		return FormatEPUB, nil
+	case ".html", ".htm", ".xhtml":
+		return FormatHTML, nil
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	case ".pdf":
+		return FormatPDF, nil
+	case ".srt":
+		return FormatSRT, nil
+	case ".vtt":
+		return FormatVTT, nil
+	case ".txt":
+		return FormatText, nil
+	}
+
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "epub"):
+		return FormatEPUB, nil
+	case strings.Contains(contentType, "html"):
+		return FormatHTML, nil
+	case strings.Contains(contentType, "markdown"):
+		return FormatMarkdown, nil
+	case strings.Contains(contentType, "pdf"):
+		return FormatPDF, nil
+	case strings.Contains(contentType, "vtt"):
+		return FormatVTT, nil
+	case strings.Contains(contentType, "subrip"):
+		return FormatSRT, nil
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return FormatPDF, nil
+	case bytes.HasPrefix(data, []byte("WEBVTT")):
+		return FormatVTT, nil
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")) && bytes.Contains(data[:min(len(data), 100)], []byte("epub")):
+		return FormatEPUB, nil
+	case strings.HasPrefix(contentType, "text/plain") || (utf8.Valid(data) && len(data) > 0):
+		return FormatText, nil
+	}
+
+	return "", ErrUnsupportedFormat
+}
+
+// Extract extracts the text of a document in the given format. The filename is
+// used as the title when the document doesn't carry one.
+func Extract(data []byte, format Format, filename string) (*Document, error) {
+	var (
+		doc *Document
+		err error
+	)
+	switch format {
+	case FormatEPUB:
+		doc, err = extractEPUB(data)
+	case FormatHTML:
+		doc, err = extractHTML(data)
+	case FormatMarkdown:
+		doc = extractMarkdown(data)
+	case FormatPDF:
+		doc, err = extractPDF(data)
+	case FormatSRT, FormatVTT:
+		doc, err = extractSubtitles(data, format)
+	case FormatText:
+		doc = &Document{Chapters: []Chapter{{Body: normalizeNewlines(string(data))}}}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	chapters := doc.Chapters[:0]
+	for _, c := range doc.Chapters {
+		c.Body = strings.TrimSpace(c.Body)
+		if c.Body != "" {
+			chapters = append(chapters, c)
+		}
+	}
+	doc.Chapters = chapters
+	if len(doc.Chapters) == 0 {
+		return nil, ErrNoText
+	}
+
+	if doc.Title == "" {
+		doc.Title = doc.Chapters[0].Title
+	}
+	if doc.Title == "" {
+		doc.Title = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	}
+	for i := range doc.Chapters {
+		switch {
+		case doc.Chapters[i].Title != "":
+		case len(doc.Chapters) == 1:
+			doc.Chapters[i].Title = doc.Title
+		default:
+			doc.Chapters[i].Title = fmt.Sprintf("%s (%d)", doc.Title, i+1)
+		}
+	}
+
+	return doc, nil
+}
+
+// normalizeNewlines converts Windows and old Mac line endings to \n.
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
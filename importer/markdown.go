@@ -0,0 +1,24 @@
+package importer
+
+import (
+	"strings"
+)
+
+// extractMarkdown keeps Markdown as is, so that readings can render it, and
+// takes the title from the first level one heading.
+func extractMarkdown(data []byte) *Document {
+	body := normalizeNewlines(string(data))
+
+	var title string
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "# ") {
+			title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+			break
+		}
+	}
+
+	return &Document{
+		Title:    title,
+		Chapters: []Chapter{{Title: title, Body: body, Markdown: true}},
+	}
+}
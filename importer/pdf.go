@@ -0,0 +1,404 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxPDFStreamSize caps the decompressed size of a single PDF stream.
+const maxPDFStreamSize = 20 << 20
+
+var (
+	pdfStreamStart = regexp.MustCompile(`stream\r?\n`)
+	pdfTitle       = regexp.MustCompile(`/Title\s*\(((?:[^()\\]|\\.)*)\)`)
+)
+
+// extractPDF extracts the text layer of a PDF. It understands uncompressed and
+// Flate compressed content streams. Strings are decoded with the ToUnicode
+// CMap of their font when it has one, as Type0 fonts with the Identity-H
+// encoding that word processors embed do, and as PDFDocEncoding or UTF-16
+// otherwise. Scanned PDFs without a text layer yield ErrNoText; PDFs whose
+// text is only set in fonts with a custom encoding and no ToUnicode CMap
+// yield ErrUndecodableText.
+func extractPDF(data []byte) (*Document, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return nil, fmt.Errorf("not a PDF file")
+	}
+
+	objects, order := pdfObjects(data)
+	streamFonts, allFonts := pdfFonts(objects)
+
+	var pages []string
+	undecodable := false
+	for _, num := range order {
+		obj := objects[num]
+		if obj.stream == nil || skipPDFStream(obj.dict) {
+			continue
+		}
+		content, ok := decodePDFStream(obj)
+		if !ok {
+			continue
+		}
+
+		fonts, ok := streamFonts[num]
+		if !ok {
+			fonts = allFonts
+		}
+		text, dropped := pdfContentText(content, fonts)
+		undecodable = undecodable || dropped
+		if text = strings.TrimSpace(text); text != "" {
+			pages = append(pages, text)
+		}
+	}
+	if len(pages) == 0 && undecodable {
+		return nil, ErrUndecodableText
+	}
+
+	var title string
+	if m := pdfTitle.FindSubmatch(data); m != nil {
+		title = collapseSpaces(decodePDFString(unescapePDFLiteral(m[1])))
+	}
+
+	return &Document{
+		Title:    title,
+		Chapters: []Chapter{{Title: title, Body: strings.Join(pages, "\n\n")}},
+	}, nil
+}
+
+// skipPDFStream reports whether a stream holds something other than page
+// content: images, fonts, metadata, cross-reference or object streams.
+func skipPDFStream(dict []byte) bool {
+	for _, marker := range []string{"/Image", "/XRef", "/ObjStm", "/Metadata", "/Length1", "/Length2", "/FontFile", "/ICCBased", "/EmbeddedFile", "/DCTDecode", "/JPXDecode"} {
+		if bytes.Contains(dict, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+// pdfContentText interprets the text showing operators of a content stream,
+// decoding strings with the CMaps of fonts, by resource name. It also reports
+// whether strings that couldn't be decoded were dropped.
+func pdfContentText(content []byte, fonts map[string]*pdfCMap) (string, bool) {
+	var (
+		b        strings.Builder
+		operands []any
+		font     *pdfCMap
+		dropped  bool
+	)
+	decode := func(s []byte) string {
+		if font != nil {
+			return font.decode(s)
+		}
+		text := decodePDFString(s)
+		dropped = dropped || (text == "" && len(s) > 0)
+		return text
+	}
+	newline := func() {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteByte('\n')
+		}
+	}
+	space := func() {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), " ") && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteByte(' ')
+		}
+	}
+
+	lex := &pdfLexer{data: content}
+	for {
+		tok, ok := lex.next()
+		if !ok {
+			break
+		}
+		op, isOp := tok.(pdfOperator)
+		if !isOp {
+			operands = append(operands, tok)
+			continue
+		}
+
+		switch op {
+		case "Tj":
+			if s, ok := lastOperand[[]byte](operands); ok {
+				b.WriteString(decode(s))
+			}
+		case "'", "\"":
+			newline()
+			if s, ok := lastOperand[[]byte](operands); ok {
+				b.WriteString(decode(s))
+			}
+		case "TJ":
+			if arr, ok := lastOperand[[]any](operands); ok {
+				for _, el := range arr {
+					switch v := el.(type) {
+					case []byte:
+						b.WriteString(decode(v))
+					case float64:
+						// Large negative kerning is how many generators encode spaces.
+						if v < -200 {
+							space()
+						}
+					}
+				}
+			}
+		case "Tf":
+			if len(operands) >= 2 {
+				if name, ok := operands[len(operands)-2].(string); ok {
+					font = fonts[strings.TrimPrefix(name, "/")]
+				}
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty, ok := operands[len(operands)-1].(float64); ok && ty != 0 {
+					newline()
+				} else {
+					space()
+				}
+			}
+		case "T*", "ET":
+			newline()
+		}
+		operands = operands[:0]
+	}
+
+	return b.String(), dropped
+}
+
+func lastOperand[T any](operands []any) (T, bool) {
+	var zero T
+	if len(operands) == 0 {
+		return zero, false
+	}
+	v, ok := operands[len(operands)-1].(T)
+	return v, ok
+}
+
+// decodePDFString decodes a PDF string as UTF-16 when it starts with a byte
+// order mark and as PDFDocEncoding (close to Latin-1) otherwise. Strings made
+// mostly of control characters come from fonts with custom encodings that
+// can't be decoded without the font program and are dropped.
+func decodePDFString(s []byte) string {
+	if len(s) >= 2 && s[0] == 0xFE && s[1] == 0xFF {
+		return decodeUTF16BE(s[2:])
+	}
+
+	runes := make([]rune, 0, len(s))
+	control := 0
+	for _, c := range s {
+		r := rune(c)
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			control++
+			continue
+		}
+		runes = append(runes, r)
+	}
+	if control > len(s)/2 {
+		return ""
+	}
+	return string(runes)
+}
+
+// pdfOperator is a content stream operator such as Tj or BT.
+type pdfOperator string
+
+// pdfLexer splits a content stream into operands (numbers, strings, names
+// and arrays) and operators.
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func (l *pdfLexer) next() (any, bool) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, false
+	}
+
+	c := l.data[l.pos]
+	switch {
+	case c == '(':
+		return l.literalString(), true
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.skipDictionary()
+		return l.next()
+	case c == '<':
+		return l.hexString(), true
+	case c == '[':
+		l.pos++
+		var arr []any
+		for {
+			l.skipSpace()
+			if l.pos >= len(l.data) {
+				return arr, true
+			}
+			if l.data[l.pos] == ']' {
+				l.pos++
+				return arr, true
+			}
+			el, ok := l.next()
+			if !ok {
+				return arr, true
+			}
+			arr = append(arr, el)
+		}
+	case c == '/':
+		start := l.pos
+		l.pos++
+		for l.pos < len(l.data) && !isPDFDelimiter(l.data[l.pos]) {
+			l.pos++
+		}
+		return string(l.data[start:l.pos]), true
+	case c == ']' || c == ')' || c == '>' || c == '{' || c == '}':
+		l.pos++
+		return l.next()
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	if n, err := strconv.ParseFloat(word, 64); err == nil {
+		return n, true
+	}
+	return pdfOperator(word), true
+}
+
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		if !isPDFSpace(c) {
+			return
+		}
+		l.pos++
+	}
+}
+
+func (l *pdfLexer) skipDictionary() {
+	depth := 0
+	for l.pos+1 < len(l.data) {
+		switch {
+		case l.data[l.pos] == '<' && l.data[l.pos+1] == '<':
+			depth++
+			l.pos += 2
+		case l.data[l.pos] == '>' && l.data[l.pos+1] == '>':
+			depth--
+			l.pos += 2
+			if depth == 0 {
+				return
+			}
+		default:
+			l.pos++
+		}
+	}
+	l.pos = len(l.data)
+}
+
+func (l *pdfLexer) literalString() []byte {
+	l.pos++ // opening parenthesis
+	start := l.pos
+	depth := 1
+	for l.pos < len(l.data) {
+		switch l.data[l.pos] {
+		case '\\':
+			l.pos++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				raw := l.data[start:l.pos]
+				l.pos++
+				return unescapePDFLiteral(raw)
+			}
+		}
+		l.pos++
+	}
+	return unescapePDFLiteral(l.data[start:])
+}
+
+func (l *pdfLexer) hexString() []byte {
+	l.pos++ // opening angle bracket
+	var digits []byte
+	for l.pos < len(l.data) && l.data[l.pos] != '>' {
+		if c := l.data[l.pos]; isHexDigit(c) {
+			digits = append(digits, c)
+		}
+		l.pos++
+	}
+	l.pos++
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	for i := range out {
+		v, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		out[i] = byte(v)
+	}
+	return out
+}
+
+// unescapePDFLiteral resolves the backslash escapes of a literal string.
+func unescapePDFLiteral(raw []byte) []byte {
+	out := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if c != '\\' || i+1 >= len(raw) {
+			out = append(out, c)
+			continue
+		}
+		i++
+		switch e := raw[i]; e {
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 't':
+			out = append(out, '\t')
+		case 'b':
+			out = append(out, '\b')
+		case 'f':
+			out = append(out, '\f')
+		case '\r', '\n':
+			// Line continuation.
+			if e == '\r' && i+1 < len(raw) && raw[i+1] == '\n' {
+				i++
+			}
+		default:
+			if e >= '0' && e <= '7' {
+				n := 0
+				j := i
+				for ; j < len(raw) && j < i+3 && raw[j] >= '0' && raw[j] <= '7'; j++ {
+					n = n*8 + int(raw[j]-'0')
+				}
+				out = append(out, byte(n))
+				i = j - 1
+			} else {
+				out = append(out, e)
+			}
+		}
+	}
+	return out
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return isPDFSpace(c) || strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,334 @@
+package importer
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"unicode/utf16"
+)
+
+const (
+	// maxCMapRange caps the number of codes a single bfrange entry may map.
+	maxCMapRange = 1 << 16
+	// pdfReference matches the object number of an indirect reference.
+	pdfReference = `\s+(\d+)\s+\d+\s+R`
+)
+
+var (
+	pdfObjectStart = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+	pdfPageType    = regexp.MustCompile(`/Type\s*/Page\b`)
+	pdfContentsRef = regexp.MustCompile(`/Contents` + pdfReference)
+	pdfContentsArr = regexp.MustCompile(`/Contents\s*\[([^\]]*)\]`)
+	pdfResourceRef = regexp.MustCompile(`/Resources` + pdfReference)
+	pdfFontRef     = regexp.MustCompile(`/Font` + pdfReference)
+	pdfFontDict    = regexp.MustCompile(`/Font\s*<<([^>]*)>>`)
+	pdfNamedRef    = regexp.MustCompile(`/([^\s/<>\[\]()]+)` + pdfReference)
+	pdfAnyRef      = regexp.MustCompile(`(\d+)\s+\d+\s+R`)
+	pdfToUnicode   = regexp.MustCompile(`/ToUnicode` + pdfReference)
+	pdfObjStmN     = regexp.MustCompile(`/N\s+(\d+)`)
+	pdfObjStmFirst = regexp.MustCompile(`/First\s+(\d+)`)
+)
+
+// pdfObject is an indirect object of a PDF: its dictionary or value and, for
+// streams, the raw stream data.
+type pdfObject struct {
+	dict   []byte
+	stream []byte
+}
+
+// pdfObjects indexes the objects of a PDF by number, including those packed
+// into object streams, and returns the numbers of the top-level objects in
+// file order.
+func pdfObjects(data []byte) (map[int]pdfObject, []int) {
+	objects := make(map[int]pdfObject)
+	var order []int
+
+	pos := 0
+	for {
+		loc := pdfObjectStart.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		start := pos + loc[1]
+
+		end := bytes.Index(data[start:], []byte("endobj"))
+		if end < 0 {
+			end = len(data) - start
+		}
+		obj := pdfObject{dict: data[start : start+end]}
+		if s := pdfStreamStart.FindIndex(obj.dict); s != nil {
+			// The stream data may contain anything, endobj included.
+			streamEnd := bytes.Index(data[start+s[1]:], []byte("endstream"))
+			if streamEnd >= 0 {
+				obj.stream = data[start+s[1] : start+s[1]+streamEnd]
+				obj.dict = data[start : start+s[0]]
+				if e := bytes.Index(data[start+s[1]+streamEnd:], []byte("endobj")); e >= 0 {
+					end = s[1] + streamEnd + e
+				} else {
+					end = len(data) - start
+				}
+			}
+		}
+
+		if _, ok := objects[num]; !ok {
+			order = append(order, num)
+		}
+		objects[num] = obj
+		pos = start + end
+	}
+
+	for _, num := range order {
+		obj := objects[num]
+		if obj.stream != nil && bytes.Contains(obj.dict, []byte("/ObjStm")) {
+			unpackObjectStream(obj, objects)
+		}
+	}
+
+	return objects, order
+}
+
+// unpackObjectStream adds the objects compressed into an object stream to
+// objects. Object streams never hold streams themselves.
+func unpackObjectStream(stm pdfObject, objects map[int]pdfObject) {
+	n, first := pdfObjStmN.FindSubmatch(stm.dict), pdfObjStmFirst.FindSubmatch(stm.dict)
+	content, ok := decodePDFStream(stm)
+	if n == nil || first == nil || !ok {
+		return
+	}
+	count, _ := strconv.Atoi(string(n[1]))
+	offset, _ := strconv.Atoi(string(first[1]))
+	if offset > len(content) {
+		return
+	}
+
+	header := bytes.Fields(content[:offset])
+	var nums, offsets []int
+	for i := 0; i+1 < len(header) && len(nums) < count; i += 2 {
+		num, err1 := strconv.Atoi(string(header[i]))
+		off, err2 := strconv.Atoi(string(header[i+1]))
+		if err1 != nil || err2 != nil || offset+off > len(content) {
+			return
+		}
+		nums = append(nums, num)
+		offsets = append(offsets, offset+off)
+	}
+	for i, num := range nums {
+		end := len(content)
+		if i+1 < len(offsets) {
+			end = offsets[i+1]
+		}
+		if _, ok := objects[num]; !ok && offsets[i] <= end {
+			objects[num] = pdfObject{dict: content[offsets[i]:end]}
+		}
+	}
+}
+
+// decodePDFStream returns the data of an uncompressed or Flate compressed
+// stream. Streams with other filters are not decoded.
+func decodePDFStream(obj pdfObject) ([]byte, bool) {
+	if bytes.Contains(obj.dict, []byte("/FlateDecode")) {
+		r, err := zlib.NewReader(bytes.NewReader(obj.stream))
+		if err != nil {
+			return nil, false
+		}
+		defer r.Close()
+		content, err := io.ReadAll(io.LimitReader(r, maxPDFStreamSize))
+		if err != nil && len(content) == 0 {
+			return nil, false
+		}
+		return content, true
+	}
+	if bytes.Contains(obj.dict, []byte("/Filter")) {
+		return nil, false
+	}
+	return obj.stream, true
+}
+
+// pdfFonts reads the ToUnicode CMaps of the fonts each page uses. It returns
+// them by resource name for each content stream, by object number, and for
+// every font resource name in the file, for the content streams of pages
+// that inherit their resources.
+func pdfFonts(objects map[int]pdfObject) (map[int]map[string]*pdfCMap, map[string]*pdfCMap) {
+	cmaps := make(map[int]*pdfCMap)
+	cmapOf := func(fontNum int) *pdfCMap {
+		m := pdfToUnicode.FindSubmatch(objects[fontNum].dict)
+		if m == nil {
+			return nil
+		}
+		num, _ := strconv.Atoi(string(m[1]))
+		if cmap, ok := cmaps[num]; ok {
+			return cmap
+		}
+		var cmap *pdfCMap
+		if content, ok := decodePDFStream(objects[num]); ok {
+			cmap = parsePDFCMap(content)
+		}
+		cmaps[num] = cmap
+		return cmap
+	}
+	fontsIn := func(dict []byte, fonts map[string]*pdfCMap) {
+		if m := pdfFontRef.FindSubmatch(dict); m != nil {
+			num, _ := strconv.Atoi(string(m[1]))
+			dict = objects[num].dict
+		} else if m := pdfFontDict.FindSubmatch(dict); m != nil {
+			dict = m[1]
+		} else {
+			return
+		}
+		for _, m := range pdfNamedRef.FindAllSubmatch(dict, -1) {
+			num, _ := strconv.Atoi(string(m[2]))
+			if _, ok := fonts[string(m[1])]; !ok {
+				if cmap := cmapOf(num); cmap != nil {
+					fonts[string(m[1])] = cmap
+				}
+			}
+		}
+	}
+
+	byStream := make(map[int]map[string]*pdfCMap)
+	all := make(map[string]*pdfCMap)
+	for _, obj := range objects {
+		fontsIn(obj.dict, all)
+		if !pdfPageType.Match(obj.dict) {
+			continue
+		}
+
+		resources := obj.dict
+		if m := pdfResourceRef.FindSubmatch(obj.dict); m != nil {
+			num, _ := strconv.Atoi(string(m[1]))
+			resources = objects[num].dict
+		}
+		fonts := make(map[string]*pdfCMap)
+		fontsIn(resources, fonts)
+		if len(fonts) == 0 {
+			continue
+		}
+
+		var contents [][]byte
+		if m := pdfContentsRef.FindSubmatch(obj.dict); m != nil {
+			contents = append(contents, m[1])
+		} else if m := pdfContentsArr.FindSubmatch(obj.dict); m != nil {
+			for _, ref := range pdfAnyRef.FindAllSubmatch(m[1], -1) {
+				contents = append(contents, ref[1])
+			}
+		}
+		for _, c := range contents {
+			num, _ := strconv.Atoi(string(c))
+			byStream[num] = fonts
+		}
+	}
+
+	return byStream, all
+}
+
+// pdfCMap maps the character codes of a font to text, as read from its
+// ToUnicode CMap.
+type pdfCMap struct {
+	// width is the number of bytes of a character code.
+	width int
+	chars map[uint32]string
+}
+
+// parsePDFCMap reads the code space and the bfchar and bfrange mappings of a
+// ToUnicode CMap.
+func parsePDFCMap(data []byte) *pdfCMap {
+	cmap := &pdfCMap{width: 1, chars: make(map[uint32]string)}
+
+	var operands []any
+	lex := &pdfLexer{data: data}
+	for {
+		tok, ok := lex.next()
+		if !ok {
+			break
+		}
+		op, isOp := tok.(pdfOperator)
+		if !isOp {
+			operands = append(operands, tok)
+			continue
+		}
+
+		switch op {
+		case "endcodespacerange":
+			if len(operands) > 0 {
+				if low, ok := operands[0].([]byte); ok && len(low) > 0 {
+					cmap.width = min(len(low), 4)
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].([]byte)
+				dst, ok2 := operands[i+1].([]byte)
+				if ok1 && ok2 {
+					cmap.chars[pdfCode(src)] = decodeUTF16BE(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lo, ok1 := operands[i].([]byte)
+				hi, ok2 := operands[i+1].([]byte)
+				if !ok1 || !ok2 {
+					continue
+				}
+				first, last := pdfCode(lo), pdfCode(hi)
+				if last < first || last-first >= maxCMapRange {
+					continue
+				}
+				switch dst := operands[i+2].(type) {
+				case []byte:
+					// Consecutive codes map to consecutive characters.
+					units := utf16BEUnits(dst)
+					if len(units) == 0 {
+						continue
+					}
+					for code := first; code <= last; code++ {
+						cmap.chars[code] = string(utf16.Decode(units))
+						units[len(units)-1]++
+					}
+				case []any:
+					for j, el := range dst {
+						if s, ok := el.([]byte); ok && first+uint32(j) <= last {
+							cmap.chars[first+uint32(j)] = decodeUTF16BE(s)
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+
+	return cmap
+}
+
+// decode maps the character codes of a string shown in the font to text.
+// Codes the CMap doesn't list are dropped.
+func (c *pdfCMap) decode(s []byte) string {
+	var b bytes.Buffer
+	for i := 0; i+c.width <= len(s); i += c.width {
+		b.WriteString(c.chars[pdfCode(s[i:i+c.width])])
+	}
+	return b.String()
+}
+
+// pdfCode reads a big-endian character code.
+func pdfCode(b []byte) uint32 {
+	var code uint32
+	for _, c := range b {
+		code = code<<8 | uint32(c)
+	}
+	return code
+}
+
+func utf16BEUnits(b []byte) []uint16 {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return units
+}
+
+func decodeUTF16BE(b []byte) string {
+	return string(utf16.Decode(utf16BEUnits(b)))
+}
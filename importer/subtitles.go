@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cue is a timed piece of subtitle text.
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+var (
+	subtitleTag       = regexp.MustCompile(`<[^>]*>|\{\\[^}]*\}`)
+	subtitleTimestamp = regexp.MustCompile(`^(?:(\d+):)?(\d{1,2}):(\d{2})[.,](\d{1,3})$`)
+)
+
+// ParseSubtitles parses SRT or WebVTT subtitles. Formatting tags such as <i>
+// or {\an8} are removed from the cue text.
+func ParseSubtitles(data []byte, format Format) ([]Cue, error) {
+	text := normalizeNewlines(string(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	if format == FormatVTT && !strings.HasPrefix(text, "WEBVTT") {
+		return nil, fmt.Errorf("missing WEBVTT header")
+	}
+
+	var cues []Cue
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var (
+		current *Cue
+		lines   []string
+	)
+	finish := func() {
+		if current != nil {
+			current.Text = strings.TrimSpace(subtitleTag.ReplaceAllString(strings.Join(lines, "\n"), ""))
+			if current.Text != "" {
+				cues = append(cues, *current)
+			}
+		}
+		current, lines = nil, nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			finish()
+		case current == nil && strings.Contains(line, "-->"):
+			start, end, err := parseCueTiming(line)
+			if err != nil {
+				return nil, err
+			}
+			current = &Cue{Start: start, End: end}
+		case current != nil:
+			lines = append(lines, line)
+		}
+		// Anything else outside a cue is a cue number, the WEBVTT header,
+		// a NOTE or STYLE block and is skipped.
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read subtitles: %w", err)
+	}
+	finish()
+
+	return cues, nil
+}
+
+// parseCueTiming parses a "00:00:01,000 --> 00:00:04,000" line, ignoring any
+// WebVTT cue settings after the end time.
+func parseCueTiming(line string) (time.Duration, time.Duration, error) {
+	parts := strings.SplitN(line, "-->", 2)
+	endFields := strings.Fields(parts[1])
+	if len(endFields) == 0 {
+		return 0, 0, fmt.Errorf("invalid cue timing %q", line)
+	}
+
+	start, err := ParseTimestamp(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := ParseTimestamp(endFields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("cue ends before it starts: %q", line)
+	}
+	return start, end, nil
+}
+
+// ParseTimestamp parses an SRT or WebVTT timestamp such as "01:02:03,450" or
+// "02:03.450".
+func ParseTimestamp(s string) (time.Duration, error) {
+	m := subtitleTimestamp.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	seconds, _ := strconv.Atoi(m[3])
+	millis, _ := strconv.Atoi((m[4] + "00")[:3])
+
+	return time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(millis)*time.Millisecond, nil
+}
+
+// extractSubtitles turns subtitles into a reading with one cue per line.
+func extractSubtitles(data []byte, format Format) (*Document, error) {
+	cues, err := ParseSubtitles(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse subtitles: %w", err)
+	}
+
+	lines := make([]string, len(cues))
+	for i, c := range cues {
+		lines[i] = strings.ReplaceAll(c.Text, "\n", " ")
+	}
+
+	return &Document{Chapters: []Chapter{{Body: strings.Join(lines, "\n")}}}, nil
+}
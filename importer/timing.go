@@ -0,0 +1,162 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TimedSpan ties a span of audio to a range of text. Start and End are
+// character (rune) offsets into the text, End being exclusive.
+type TimedSpan struct {
+	StartTime time.Duration
+	EndTime   time.Duration
+	Start     int
+	End       int
+}
+
+type timestampEntry struct {
+	StartTime *float64 `json:"startTime"`
+	EndTime   *float64 `json:"endTime"`
+	Start     *int     `json:"start"`
+	End       *int     `json:"end"`
+}
+
+// ParseTimestampJSON reads a JSON list of timed character ranges, as
+// produced by forced aligners:
+//
+//	[{"startTime": 0.0, "endTime": 2.45, "start": 0, "end": 31}, ...]
+//
+// Times are in seconds; start and end are character offsets into the text,
+// end being exclusive.
+func ParseTimestampJSON(data []byte) ([]TimedSpan, error) {
+	var entries []timestampEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse timestamps: %w", err)
+	}
+
+	spans := make([]TimedSpan, len(entries))
+	for i, e := range entries {
+		if e.StartTime == nil || e.EndTime == nil || e.Start == nil || e.End == nil {
+			return nil, fmt.Errorf("timestamp %d: startTime, endTime, start and end are required", i+1)
+		}
+		if *e.StartTime < 0 || *e.EndTime < *e.StartTime {
+			return nil, fmt.Errorf("timestamp %d: invalid time range %g-%g", i+1, *e.StartTime, *e.EndTime)
+		}
+		if *e.Start < 0 || *e.End <= *e.Start {
+			return nil, fmt.Errorf("timestamp %d: invalid character range %d-%d", i+1, *e.Start, *e.End)
+		}
+		spans[i] = TimedSpan{
+			StartTime: seconds(*e.StartTime),
+			EndTime:   seconds(*e.EndTime),
+			Start:     *e.Start,
+			End:       *e.End,
+		}
+	}
+
+	return spans, nil
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Round(s * float64(time.Second)))
+}
+
+// textWord is a word of a text with its rune offsets.
+type textWord struct {
+	word       string
+	start, end int
+}
+
+// AlignCues finds the passage of text read in each subtitle cue. Cues are
+// matched in order by their words, ignoring case and punctuation, so
+// subtitles that were lightly edited still line up with the text. Cues whose
+// words can't be found after the previous match are left out.
+func AlignCues(text string, cues []Cue) []TimedSpan {
+	runes := []rune(text)
+	words := splitWords(runes)
+
+	var spans []TimedSpan
+	next := 0
+	for _, cue := range cues {
+		cueWords := splitWords([]rune(cue.Text))
+		if len(cueWords) == 0 {
+			continue
+		}
+		i := findWords(words, cueWords, next)
+		if i < 0 {
+			continue
+		}
+
+		start, end := words[i].start, words[i+len(cueWords)-1].end
+		// Take in the punctuation closing the passage.
+		for end < len(runes) && !unicode.IsSpace(runes[end]) && !isWordRune(runes[end]) {
+			end++
+		}
+		spans = append(spans, TimedSpan{
+			StartTime: cue.Start,
+			EndTime:   cue.End,
+			Start:     start,
+			End:       end,
+		})
+		next = i + len(cueWords)
+	}
+
+	return spans
+}
+
+// findWords returns the index of the first occurrence of needle in words at
+// or after from, or -1.
+func findWords(words, needle []textWord, from int) int {
+	for i := from; i+len(needle) <= len(words); i++ {
+		match := true
+		for j := range needle {
+			if words[i+j].word != needle[j].word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitWords splits text into lowercased words of letters and digits. Han,
+// hiragana and katakana characters are words of their own, as those scripts
+// don't separate words with spaces.
+func splitWords(runes []rune) []textWord {
+	var words []textWord
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			word := strings.ReplaceAll(strings.ToLower(string(runes[start:end])), "’", "'")
+			words = append(words, textWord{word: word, start: start, end: end})
+			start = -1
+		}
+	}
+	for i, r := range runes {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			flush(i)
+			words = append(words, textWord{word: string(r), start: i, end: i + 1})
+		case isWordRune(r):
+			if start < 0 {
+				start = i
+			}
+		case (r == '\'' || r == '’') && start >= 0 && i+1 < len(runes) && isWordRune(runes[i+1]):
+			// An apostrophe inside a word, as in "don't".
+		default:
+			flush(i)
+		}
+	}
+	flush(len(runes))
+	return words
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE readings
+    ADD COLUMN body TEXT NOT NULL DEFAULT '',
+    ADD COLUMN format VARCHAR(255) NOT NULL DEFAULT 'PLAIN',
+    ADD COLUMN language VARCHAR(255),
+    ADD COLUMN level VARCHAR(255),
+    ADD COLUMN source_url VARCHAR(255),
+    ADD COLUMN author VARCHAR(255),
+    ADD COLUMN word_count BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE readings
+    DROP COLUMN body,
+    DROP COLUMN format,
+    DROP COLUMN language,
+    DROP COLUMN level,
+    DROP COLUMN source_url,
+    DROP COLUMN author,
+    DROP COLUMN word_count,
+    DROP COLUMN created_at,
+    DROP COLUMN updated_at;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE reading_progresses (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reading_id UUID NOT NULL REFERENCES readings(id) ON DELETE CASCADE,
+    position BIGINT NOT NULL DEFAULT 0,
+    position_unit VARCHAR(255) NOT NULL DEFAULT 'CHARACTER',
+    percent DOUBLE PRECISION NOT NULL DEFAULT 0,
+    time_spent_seconds BIGINT NOT NULL DEFAULT 0,
+    last_opened_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX readingprogress_user_id_reading_id ON reading_progresses (user_id, reading_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reading_progresses;
+-- +goose StatementEnd
@@ -0,0 +1,71 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE vocabulary_items (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    term VARCHAR NOT NULL,
+    normalized_term VARCHAR NOT NULL,
+    language VARCHAR NOT NULL DEFAULT '',
+    lemma VARCHAR,
+    translation VARCHAR,
+    notes TEXT,
+    status BIGINT NOT NULL DEFAULT 0,
+    ignored BOOLEAN NOT NULL DEFAULT FALSE,
+    sentence TEXT,
+    reading_id UUID REFERENCES readings(id) ON DELETE SET NULL,
+    status_updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX vocabularyitem_user_id_language_normalized_term ON vocabulary_items (user_id, language, normalized_term);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- Convert the untyped users.saved_words map into rows. Values can be a status
+-- name ("known"), an LWT style number (0 new, 1-4 learning, 5 known,
+-- 98 ignored, 99 well known) or an object with "status", "language" and
+-- "translation" keys. SQL can only approximate tokenizer.Normalize here (no
+-- NFC, no Turkish casing); `make backfill` renormalizes the terms in Go.
+INSERT INTO vocabulary_items (id, user_id, term, normalized_term, language, translation, status, ignored)
+SELECT
+    uuid_generate_v4(),
+    u.id,
+    w.key,
+    lower(replace(w.key, '’', '''')),
+    COALESCE(lower(w.value->>'language'), ''),
+    w.value->>'translation',
+    CASE jsonb_typeof(s.status)
+        WHEN 'number' THEN
+            CASE
+                WHEN (s.status #>> '{}')::numeric = 98 THEN 0
+                ELSE LEAST(GREATEST((s.status #>> '{}')::numeric, 0), 5)::BIGINT
+            END
+        WHEN 'string' THEN
+            CASE upper(s.status #>> '{}')
+                WHEN 'NEW' THEN 0
+                WHEN 'KNOWN' THEN 5
+                WHEN 'IGNORED' THEN 0
+                ELSE 1
+            END
+        ELSE 1
+    END,
+    CASE jsonb_typeof(s.status)
+        WHEN 'number' THEN (s.status #>> '{}')::numeric = 98
+        WHEN 'string' THEN upper(s.status #>> '{}') = 'IGNORED'
+        ELSE FALSE
+    END
+FROM users u
+CROSS JOIN LATERAL jsonb_each(u.saved_words::jsonb) AS w(key, value)
+CROSS JOIN LATERAL (
+    SELECT CASE WHEN jsonb_typeof(w.value) = 'object' THEN w.value->'status' ELSE w.value END AS status
+) s
+WHERE u.saved_words IS NOT NULL
+  AND jsonb_typeof(u.saved_words::jsonb) = 'object'
+  AND w.key <> ''
+ON CONFLICT (user_id, language, normalized_term) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE vocabulary_items;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE flashcards
+    ADD COLUMN vocabulary_item_id UUID REFERENCES vocabulary_items(id) ON DELETE SET NULL,
+    ADD COLUMN reading_id UUID REFERENCES readings(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE flashcards
+    DROP COLUMN vocabulary_item_id,
+    DROP COLUMN reading_id;
+-- +goose StatementEnd
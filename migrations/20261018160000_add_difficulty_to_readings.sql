@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE readings
+    ADD COLUMN estimated_level VARCHAR(255),
+    ADD COLUMN difficulty_score DOUBLE PRECISION;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE readings
+    DROP COLUMN estimated_level,
+    DROP COLUMN difficulty_score;
+-- +goose StatementEnd
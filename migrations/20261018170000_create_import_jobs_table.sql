@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE readings
+    ADD COLUMN parent_id UUID REFERENCES readings(id) ON DELETE CASCADE,
+    ADD COLUMN chapter_index BIGINT;
+CREATE TABLE import_jobs (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reading_id UUID REFERENCES readings(id) ON DELETE SET NULL,
+    filename VARCHAR(255) NOT NULL,
+    format VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(255) NOT NULL DEFAULT 'PENDING',
+    progress DOUBLE PRECISION NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE import_jobs;
+ALTER TABLE readings
+    DROP COLUMN parent_id,
+    DROP COLUMN chapter_index;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE highlights (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reading_id UUID NOT NULL REFERENCES readings(id) ON DELETE CASCADE,
+    start_offset BIGINT NOT NULL,
+    end_offset BIGINT NOT NULL,
+    quote TEXT NOT NULL,
+    prefix VARCHAR(255) NOT NULL DEFAULT '',
+    suffix VARCHAR(255) NOT NULL DEFAULT '',
+    color VARCHAR(255) NOT NULL DEFAULT 'YELLOW',
+    note TEXT,
+    orphaned BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX highlight_reading_id_user_id ON highlights (reading_id, user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE highlights;
+-- +goose StatementEnd
@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE courses (
+    id UUID PRIMARY KEY,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    language VARCHAR(255),
+    level VARCHAR(255),
+    state VARCHAR(255) NOT NULL DEFAULT 'DRAFT',
+    published_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE TABLE course_items (
+    id UUID PRIMARY KEY,
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    position BIGINT NOT NULL,
+    kind VARCHAR(255) NOT NULL,
+    title VARCHAR(255),
+    reading_id UUID REFERENCES readings(id) ON DELETE CASCADE
+);
+CREATE INDEX courseitem_course_id_position ON course_items (course_id, position);
+CREATE TABLE course_item_flashcards (
+    course_item_id UUID NOT NULL REFERENCES course_items(id) ON DELETE CASCADE,
+    flashcard_id UUID NOT NULL REFERENCES flashcards(id) ON DELETE CASCADE,
+    PRIMARY KEY (course_item_id, flashcard_id)
+);
+CREATE TABLE course_enrollments (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    completed_deck_items JSONB,
+    enrolled_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+CREATE UNIQUE INDEX courseenrollment_user_id_course_id ON course_enrollments (user_id, course_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE course_enrollments;
+DROP TABLE course_item_flashcards;
+DROP TABLE course_items;
+DROP TABLE courses;
+-- +goose StatementEnd
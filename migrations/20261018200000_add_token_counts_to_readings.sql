@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Existing readings get their token counts from `make backfill`
+-- (scripts/backfill.go) after migrating.
+ALTER TABLE readings
+    ADD COLUMN token_counts JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE readings
+    DROP COLUMN token_counts;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sentences of existing readings are created the next time their body is
+-- saved or their sentences are requested.
+CREATE TABLE reading_sentences (
+    id UUID PRIMARY KEY,
+    reading_id UUID NOT NULL REFERENCES readings(id) ON DELETE CASCADE,
+    position BIGINT NOT NULL,
+    start_offset BIGINT NOT NULL,
+    end_offset BIGINT NOT NULL,
+    text TEXT NOT NULL
+);
+CREATE INDEX readingsentence_reading_id_position ON reading_sentences (reading_id, position);
+CREATE TABLE sentence_translations (
+    id UUID PRIMARY KEY,
+    sentence_id UUID NOT NULL REFERENCES reading_sentences(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    language VARCHAR(255) NOT NULL,
+    text TEXT NOT NULL,
+    source VARCHAR(255) NOT NULL,
+    provider VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX sentencetranslation_sentence_id_language ON sentence_translations (sentence_id, language);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE sentence_translations;
+DROP TABLE reading_sentences;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE readings
+    ADD COLUMN audio_url VARCHAR(255),
+    ADD COLUMN audio_key VARCHAR(255);
+CREATE TABLE audio_cues (
+    id UUID PRIMARY KEY,
+    reading_id UUID NOT NULL REFERENCES readings(id) ON DELETE CASCADE,
+    position BIGINT NOT NULL,
+    start_ms BIGINT NOT NULL,
+    end_ms BIGINT NOT NULL,
+    start_offset BIGINT NOT NULL,
+    end_offset BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    prefix VARCHAR(255) NOT NULL DEFAULT '',
+    suffix VARCHAR(255) NOT NULL DEFAULT '',
+    orphaned BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE INDEX audiocue_reading_id_position ON audio_cues (reading_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audio_cues;
+ALTER TABLE readings
+    DROP COLUMN audio_url,
+    DROP COLUMN audio_key;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE cached_translations (
+    id UUID PRIMARY KEY,
+    source_language VARCHAR(255) NOT NULL,
+    target_language VARCHAR(255) NOT NULL,
+    text_hash VARCHAR(255) NOT NULL,
+    text TEXT NOT NULL,
+    translation TEXT NOT NULL,
+    provider VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX cachedtranslation_source_language_target_language_text_hash ON cached_translations (source_language, target_language, text_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE cached_translations;
+-- +goose StatementEnd
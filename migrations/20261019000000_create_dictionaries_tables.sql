@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE dictionaries (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    source_language VARCHAR(255) NOT NULL,
+    target_language VARCHAR(255) NOT NULL,
+    format VARCHAR(255) NOT NULL,
+    entry_count BIGINT NOT NULL DEFAULT 0,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX dictionary_source_language_target_language ON dictionaries (source_language, target_language);
+CREATE TABLE dictionary_entries (
+    id UUID PRIMARY KEY,
+    dictionary_id UUID NOT NULL REFERENCES dictionaries(id) ON DELETE CASCADE,
+    headword VARCHAR(255) NOT NULL,
+    normalized_headword VARCHAR(255) NOT NULL,
+    reading VARCHAR(255),
+    part_of_speech VARCHAR(255),
+    definitions JSONB NOT NULL,
+    examples JSONB,
+    rank BIGINT NOT NULL
+);
+CREATE INDEX dictionaryentry_normalized_headword_dictionary_id ON dictionary_entries (normalized_headword, dictionary_id);
+CREATE TABLE inflection_forms (
+    id UUID PRIMARY KEY,
+    language VARCHAR(255) NOT NULL,
+    form VARCHAR(255) NOT NULL,
+    lemma VARCHAR(255) NOT NULL,
+    dictionary_id UUID REFERENCES dictionaries(id) ON DELETE CASCADE
+);
+CREATE INDEX inflectionform_language_form ON inflection_forms (language, form);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE inflection_forms;
+DROP TABLE dictionary_entries;
+DROP TABLE dictionaries;
+-- +goose StatementEnd
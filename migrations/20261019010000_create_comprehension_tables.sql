@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE comprehension_questions (
+    id UUID PRIMARY KEY,
+    reading_id UUID NOT NULL REFERENCES readings(id) ON DELETE CASCADE,
+    position BIGINT NOT NULL,
+    kind VARCHAR(255) NOT NULL,
+    prompt TEXT NOT NULL,
+    choices JSONB,
+    correct_choice BIGINT,
+    correct_value BOOLEAN,
+    accepted_answers JSONB,
+    points BIGINT NOT NULL DEFAULT 1,
+    explanation TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX comprehensionquestion_reading_id_position ON comprehension_questions (reading_id, position);
+CREATE TABLE comprehension_attempts (
+    id UUID PRIMARY KEY,
+    reading_id UUID NOT NULL REFERENCES readings(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    score BIGINT NOT NULL,
+    max_score BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX comprehensionattempt_reading_id_user_id ON comprehension_attempts (reading_id, user_id);
+CREATE TABLE comprehension_answers (
+    id UUID PRIMARY KEY,
+    attempt_id UUID NOT NULL REFERENCES comprehension_attempts(id) ON DELETE CASCADE,
+    question_id UUID NOT NULL REFERENCES comprehension_questions(id) ON DELETE CASCADE,
+    choice BIGINT,
+    value BOOLEAN,
+    text TEXT,
+    correct BOOLEAN NOT NULL,
+    points BIGINT NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE comprehension_answers;
+DROP TABLE comprehension_attempts;
+DROP TABLE comprehension_questions;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE quiz_attempts (
+    id UUID PRIMARY KEY,
+    reading_id UUID NOT NULL REFERENCES readings(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(255) NOT NULL,
+    score BIGINT NOT NULL,
+    total BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX quizattempt_reading_id_user_id ON quiz_attempts (reading_id, user_id);
+CREATE TABLE quiz_answers (
+    id UUID PRIMARY KEY,
+    attempt_id UUID NOT NULL REFERENCES quiz_attempts(id) ON DELETE CASCADE,
+    position BIGINT NOT NULL,
+    sentence TEXT NOT NULL,
+    word VARCHAR(255) NOT NULL,
+    answer VARCHAR(255) NOT NULL,
+    correct BOOLEAN NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE quiz_answers;
+DROP TABLE quiz_attempts;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE reading_revisions (
+    id UUID PRIMARY KEY,
+    reading_id UUID NOT NULL REFERENCES readings(id) ON DELETE CASCADE,
+    number BIGINT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    format VARCHAR(255) NOT NULL,
+    restored_from BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX readingrevision_reading_id_number ON reading_revisions (reading_id, number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE reading_revisions;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE readings
+    ADD COLUMN publication_status VARCHAR(255) NOT NULL DEFAULT 'PRIVATE',
+    ADD COLUMN submitted_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN published_at TIMESTAMP WITH TIME ZONE;
+UPDATE readings SET publication_status = 'PUBLISHED', published_at = updated_at WHERE public;
+CREATE TABLE reading_reviews (
+    id UUID PRIMARY KEY,
+    reading_id UUID NOT NULL REFERENCES readings(id) ON DELETE CASCADE,
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    decision VARCHAR(255) NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX readingreview_reading_id_created_at ON reading_reviews (reading_id, created_at);
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(255) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reading_id UUID REFERENCES readings(id) ON DELETE CASCADE,
+    message TEXT NOT NULL,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX notification_user_id_created_at ON notifications (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notifications;
+DROP TABLE reading_reviews;
+ALTER TABLE readings
+    DROP COLUMN publication_status,
+    DROP COLUMN submitted_at,
+    DROP COLUMN published_at;
+-- +goose StatementEnd
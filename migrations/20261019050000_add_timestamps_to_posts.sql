@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+    ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN published_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+UPDATE posts SET published_at = created_at WHERE NOT draft;
+CREATE INDEX post_draft_publish_at ON posts (draft, publish_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX post_draft_publish_at;
+ALTER TABLE posts
+    DROP COLUMN publish_at,
+    DROP COLUMN published_at,
+    DROP COLUMN created_at,
+    DROP COLUMN updated_at;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE comments (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    post_id UUID REFERENCES posts(id) ON DELETE CASCADE,
+    reading_id UUID REFERENCES readings(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX comment_post_id_parent_id_created_at ON comments (post_id, parent_id, created_at);
+CREATE INDEX comment_reading_id_parent_id_created_at ON comments (reading_id, parent_id, created_at);
+CREATE INDEX comment_parent_id_created_at ON comments (parent_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE comments;
+-- +goose StatementEnd
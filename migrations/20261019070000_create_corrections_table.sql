@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN native_languages JSONB;
+ALTER TABLE posts ADD COLUMN language VARCHAR(255);
+CREATE INDEX post_language_draft ON posts (language, draft);
+CREATE TABLE corrections (
+    id UUID PRIMARY KEY,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sentence BIGINT NOT NULL,
+    start_offset BIGINT NOT NULL,
+    end_offset BIGINT NOT NULL,
+    original TEXT NOT NULL,
+    corrected TEXT NOT NULL,
+    explanation TEXT,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    thanked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE INDEX correction_post_id_sentence ON corrections (post_id, sentence);
+CREATE INDEX correction_user_id_created_at ON corrections (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE corrections;
+DROP INDEX post_language_draft;
+ALTER TABLE posts DROP COLUMN language;
+ALTER TABLE users DROP COLUMN native_languages;
+-- +goose StatementEnd
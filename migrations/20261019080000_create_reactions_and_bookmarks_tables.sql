@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE reactions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID REFERENCES posts(id) ON DELETE CASCADE,
+    reading_id UUID REFERENCES readings(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    kind VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX reaction_user_id_post_id ON reactions (user_id, post_id);
+CREATE UNIQUE INDEX reaction_user_id_reading_id ON reactions (user_id, reading_id);
+CREATE UNIQUE INDEX reaction_user_id_comment_id ON reactions (user_id, comment_id);
+CREATE INDEX reaction_post_id ON reactions (post_id);
+CREATE INDEX reaction_reading_id ON reactions (reading_id);
+CREATE INDEX reaction_comment_id ON reactions (comment_id);
+CREATE TABLE bookmarks (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id UUID REFERENCES posts(id) ON DELETE CASCADE,
+    reading_id UUID REFERENCES readings(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX bookmark_user_id_post_id ON bookmarks (user_id, post_id);
+CREATE UNIQUE INDEX bookmark_user_id_reading_id ON bookmarks (user_id, reading_id);
+CREATE UNIQUE INDEX bookmark_user_id_comment_id ON bookmarks (user_id, comment_id);
+CREATE INDEX bookmark_post_id ON bookmarks (post_id);
+CREATE INDEX bookmark_reading_id ON bookmarks (reading_id);
+CREATE INDEX bookmark_comment_id ON bookmarks (comment_id);
+CREATE INDEX bookmark_user_id_created_at ON bookmarks (user_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE bookmarks;
+DROP TABLE reactions;
+-- +goose StatementEnd
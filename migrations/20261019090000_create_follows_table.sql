@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN target_language VARCHAR(255);
+CREATE TABLE follows (
+    id UUID PRIMARY KEY,
+    follower_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX follow_follower_id_followee_id ON follows (follower_id, followee_id);
+CREATE INDEX follow_followee_id_created_at ON follows (followee_id, created_at);
+CREATE INDEX post_user_id_published_at ON posts (user_id, published_at);
+CREATE INDEX reading_user_id_published_at ON readings (user_id, published_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX reading_user_id_published_at;
+DROP INDEX post_user_id_published_at;
+DROP TABLE follows;
+ALTER TABLE users DROP COLUMN target_language;
+-- +goose StatementEnd
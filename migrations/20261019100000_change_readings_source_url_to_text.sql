@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Imported pages can have addresses longer than 255 characters.
+ALTER TABLE readings
+    ALTER COLUMN source_url TYPE TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE readings
+    ALTER COLUMN source_url TYPE VARCHAR(255) USING LEFT(source_url, 255);
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+//go:build ignore
+
+// backfill fills in the data derived from stored content that migrations
+// can't compute in SQL, such as the token counts of readings stored before
+// they were precomputed and the normalized terms of migrated saved words.
+// Every step skips rows that are already filled in, so it is safe to run
+// more than once:
+//
+//	go run scripts/backfill.go
+package main
+
+import (
+	"context"
+	"log"
+
+	"LinganoGO/config"
+	"LinganoGO/services"
+)
+
+func main() {
+	if err := config.ConnectEntDB(); err != nil {
+		log.Fatalf("Failed to connect to PostgreSQL with Ent: %v", err)
+	}
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+
+	readings, err := services.NewReadingService().BackfillTokenCounts(ctx)
+	if err != nil {
+		log.Fatalf("Failed to backfill token counts: %v", err)
+	}
+	log.Printf("Computed token counts of %d readings", readings)
+
+	words, err := services.NewVocabularyService().NormalizeTerms(ctx)
+	if err != nil {
+		log.Fatalf("Failed to normalize saved words: %v", err)
+	}
+	log.Printf("Normalized %d saved words", words)
+}
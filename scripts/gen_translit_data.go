@@ -0,0 +1,338 @@
+//go:build ignore
+
+// gen_translit_data builds the reading tables embedded by the translit
+// package from the pinyin dictionary of github.com/mozillazg/go-pinyin and
+// the IPA dictionary shipped with github.com/ikawaha/kagome-dict. Neither
+// module is needed at runtime, so they are not part of go.mod; run it with
+//
+//	go run -mod=mod scripts/gen_translit_data.go
+//
+// and drop the requirements it adds to go.mod afterwards (go mod tidy).
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/mozillazg/go-pinyin"
+)
+
+const outDir = "translit/data"
+
+// maxCompoundLen is the longest kanji compound kept in the Japanese table.
+const maxCompoundLen = 4
+
+func main() {
+	if err := writePinyin(filepath.Join(outDir, "pinyin.txt.gz")); err != nil {
+		log.Fatalf("Failed to write pinyin table: %v", err)
+	}
+	if err := writeKanji(filepath.Join(outDir, "kanji.txt.gz")); err != nil {
+		log.Fatalf("Failed to write kanji table: %v", err)
+	}
+}
+
+// writePinyin writes one line per toned syllable followed by every character
+// whose most common reading it is.
+func writePinyin(path string) error {
+	bySyllable := make(map[string][]rune)
+	for code, readings := range pinyin.PinyinDict {
+		r := rune(code)
+		if !unicode.Is(unicode.Han, r) {
+			continue
+		}
+		syllable, _, _ := strings.Cut(readings, ",")
+		if syllable == "" {
+			continue
+		}
+		bySyllable[syllable] = append(bySyllable[syllable], r)
+	}
+
+	lines := make([]string, 0, len(bySyllable))
+	for syllable, runes := range bySyllable {
+		sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+		lines = append(lines, syllable+"\t"+string(runes))
+	}
+	sort.Strings(lines)
+	return writeLines(path, "# toned syllable\tcharacters", lines)
+}
+
+type entry struct {
+	reading string
+	weight  int16
+}
+
+// writeKanji writes the Japanese readings used for furigana:
+//
+//	K<TAB>kanji<TAB>reading on its own<TAB>reading inside compounds
+//	O<TAB>kanji + first kana<TAB>reading of the kanji before that okurigana
+//	C<TAB>compound<TAB>per character readings separated by "|"
+//
+// Compounds are only listed when reading them character by character gives
+// the wrong result; a compound whose reading cannot be split (jukujikun such
+// as 今日) has a single reading.
+func writeKanji(path string) error {
+	d := ipa.Dict()
+
+	best := make(map[string]entry)
+	readings := make(map[rune][]entry)
+	walk(d, func(surface string, id int) {
+		features := d.Contents[id]
+		if len(features) < 2 || (isProperNoun(d, id) && d.Morphs[id].Weight >= rareName) {
+			return
+		}
+		reading := toHiragana(features[len(features)-2])
+		if reading == "" || reading == "*" || !isKana(reading) {
+			return
+		}
+		e := entry{reading: reading, weight: d.Morphs[id].Weight}
+
+		runes := []rune(surface)
+		han := hanPrefix(runes)
+		switch {
+		case han == len(runes) && han <= maxCompoundLen:
+			if prev, ok := best[surface]; !ok || e.weight < prev.weight {
+				best[surface] = e
+			}
+			if han == 1 {
+				readings[runes[0]] = append(readings[runes[0]], e)
+			}
+		case han == 1 && isHiragana(runes[1]):
+			// A kanji followed by okurigana: 食べる is read たべる, so the
+			// kanji reads た.
+			okurigana := string(runes[1:])
+			if stem, ok := strings.CutSuffix(reading, okurigana); ok && stem != "" {
+				key := "~" + string(runes[:2])
+				if prev, ok := best[key]; !ok || e.weight < prev.weight {
+					best[key] = entry{reading: stem, weight: e.weight}
+				}
+			}
+		}
+	})
+
+	for r, list := range readings {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].weight < list[j].weight })
+		readings[r] = list
+	}
+
+	// Count how each kanji is read inside compounds to pick the reading used
+	// when no better information is available (usually the on'yomi).
+	inCompound := make(map[rune]map[string]int)
+	aligned := make(map[string][]string)
+	for surface, e := range best {
+		runes := []rune(surface)
+		if surface[0] == '~' || len(runes) < 2 {
+			continue
+		}
+		parts := align(runes, e.reading, readings)
+		if parts == nil {
+			continue
+		}
+		aligned[surface] = parts
+		for i, r := range runes {
+			if inCompound[r] == nil {
+				inCompound[r] = make(map[string]int)
+			}
+			// A geminated reading only applies before certain sounds.
+			if !strings.HasSuffix(parts[i], "っ") {
+				inCompound[r][parts[i]]++
+			}
+		}
+	}
+
+	var lines []string
+	compound := make(map[rune]string)
+	for r, list := range readings {
+		alone := list[0].reading
+		compound[r] = alone
+		most := 0
+		for reading, n := range inCompound[r] {
+			if n > most || (n == most && reading < compound[r]) {
+				compound[r], most = reading, n
+			}
+		}
+		lines = append(lines, fmt.Sprintf("K\t%c\t%s\t%s", r, alone, compound[r]))
+	}
+
+	for key, e := range best {
+		if rest, ok := strings.CutPrefix(key, "~"); ok {
+			lines = append(lines, fmt.Sprintf("O\t%s\t%s", rest, e.reading))
+		}
+	}
+
+	for surface, e := range best {
+		runes := []rune(surface)
+		if surface[0] == '~' || len(runes) < 2 {
+			continue
+		}
+		guess := ""
+		for _, r := range runes {
+			guess += compound[r]
+		}
+		if guess == e.reading {
+			continue
+		}
+		if parts, ok := aligned[surface]; ok {
+			lines = append(lines, fmt.Sprintf("C\t%s\t%s", surface, strings.Join(parts, "|")))
+		} else {
+			lines = append(lines, fmt.Sprintf("C\t%s\t%s", surface, e.reading))
+		}
+	}
+
+	sort.Strings(lines)
+	return writeLines(path, "# K kanji alone compound | O kanji+okurigana reading | C compound readings", lines)
+}
+
+// rareName is the cost above which proper nouns are left out. IPADIC lists
+// tens of thousands of place and family names; only the common ones (日本,
+// 東京, 田中) are worth their space.
+const rareName = 7000
+
+// isProperNoun reports whether the entry is a name.
+func isProperNoun(d *dict.Dict, id int) bool {
+	for _, pos := range d.POSTable.POSs[id] {
+		if d.POSTable.NameList[pos] == "固有名詞" {
+			return true
+		}
+	}
+	return false
+}
+
+// walk calls fn for every surface form in the dictionary index.
+func walk(d *dict.Dict, fn func(surface string, id int)) {
+	da := d.Index.Da
+	var visit func(p int, prefix []byte)
+	visit = func(p int, prefix []byte) {
+		base := int(da[p].Base)
+		for c := 0; c < 256; c++ {
+			q := base + c
+			if q < 0 || q >= len(da) || int(da[q].Check) != p {
+				continue
+			}
+			if c == 0 {
+				if da[q].Base <= 0 && utf8.Valid(prefix) {
+					for _, id := range d.Index.Search(string(prefix)) {
+						fn(string(prefix), id)
+					}
+				}
+				continue
+			}
+			visit(q, append(prefix, byte(c)))
+		}
+	}
+	visit(0, nil)
+}
+
+// align splits the reading of a compound into one reading per kanji, allowing
+// for rendaku (さ → ざ) and gemination (がく → がっ) between characters.
+func align(runes []rune, reading string, readings map[rune][]entry) []string {
+	if len(runes) == 0 {
+		if reading == "" {
+			return []string{}
+		}
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, e := range readings[runes[0]] {
+		for _, variant := range variants(e.reading) {
+			if seen[variant] {
+				continue
+			}
+			seen[variant] = true
+			if rest, ok := strings.CutPrefix(reading, variant); ok {
+				if parts := align(runes[1:], rest, readings); parts != nil {
+					return append([]string{variant}, parts...)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+var voiced = map[rune]rune{
+	'か': 'が', 'き': 'ぎ', 'く': 'ぐ', 'け': 'げ', 'こ': 'ご',
+	'さ': 'ざ', 'し': 'じ', 'す': 'ず', 'せ': 'ぜ', 'そ': 'ぞ',
+	'た': 'だ', 'ち': 'ぢ', 'つ': 'づ', 'て': 'で', 'と': 'ど',
+	'は': 'ば', 'ひ': 'び', 'ふ': 'ぶ', 'へ': 'べ', 'ほ': 'ぼ',
+}
+
+var semivoiced = map[rune]rune{'は': 'ぱ', 'ひ': 'ぴ', 'ふ': 'ぷ', 'へ': 'ぺ', 'ほ': 'ぽ'}
+
+func variants(reading string) []string {
+	runes := []rune(reading)
+	out := []string{reading}
+	if v, ok := voiced[runes[0]]; ok {
+		out = append(out, string(v)+string(runes[1:]))
+	}
+	if v, ok := semivoiced[runes[0]]; ok {
+		out = append(out, string(v)+string(runes[1:]))
+	}
+	if n := len(runes); n > 1 && strings.ContainsRune("つくちき", runes[n-1]) {
+		out = append(out, string(runes[:n-1])+"っ")
+	}
+	return out
+}
+
+func hanPrefix(runes []rune) int {
+	n := 0
+	for n < len(runes) && (unicode.Is(unicode.Han, runes[n]) || runes[n] == '々') {
+		n++
+	}
+	return n
+}
+
+func toHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, s)
+}
+
+func isHiragana(r rune) bool {
+	return r >= 'ぁ' && r <= 'ゖ'
+}
+
+func isKana(s string) bool {
+	for _, r := range s {
+		if !isHiragana(r) && r != 'ー' {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(path, header string, lines []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewWriterLevel(f, gzip.BestCompression)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(gz)
+	fmt.Fprintln(w, header)
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -8,6 +9,8 @@ import (
 
 	"LinganoGO/config"
 	"LinganoGO/graph"
+	"LinganoGO/services"
+	"LinganoGO/storage"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
@@ -21,6 +24,14 @@ import (
 )
 
 const defaultPort = "8081"
+
+// maxUploadSize is the largest multipart request the server reads: the
+// largest file the API accepts plus room for the operation around it.
+const maxUploadSize = max(services.MaxImportSize, services.MaxAudioSize, services.MaxDictionarySize) + 1<<20
+
+// maxUploadMemory is how much of a multipart request is kept in memory; the
+// rest is buffered in temporary files.
+const maxUploadMemory = 32 << 20
 const horizontalLine = "================================================================"
 
 func displayTitle() {
@@ -48,6 +59,11 @@ func main() {
 	}
 	defer config.DisconnectEntDB()
 
+	// Publish scheduled posts in the background
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go services.NewPostService().RunScheduler(schedulerCtx)
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = defaultPort
@@ -84,11 +100,17 @@ func main() {
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
-	srv.AddTransport(transport.MultipartForm{})
+	srv.AddTransport(transport.MultipartForm{
+		MaxUploadSize: maxUploadSize,
+		MaxMemory:     maxUploadMemory,
+	})
 
 	router.Handle("/", playground.Handler("GraphQL playground", "/graphql"))
 	router.Handle("/query", srv)
 	router.Handle("/graphql", srv) // Main GraphQL endpoint
+
+	// Serve uploaded media such as reading audio
+	router.Handle("/media/*", http.StripPrefix("/media", storage.Handler(storage.Dir())))
 	
 	// Add a health check endpoint
 	router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,411 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"LinganoGO/anchor"
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/audiocue"
+	"LinganoGO/graph/model"
+	"LinganoGO/importer"
+	"LinganoGO/storage"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxAudioSize is the largest audio file attachReadingAudio accepts.
+	MaxAudioSize = 200 << 20
+	// maxCueFileSize is the largest subtitle or timestamp file accepted.
+	maxCueFileSize = 10 << 20
+)
+
+// ErrNotAudio is returned when an uploaded file is not an audio file.
+var ErrNotAudio = errors.New("file is not an audio file")
+
+// audioExtensions are the extensions accepted for audio whose content type
+// can't be sniffed.
+var audioExtensions = map[string]bool{
+	".mp3": true, ".m4a": true, ".aac": true, ".ogg": true, ".oga": true,
+	".opus": true, ".wav": true, ".flac": true, ".webm": true,
+}
+
+// AudioService provides methods for reading audio and its cues using Ent
+type AudioService struct {
+	client *ent.Client
+	store  storage.Store
+}
+
+// NewAudioService creates a new AudioService
+func NewAudioService() *AudioService {
+	return &AudioService{
+		client: config.GetEntClient(),
+		store:  storage.NewLocalStore(storage.Dir(), storage.BaseURL()),
+	}
+}
+
+// AttachAudio stores an audio file for a reading owned by the user, replacing
+// the previous one. Cues are kept, so a re-recorded file can be re-timed.
+func (s *AudioService) AttachAudio(ctx context.Context, readingID, userID uuid.UUID, file graphql.Upload) (*ent.Reading, error) {
+	reading, err := s.ownedReading(ctx, readingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if file.Size > MaxAudioSize {
+		return nil, fmt.Errorf("failed to attach audio: %w", ErrFileTooLarge)
+	}
+
+	ext, err := audioExtension(file)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("audio/%s/%s%s", readingID, uuid.New(), ext)
+	url, err := s.store.Save(ctx, key, io.LimitReader(file.File, MaxAudioSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save audio: %w", err)
+	}
+
+	updated, err := reading.Update().
+		SetAudioURL(url).
+		SetAudioKey(key).
+		Save(ctx)
+	if err != nil {
+		if err := s.store.Delete(ctx, key); err != nil {
+			log.Printf("failed to delete audio %s: %v", key, err)
+		}
+		return nil, fmt.Errorf("failed to attach audio: %w", err)
+	}
+
+	s.deleteFile(ctx, reading.AudioKey)
+	return updated, nil
+}
+
+// RemoveAudio deletes a reading's audio file and its cues.
+func (s *AudioService) RemoveAudio(ctx context.Context, readingID, userID uuid.UUID) (*ent.Reading, error) {
+	reading, err := s.ownedReading(ctx, readingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *ent.Reading
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		if _, err := tx.AudioCue.Delete().Where(audiocue.ReadingID(readingID)).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to delete cues: %w", err)
+		}
+		var err error
+		updated, err = tx.Reading.UpdateOneID(readingID).
+			ClearAudioURL().
+			ClearAudioKey().
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove audio: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.deleteFile(ctx, reading.AudioKey)
+	return updated, nil
+}
+
+// GetCues returns the cues of a reading in order
+func (s *AudioService) GetCues(ctx context.Context, readingID uuid.UUID) ([]*ent.AudioCue, error) {
+	cues, err := s.client.AudioCue.
+		Query().
+		Where(audiocue.ReadingID(readingID)).
+		Order(ent.Asc(audiocue.FieldPosition)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cues: %w", err)
+	}
+
+	return cues, nil
+}
+
+// ImportCues replaces the cues of a reading owned by the user with those of a
+// WebVTT or SRT file, matched to the body by their text, or of a JSON list of
+// timed character ranges. Subtitle cues whose text can't be found in the body
+// are skipped.
+func (s *AudioService) ImportCues(ctx context.Context, readingID, userID uuid.UUID, file graphql.Upload, format *model.CueFormat) ([]*ent.AudioCue, error) {
+	reading, err := s.ownedReading(ctx, readingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file.File, maxCueFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	if len(data) > maxCueFileSize {
+		return nil, fmt.Errorf("failed to import cues: %w", ErrFileTooLarge)
+	}
+
+	var spans []importer.TimedSpan
+	switch cueFormat(file.Filename, data, format) {
+	case model.CueFormatJSON:
+		spans, err = importer.ParseTimestampJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import cues: %w", err)
+		}
+		length := len([]rune(reading.Body))
+		for i, span := range spans {
+			if span.End > length {
+				return nil, fmt.Errorf("timestamp %d: range %d-%d is outside the text", i+1, span.Start, span.End)
+			}
+		}
+	case model.CueFormatSrt:
+		spans, err = alignSubtitles(reading.Body, data, importer.FormatSRT)
+	default:
+		spans, err = alignSubtitles(reading.Body, data, importer.FormatVTT)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to import cues: %w", err)
+	}
+
+	var cues []*ent.AudioCue
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		if _, err := tx.AudioCue.Delete().Where(audiocue.ReadingID(readingID)).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to replace cues: %w", err)
+		}
+
+		creates := make([]*ent.AudioCueCreate, len(spans))
+		for i, span := range spans {
+			selector, err := anchor.New(reading.Body, span.Start, span.End)
+			if err != nil {
+				return fmt.Errorf("cue %d: %w", i+1, err)
+			}
+			creates[i] = tx.AudioCue.
+				Create().
+				SetReadingID(readingID).
+				SetPosition(i).
+				SetStartMs(int(span.StartTime / time.Millisecond)).
+				SetEndMs(int(span.EndTime / time.Millisecond)).
+				SetStart(selector.Start).
+				SetEnd(selector.End).
+				SetText(selector.Quote).
+				SetPrefix(selector.Prefix).
+				SetSuffix(selector.Suffix)
+		}
+		var err error
+		cues, err = tx.AudioCue.CreateBulk(creates...).Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create cues: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cues, nil
+}
+
+// RetimeCues sets new times on cues of a reading owned by the user and then
+// moves every cue by shiftMs milliseconds, if given, e.g. to make up for
+// silence added at the start of a recording.
+func (s *AudioService) RetimeCues(ctx context.Context, readingID, userID uuid.UUID, timings []*model.CueTiming, shiftMs *int) ([]*ent.AudioCue, error) {
+	if _, err := s.ownedReading(ctx, readingID, userID); err != nil {
+		return nil, err
+	}
+
+	cues, err := s.GetCues(ctx, readingID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*ent.AudioCue, len(cues))
+	for _, cue := range cues {
+		byID[cue.ID.String()] = cue
+	}
+
+	type timing struct{ start, end int }
+	changed := make(map[uuid.UUID]timing)
+	for _, t := range timings {
+		cue, ok := byID[t.ID]
+		if !ok {
+			return nil, fmt.Errorf("cue %s does not belong to the reading", t.ID)
+		}
+		changed[cue.ID] = timing{t.StartMs, t.EndMs}
+	}
+	shift := 0
+	if shiftMs != nil {
+		shift = *shiftMs
+	}
+
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		for _, cue := range cues {
+			t, ok := changed[cue.ID]
+			if !ok {
+				t = timing{cue.StartMs, cue.EndMs}
+			}
+			t.start += shift
+			t.end += shift
+			if t.start < 0 || t.end < t.start {
+				return fmt.Errorf("cue %s: invalid time range %d-%d ms", cue.ID, t.start, t.end)
+			}
+			if t.start == cue.StartMs && t.end == cue.EndMs {
+				continue
+			}
+			err := tx.AudioCue.UpdateOneID(cue.ID).
+				SetStartMs(t.start).
+				SetEndMs(t.end).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to retime cue: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCues(ctx, readingID)
+}
+
+func (s *AudioService) ownedReading(ctx context.Context, readingID, userID uuid.UUID) (*ent.Reading, error) {
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if reading.UserID != userID {
+		return nil, fmt.Errorf("failed to change reading audio: %w", ErrForbidden)
+	}
+	return reading, nil
+}
+
+// deleteFile removes a replaced audio file. The reading no longer refers to
+// it, so a failure only leaves an orphaned file behind and is logged.
+func (s *AudioService) deleteFile(ctx context.Context, key *string) {
+	if key == nil {
+		return
+	}
+	if err := s.store.Delete(ctx, *key); err != nil {
+		log.Printf("failed to delete audio %s: %v", *key, err)
+	}
+}
+
+// reanchorAudioCues moves the cues of a reading whose body changed to where
+// their passages now are. Like highlights, cues that lose their passage are
+// marked orphaned and keep their old offsets.
+func reanchorAudioCues(ctx context.Context, tx *ent.Tx, readingID uuid.UUID, body string) error {
+	cues, err := tx.AudioCue.
+		Query().
+		Where(audiocue.ReadingID(readingID)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get cues: %w", err)
+	}
+
+	for _, cue := range cues {
+		old := anchor.Selector{
+			Start:  cue.Start,
+			End:    cue.End,
+			Quote:  cue.Text,
+			Prefix: cue.Prefix,
+			Suffix: cue.Suffix,
+		}
+		selector, ok := anchor.Reanchor(body, old)
+
+		update := cue.Update()
+		switch {
+		case ok && selector == old && !cue.Orphaned:
+			continue
+		case ok:
+			update.
+				SetStart(selector.Start).
+				SetEnd(selector.End).
+				SetText(selector.Quote).
+				SetPrefix(selector.Prefix).
+				SetSuffix(selector.Suffix).
+				SetOrphaned(false)
+		case cue.Orphaned:
+			continue
+		default:
+			update.SetOrphaned(true)
+		}
+
+		if err := update.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to re-anchor cue: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// alignSubtitles parses subtitles and matches their cues to the body.
+func alignSubtitles(body string, data []byte, format importer.Format) ([]importer.TimedSpan, error) {
+	cues, err := importer.ParseSubtitles(data, format)
+	if err != nil {
+		return nil, err
+	}
+	return importer.AlignCues(body, cues), nil
+}
+
+// cueFormat returns the format of a cue file: the requested one, or the one
+// its name or content suggests.
+func cueFormat(filename string, data []byte, format *model.CueFormat) model.CueFormat {
+	if format != nil {
+		return *format
+	}
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	switch {
+	case strings.EqualFold(filepath.Ext(filename), ".json") || bytes.HasPrefix(trimmed, []byte("[")):
+		return model.CueFormatJSON
+	case strings.EqualFold(filepath.Ext(filename), ".srt"):
+		return model.CueFormatSrt
+	case bytes.HasPrefix(trimmed, []byte("WEBVTT")):
+		return model.CueFormatVtt
+	default:
+		return model.CueFormatSrt
+	}
+}
+
+// audioExtension checks that an upload is an audio file and returns the
+// extension to store it with.
+func audioExtension(file graphql.Upload) (string, error) {
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+
+	var head [512]byte
+	n, err := io.ReadFull(file.File, head[:])
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	if _, err := file.File.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	switch {
+	case strings.HasPrefix(contentType, "audio/"),
+		contentType == "application/ogg",
+		contentType == "video/mp4", // .m4a
+		contentType == "video/webm":
+	case contentType == "application/octet-stream" && audioExtensions[ext]:
+	default:
+		return "", fmt.Errorf("%w: %s", ErrNotAudio, contentType)
+	}
+
+	if !audioExtensions[ext] {
+		if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+			ext = exts[0]
+		} else {
+			ext = ""
+		}
+	}
+	return ext, nil
+}
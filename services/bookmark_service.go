@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/bookmark"
+
+	"github.com/google/uuid"
+)
+
+// maxBookmarkPage is the largest number of bookmarks returned at once.
+const maxBookmarkPage = 100
+
+// BookmarkService provides methods for the posts, readings and comments users save for later using Ent
+type BookmarkService struct {
+	client *ent.Client
+}
+
+// NewBookmarkService creates a new BookmarkService
+func NewBookmarkService() *BookmarkService {
+	return &BookmarkService{
+		client: config.GetEntClient(),
+	}
+}
+
+// ToggleBookmark bookmarks a target, or removes the bookmark when the user
+// already has one. It returns whether the target is now bookmarked.
+func (s *BookmarkService) ToggleBookmark(ctx context.Context, userID uuid.UUID, target Target) (bool, error) {
+	if err := checkTargetVisible(ctx, s.client, target, userID); err != nil {
+		return false, fmt.Errorf("failed to bookmark: %w", err)
+	}
+
+	removed, err := s.client.Bookmark.
+		Delete().
+		Where(bookmark.UserID(userID), target.bookmarkPredicate()).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+	if removed > 0 {
+		return false, nil
+	}
+
+	err = s.client.Bookmark.
+		Create().
+		SetUserID(userID).
+		SetNillablePostID(target.PostID).
+		SetNillableReadingID(target.ReadingID).
+		SetNillableCommentID(target.CommentID).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to bookmark: %w", err)
+	}
+
+	return true, nil
+}
+
+// IsBookmarked tells whether the viewer bookmarked a target. Anonymous
+// viewers have no bookmarks.
+func (s *BookmarkService) IsBookmarked(ctx context.Context, target Target, viewerID *uuid.UUID) (bool, error) {
+	if viewerID == nil {
+		return false, nil
+	}
+	exists, err := s.client.Bookmark.
+		Query().
+		Where(bookmark.UserID(*viewerID), target.bookmarkPredicate()).
+		Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get bookmark: %w", err)
+	}
+	return exists, nil
+}
+
+// GetBookmarks returns a page of the user's bookmarks, newest first
+func (s *BookmarkService) GetBookmarks(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*ent.Bookmark, error) {
+	if limit < 1 || limit > maxBookmarkPage {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxBookmarkPage)
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+
+	bookmarks, err := s.client.Bookmark.
+		Query().
+		Where(bookmark.UserID(userID)).
+		Order(ent.Desc(bookmark.FieldCreatedAt), ent.Desc(bookmark.FieldID)).
+		Limit(limit).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookmarks: %w", err)
+	}
+
+	return bookmarks, nil
+}
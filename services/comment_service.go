@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/comment"
+	"LinganoGO/ent/predicate"
+	"LinganoGO/ent/user"
+	"LinganoGO/graph/model"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxCommentLength is the longest comment body in characters.
+	maxCommentLength = 10000
+	// maxCommentPage is the largest number of comments returned at once.
+	maxCommentPage = 100
+)
+
+// CommentService provides methods for threaded comments on posts and readings using Ent
+type CommentService struct {
+	client *ent.Client
+}
+
+// NewCommentService creates a new CommentService
+func NewCommentService() *CommentService {
+	return &CommentService{
+		client: config.GetEntClient(),
+	}
+}
+
+// CreateComment comments on a published post or a public reading, or replies
+// to a comment. Replies belong to the post or reading of their parent.
+func (s *CommentService) CreateComment(ctx context.Context, input model.NewComment) (*ent.Comment, error) {
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	body, err := NormalizeCommentBody(input.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	targets := 0
+	for _, id := range []*string{input.PostID, input.ReadingID, input.ParentID} {
+		if id != nil {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return nil, errors.New("exactly one of postID, readingID and parentID must be set")
+	}
+
+	create := s.client.Comment.
+		Create().
+		SetUserID(userUUID).
+		SetBody(body)
+
+	var postID, readingID *uuid.UUID
+	switch {
+	case input.ParentID != nil:
+		parentUUID, err := uuid.Parse(*input.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent comment ID: %w", err)
+		}
+		parent, err := s.client.Comment.Get(ctx, parentUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent comment: %w", err)
+		}
+		if parent.DeletedAt != nil {
+			return nil, errors.New("cannot reply to a deleted comment")
+		}
+		postID, readingID = parent.PostID, parent.ReadingID
+		create.SetParentID(parentUUID)
+	case input.PostID != nil:
+		postUUID, err := uuid.Parse(*input.PostID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid post ID: %w", err)
+		}
+		postID = &postUUID
+	default:
+		readingUUID, err := uuid.Parse(*input.ReadingID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reading ID: %w", err)
+		}
+		readingID = &readingUUID
+	}
+
+	if postID != nil {
+		p, err := s.client.Post.Get(ctx, *postID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get post: %w", err)
+		}
+		if p.Draft {
+			return nil, fmt.Errorf("failed to create comment: %w", ErrForbidden)
+		}
+		create.SetPostID(*postID)
+	}
+	if readingID != nil {
+		r, err := s.client.Reading.Get(ctx, *readingID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reading: %w", err)
+		}
+		if !r.Public {
+			return nil, fmt.Errorf("failed to create comment: %w", ErrForbidden)
+		}
+		create.SetReadingID(*readingID)
+	}
+
+	created, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateComment changes the body of a comment written by the user
+func (s *CommentService) UpdateComment(ctx context.Context, id, userID uuid.UUID, body string) (*ent.Comment, error) {
+	c, err := s.client.Comment.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if c.DeletedAt != nil {
+		return nil, errors.New("comment has been deleted")
+	}
+	if c.UserID == nil || *c.UserID != userID {
+		return nil, fmt.Errorf("failed to update comment: %w", ErrForbidden)
+	}
+	body, err = NormalizeCommentBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	updated, err := c.Update().
+		SetBody(body).
+		SetEditedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteComment deletes a comment written by the user, or any comment when
+// the user is an admin. A comment with replies is kept as a tombstone so the
+// thread stays intact; tombstones left without replies are removed as well.
+func (s *CommentService) DeleteComment(ctx context.Context, id, userID uuid.UUID) error {
+	return withTx(ctx, s.client, func(tx *ent.Tx) error {
+		c, err := tx.Comment.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+		if c.DeletedAt != nil {
+			return errors.New("comment has been deleted")
+		}
+		if c.UserID == nil || *c.UserID != userID {
+			u, err := tx.User.Get(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("failed to get user: %w", err)
+			}
+			if u.Role != user.RoleADMIN {
+				return fmt.Errorf("failed to delete comment: %w", ErrForbidden)
+			}
+		}
+
+		replies, err := tx.Comment.Query().
+			Where(comment.ParentID(id)).
+			Count(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count replies: %w", err)
+		}
+		if replies > 0 {
+			err := tx.Comment.UpdateOne(c).
+				ClearUserID().
+				SetBody("").
+				SetDeletedAt(time.Now()).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to delete comment: %w", err)
+			}
+			return nil
+		}
+
+		if err := tx.Comment.DeleteOne(c).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+		return pruneTombstones(ctx, tx, c.ParentID)
+	})
+}
+
+// GetComment returns a single comment
+func (s *CommentService) GetComment(ctx context.Context, id uuid.UUID) (*ent.Comment, error) {
+	c, err := s.client.Comment.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return c, nil
+}
+
+// GetPostComments returns a page of the top-level comments on a post
+func (s *CommentService) GetPostComments(ctx context.Context, postID uuid.UUID, limit, offset int) ([]*ent.Comment, error) {
+	return s.page(ctx, limit, offset, comment.PostID(postID), comment.ParentIDIsNil())
+}
+
+// GetReadingComments returns a page of the top-level comments on a reading
+func (s *CommentService) GetReadingComments(ctx context.Context, readingID uuid.UUID, limit, offset int) ([]*ent.Comment, error) {
+	return s.page(ctx, limit, offset, comment.ReadingID(readingID), comment.ParentIDIsNil())
+}
+
+// GetReplies returns a page of the direct replies to a comment
+func (s *CommentService) GetReplies(ctx context.Context, parentID uuid.UUID, limit, offset int) ([]*ent.Comment, error) {
+	return s.page(ctx, limit, offset, comment.ParentID(parentID))
+}
+
+// CountPostComments returns how many comments a post has, replies included
+func (s *CommentService) CountPostComments(ctx context.Context, postID uuid.UUID) (int, error) {
+	return s.count(ctx, comment.PostID(postID))
+}
+
+// CountReadingComments returns how many comments a reading has, replies included
+func (s *CommentService) CountReadingComments(ctx context.Context, readingID uuid.UUID) (int, error) {
+	return s.count(ctx, comment.ReadingID(readingID))
+}
+
+// CountReplies returns how many direct replies a comment has
+func (s *CommentService) CountReplies(ctx context.Context, parentID uuid.UUID) (int, error) {
+	return s.count(ctx, comment.ParentID(parentID))
+}
+
+func (s *CommentService) page(ctx context.Context, limit, offset int, predicates ...predicate.Comment) ([]*ent.Comment, error) {
+	if limit < 1 || limit > maxCommentPage {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxCommentPage)
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+
+	comments, err := s.client.Comment.
+		Query().
+		Where(predicates...).
+		Order(ent.Asc(comment.FieldCreatedAt), ent.Asc(comment.FieldID)).
+		Limit(limit).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+func (s *CommentService) count(ctx context.Context, predicates ...predicate.Comment) (int, error) {
+	count, err := s.client.Comment.
+		Query().
+		Where(predicates...).
+		Where(comment.DeletedAtIsNil()).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+	return count, nil
+}
+
+// pruneTombstones removes deleted ancestors of a removed comment that no
+// longer have any replies, walking up the thread from parentID.
+func pruneTombstones(ctx context.Context, tx *ent.Tx, parentID *uuid.UUID) error {
+	for parentID != nil {
+		parent, err := tx.Comment.Get(ctx, *parentID)
+		if err != nil {
+			return fmt.Errorf("failed to get parent comment: %w", err)
+		}
+		if parent.DeletedAt == nil {
+			return nil
+		}
+		replies, err := tx.Comment.Query().
+			Where(comment.ParentID(parent.ID)).
+			Count(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count replies: %w", err)
+		}
+		if replies > 0 {
+			return nil
+		}
+		if err := tx.Comment.DeleteOne(parent).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+		parentID = parent.ParentID
+	}
+	return nil
+}
+
+// NormalizeCommentBody trims a comment body and checks that it is neither
+// empty nor too long.
+func NormalizeCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", errors.New("comment must not be empty")
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return "", fmt.Errorf("comment must not be longer than %d characters", maxCommentLength)
+	}
+	return body, nil
+}
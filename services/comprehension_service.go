@@ -0,0 +1,621 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/comprehensionanswer"
+	"LinganoGO/ent/comprehensionattempt"
+	"LinganoGO/ent/comprehensionquestion"
+	"LinganoGO/graph/model"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ComprehensionService provides methods for the comprehension questions of readings and the
+// attempts readers make at them using Ent
+type ComprehensionService struct {
+	client *ent.Client
+}
+
+// NewComprehensionService creates a new ComprehensionService
+func NewComprehensionService() *ComprehensionService {
+	return &ComprehensionService{
+		client: config.GetEntClient(),
+	}
+}
+
+// questionDraft is a question's content before it is saved, used to validate
+// new questions and the result of applying changes to existing ones.
+type questionDraft struct {
+	kind            comprehensionquestion.Kind
+	prompt          string
+	choices         []string
+	correctChoice   *int
+	correctValue    *bool
+	acceptedAnswers []string
+	points          int
+}
+
+// check validates the draft and drops the answer fields its kind doesn't use.
+func (d *questionDraft) check() error {
+	d.prompt = strings.TrimSpace(d.prompt)
+	if d.prompt == "" {
+		return fmt.Errorf("a question needs a prompt")
+	}
+	if d.points < 1 {
+		return fmt.Errorf("a question is worth at least one point")
+	}
+
+	switch d.kind {
+	case comprehensionquestion.KindMULTIPLE_CHOICE:
+		choices := make([]string, 0, len(d.choices))
+		for _, choice := range d.choices {
+			choice = strings.TrimSpace(choice)
+			if choice == "" {
+				return fmt.Errorf("choices can't be empty")
+			}
+			choices = append(choices, choice)
+		}
+		if len(choices) < 2 {
+			return fmt.Errorf("multiple choice questions need at least two choices")
+		}
+		if d.correctChoice == nil || *d.correctChoice < 0 || *d.correctChoice >= len(choices) {
+			return fmt.Errorf("the correct choice must be the index of one of the choices")
+		}
+		d.choices = choices
+		d.correctValue, d.acceptedAnswers = nil, nil
+	case comprehensionquestion.KindTRUE_FALSE:
+		if d.correctValue == nil {
+			return fmt.Errorf("true/false questions need a correct value")
+		}
+		d.choices, d.correctChoice, d.acceptedAnswers = nil, nil, nil
+	case comprehensionquestion.KindSHORT_ANSWER:
+		var accepted []string
+		for _, answer := range d.acceptedAnswers {
+			if answer = strings.TrimSpace(answer); answer != "" && !slices.Contains(accepted, answer) {
+				accepted = append(accepted, answer)
+			}
+		}
+		if len(accepted) == 0 {
+			return fmt.Errorf("short answer questions need at least one accepted answer")
+		}
+		d.acceptedAnswers = accepted
+		d.choices, d.correctChoice, d.correctValue = nil, nil, nil
+	default:
+		return fmt.Errorf("invalid question kind %q", d.kind)
+	}
+	return nil
+}
+
+// AddQuestion adds a question at the end of a reading owned by the user
+func (s *ComprehensionService) AddQuestion(ctx context.Context, input model.NewComprehensionQuestion) (*ent.ComprehensionQuestion, error) {
+	readingUUID, err := uuid.Parse(input.ReadingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	if _, err := s.ownedReading(ctx, readingUUID, userUUID); err != nil {
+		return nil, err
+	}
+
+	draft := questionDraft{
+		kind:            input.Kind,
+		prompt:          input.Prompt,
+		choices:         input.Choices,
+		correctChoice:   input.CorrectChoice,
+		correctValue:    input.CorrectValue,
+		acceptedAnswers: input.AcceptedAnswers,
+		points:          1,
+	}
+	if input.Points != nil {
+		draft.points = *input.Points
+	}
+	if err := draft.check(); err != nil {
+		return nil, err
+	}
+
+	var created *ent.ComprehensionQuestion
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		count, err := tx.ComprehensionQuestion.
+			Query().
+			Where(comprehensionquestion.ReadingID(readingUUID)).
+			Count(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count questions: %w", err)
+		}
+
+		create := tx.ComprehensionQuestion.
+			Create().
+			SetReadingID(readingUUID).
+			SetPosition(count).
+			SetKind(draft.kind).
+			SetPrompt(draft.prompt).
+			SetNillableCorrectChoice(draft.correctChoice).
+			SetNillableCorrectValue(draft.correctValue).
+			SetPoints(draft.points)
+		if draft.choices != nil {
+			create.SetChoices(draft.choices)
+		}
+		if draft.acceptedAnswers != nil {
+			create.SetAcceptedAnswers(draft.acceptedAnswers)
+		}
+		if input.Explanation != nil && strings.TrimSpace(*input.Explanation) != "" {
+			create.SetExplanation(strings.TrimSpace(*input.Explanation))
+		}
+
+		created, err = create.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to add question: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateQuestion changes a question of a reading owned by the user. Changing
+// the kind requires the answer fields of the new kind.
+func (s *ComprehensionService) UpdateQuestion(ctx context.Context, id, userID uuid.UUID, input model.UpdateComprehensionQuestion) (*ent.ComprehensionQuestion, error) {
+	existing, err := s.ownedQuestion(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := questionDraft{
+		kind:            existing.Kind,
+		prompt:          existing.Prompt,
+		choices:         existing.Choices,
+		correctChoice:   existing.CorrectChoice,
+		correctValue:    existing.CorrectValue,
+		acceptedAnswers: existing.AcceptedAnswers,
+		points:          existing.Points,
+	}
+	if input.Kind != nil {
+		draft.kind = *input.Kind
+	}
+	if input.Prompt != nil {
+		draft.prompt = *input.Prompt
+	}
+	if input.Choices != nil {
+		draft.choices = input.Choices
+	}
+	if input.CorrectChoice != nil {
+		draft.correctChoice = input.CorrectChoice
+	}
+	if input.CorrectValue != nil {
+		draft.correctValue = input.CorrectValue
+	}
+	if input.AcceptedAnswers != nil {
+		draft.acceptedAnswers = input.AcceptedAnswers
+	}
+	if input.Points != nil {
+		draft.points = *input.Points
+	}
+	if err := draft.check(); err != nil {
+		return nil, err
+	}
+
+	var updated *ent.ComprehensionQuestion
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		update := tx.ComprehensionQuestion.
+			UpdateOneID(id).
+			SetKind(draft.kind).
+			SetPrompt(draft.prompt).
+			SetPoints(draft.points)
+		if draft.choices != nil {
+			update.SetChoices(draft.choices)
+		} else {
+			update.ClearChoices()
+		}
+		if draft.correctChoice != nil {
+			update.SetCorrectChoice(*draft.correctChoice)
+		} else {
+			update.ClearCorrectChoice()
+		}
+		if draft.correctValue != nil {
+			update.SetCorrectValue(*draft.correctValue)
+		} else {
+			update.ClearCorrectValue()
+		}
+		if draft.acceptedAnswers != nil {
+			update.SetAcceptedAnswers(draft.acceptedAnswers)
+		} else {
+			update.ClearAcceptedAnswers()
+		}
+		if input.Explanation != nil {
+			if explanation := strings.TrimSpace(*input.Explanation); explanation == "" {
+				update.ClearExplanation()
+			} else {
+				update.SetExplanation(explanation)
+			}
+		}
+		if _, err := update.Save(ctx); err != nil {
+			return fmt.Errorf("failed to update question: %w", err)
+		}
+
+		if input.Position != nil && *input.Position != existing.Position {
+			if err := moveQuestion(ctx, tx, existing.ReadingID, id, *input.Position); err != nil {
+				return err
+			}
+		}
+
+		updated, err = tx.ComprehensionQuestion.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get question: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteQuestion deletes a question of a reading owned by the user, along
+// with the answers given to it. Past attempts keep their score.
+func (s *ComprehensionService) DeleteQuestion(ctx context.Context, id, userID uuid.UUID) error {
+	existing, err := s.ownedQuestion(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return withTx(ctx, s.client, func(tx *ent.Tx) error {
+		if err := tx.ComprehensionQuestion.DeleteOneID(id).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to delete question: %w", err)
+		}
+		_, err := tx.ComprehensionQuestion.
+			Update().
+			Where(
+				comprehensionquestion.ReadingID(existing.ReadingID),
+				comprehensionquestion.PositionGT(existing.Position),
+			).
+			AddPosition(-1).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to renumber questions: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetQuestions returns the questions of a reading the user can view, in
+// order. The answers and explanations are left out until the user made an
+// attempt, unless they own the reading.
+func (s *ComprehensionService) GetQuestions(ctx context.Context, readingID, userID uuid.UUID) ([]*ent.ComprehensionQuestion, error) {
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if !canViewReading(reading, &userID) {
+		return nil, fmt.Errorf("failed to get questions: %w", ErrForbidden)
+	}
+
+	questions, err := s.questions(ctx, readingID)
+	if err != nil {
+		return nil, err
+	}
+	if reading.UserID == userID {
+		return questions, nil
+	}
+
+	attempted, err := s.client.ComprehensionAttempt.
+		Query().
+		Where(
+			comprehensionattempt.ReadingID(readingID),
+			comprehensionattempt.UserID(userID),
+		).
+		Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempts: %w", err)
+	}
+	if attempted {
+		return questions, nil
+	}
+
+	hidden := make([]*ent.ComprehensionQuestion, len(questions))
+	for i, q := range questions {
+		copied := *q
+		copied.CorrectChoice, copied.CorrectValue, copied.AcceptedAnswers, copied.Explanation = nil, nil, nil, nil
+		hidden[i] = &copied
+	}
+	return hidden, nil
+}
+
+// SubmitAnswers scores the user's answers to the questions of a reading they
+// can view and records the attempt. Questions left unanswered count as wrong;
+// answering a question that isn't part of the reading is an error.
+func (s *ComprehensionService) SubmitAnswers(ctx context.Context, readingID, userID uuid.UUID, answers []*model.ComprehensionAnswerInput) (*ent.ComprehensionAttempt, error) {
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if !canViewReading(reading, &userID) {
+		return nil, fmt.Errorf("failed to submit answers: %w", ErrForbidden)
+	}
+
+	questions, err := s.questions(ctx, readingID)
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("reading has no comprehension questions")
+	}
+
+	given := make(map[uuid.UUID]*model.ComprehensionAnswerInput, len(answers))
+	for _, answer := range answers {
+		id, err := uuid.Parse(answer.QuestionID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid question ID: %w", err)
+		}
+		if !slices.ContainsFunc(questions, func(q *ent.ComprehensionQuestion) bool { return q.ID == id }) {
+			return nil, fmt.Errorf("question %s is not part of this reading", id)
+		}
+		if _, ok := given[id]; ok {
+			return nil, fmt.Errorf("question %s is answered twice", id)
+		}
+		given[id] = answer
+	}
+
+	var attempt *ent.ComprehensionAttempt
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		score, maxScore := 0, 0
+		correct := make(map[uuid.UUID]bool, len(questions))
+		for _, q := range questions {
+			maxScore += q.Points
+			if answer := given[q.ID]; answer != nil && ScoreAnswer(q, *answer) {
+				correct[q.ID] = true
+				score += q.Points
+			}
+		}
+
+		attempt, err = tx.ComprehensionAttempt.
+			Create().
+			SetReadingID(readingID).
+			SetUserID(userID).
+			SetScore(score).
+			SetMaxScore(maxScore).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to record attempt: %w", err)
+		}
+
+		builders := make([]*ent.ComprehensionAnswerCreate, 0, len(questions))
+		for _, q := range questions {
+			create := tx.ComprehensionAnswer.
+				Create().
+				SetAttemptID(attempt.ID).
+				SetQuestionID(q.ID).
+				SetCorrect(false).
+				SetPoints(0)
+			if answer := given[q.ID]; answer != nil {
+				create.
+					SetNillableChoice(answer.Choice).
+					SetNillableValue(answer.Value).
+					SetNillableText(answer.Text)
+			}
+			if correct[q.ID] {
+				create.SetCorrect(true).SetPoints(q.Points)
+			}
+			builders = append(builders, create)
+		}
+		if _, err := tx.ComprehensionAnswer.CreateBulk(builders...).Save(ctx); err != nil {
+			return fmt.Errorf("failed to record answers: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+// GetAttempts returns the user's own attempts at a reading's questions, newest first
+func (s *ComprehensionService) GetAttempts(ctx context.Context, readingID, userID uuid.UUID) ([]*ent.ComprehensionAttempt, error) {
+	attempts, err := s.client.ComprehensionAttempt.
+		Query().
+		Where(
+			comprehensionattempt.ReadingID(readingID),
+			comprehensionattempt.UserID(userID),
+		).
+		Order(ent.Desc(comprehensionattempt.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// GetResults sums up the attempts at the questions of a reading owned by the
+// user. Averages and per question results use each student's latest attempt.
+func (s *ComprehensionService) GetResults(ctx context.Context, readingID, userID uuid.UUID) (*model.ComprehensionResults, error) {
+	if _, err := s.ownedReading(ctx, readingID, userID); err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.client.ComprehensionAttempt.
+		Query().
+		Where(comprehensionattempt.ReadingID(readingID)).
+		Order(ent.Desc(comprehensionattempt.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempts: %w", err)
+	}
+	questions, err := s.questions(ctx, readingID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &model.ComprehensionResults{
+		ReadingID:    readingID.String(),
+		AttemptCount: len(attempts),
+		Questions:    make([]*model.QuestionResult, 0, len(questions)),
+		Attempts:     attempts,
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var latest []uuid.UUID
+	total := 0.0
+	for _, attempt := range attempts {
+		if seen[attempt.UserID] {
+			continue
+		}
+		seen[attempt.UserID] = true
+		latest = append(latest, attempt.ID)
+		total += AttemptPercentage(attempt)
+	}
+	results.StudentCount = len(latest)
+	if len(latest) > 0 {
+		results.AveragePercentage = total / float64(len(latest))
+	}
+
+	answers, err := s.client.ComprehensionAnswer.
+		Query().
+		Where(comprehensionanswer.AttemptIDIn(latest...)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+	byQuestion := make(map[uuid.UUID]*model.QuestionResult, len(questions))
+	for _, q := range questions {
+		result := &model.QuestionResult{Question: q}
+		byQuestion[q.ID] = result
+		results.Questions = append(results.Questions, result)
+	}
+	for _, answer := range answers {
+		result, ok := byQuestion[answer.QuestionID]
+		if !ok {
+			continue
+		}
+		result.AnswerCount++
+		if answer.Correct {
+			result.CorrectCount++
+		}
+	}
+	for _, result := range results.Questions {
+		if result.AnswerCount > 0 {
+			result.CorrectRate = float64(result.CorrectCount) / float64(result.AnswerCount)
+		}
+	}
+
+	return results, nil
+}
+
+// ScoreAnswer reports whether an answer to a question is correct. Short
+// answers are compared ignoring case, surrounding punctuation and extra
+// spaces.
+func ScoreAnswer(q *ent.ComprehensionQuestion, answer model.ComprehensionAnswerInput) bool {
+	switch q.Kind {
+	case comprehensionquestion.KindMULTIPLE_CHOICE:
+		return answer.Choice != nil && q.CorrectChoice != nil && *answer.Choice == *q.CorrectChoice
+	case comprehensionquestion.KindTRUE_FALSE:
+		return answer.Value != nil && q.CorrectValue != nil && *answer.Value == *q.CorrectValue
+	case comprehensionquestion.KindSHORT_ANSWER:
+		if answer.Text == nil {
+			return false
+		}
+		given := normalizeAnswer(*answer.Text)
+		if given == "" {
+			return false
+		}
+		for _, accepted := range q.AcceptedAnswers {
+			if normalizeAnswer(accepted) == given {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeAnswer puts a short answer in a form where trivially different
+// spellings compare equal: "  The Moon. " and "the moon".
+func normalizeAnswer(answer string) string {
+	answer = strings.ToLower(norm.NFC.String(answer))
+	answer = strings.Join(strings.Fields(answer), " ")
+	return strings.TrimFunc(answer, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSpace(r)
+	})
+}
+
+// AttemptPercentage returns the score of an attempt as a percentage of the
+// points it could have scored.
+func AttemptPercentage(attempt *ent.ComprehensionAttempt) float64 {
+	if attempt.MaxScore == 0 {
+		return 0
+	}
+	return float64(attempt.Score) / float64(attempt.MaxScore) * 100
+}
+
+// questions returns the questions of a reading in order
+func (s *ComprehensionService) questions(ctx context.Context, readingID uuid.UUID) ([]*ent.ComprehensionQuestion, error) {
+	questions, err := s.client.ComprehensionQuestion.
+		Query().
+		Where(comprehensionquestion.ReadingID(readingID)).
+		Order(ent.Asc(comprehensionquestion.FieldPosition)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	return questions, nil
+}
+
+func (s *ComprehensionService) ownedReading(ctx context.Context, readingID, userID uuid.UUID) (*ent.Reading, error) {
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if reading.UserID != userID {
+		return nil, fmt.Errorf("failed to change comprehension questions: %w", ErrForbidden)
+	}
+	return reading, nil
+}
+
+func (s *ComprehensionService) ownedQuestion(ctx context.Context, id, userID uuid.UUID) (*ent.ComprehensionQuestion, error) {
+	q, err := s.client.ComprehensionQuestion.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	if _, err := s.ownedReading(ctx, q.ReadingID, userID); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// moveQuestion moves a question to position, clamped to the question count,
+// and renumbers the others.
+func moveQuestion(ctx context.Context, tx *ent.Tx, readingID, id uuid.UUID, position int) error {
+	ids, err := tx.ComprehensionQuestion.
+		Query().
+		Where(comprehensionquestion.ReadingID(readingID)).
+		Order(ent.Asc(comprehensionquestion.FieldPosition)).
+		IDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get questions: %w", err)
+	}
+
+	ids = slices.DeleteFunc(ids, func(other uuid.UUID) bool { return other == id })
+	position = max(0, min(position, len(ids)))
+	ids = slices.Insert(ids, position, id)
+	for i, other := range ids {
+		if err := tx.ComprehensionQuestion.UpdateOneID(other).SetPosition(i).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to move question: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/correction"
+	"LinganoGO/ent/post"
+	"LinganoGO/ent/user"
+	"LinganoGO/graph/model"
+	"LinganoGO/textdiff"
+	"LinganoGO/tokenizer"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// maxCorrectionMatches is the largest number of posts or helpers returned
+// when matching posts with native speakers.
+const maxCorrectionMatches = 100
+
+// CorrectionService provides methods for correcting the sentences of posts using Ent
+type CorrectionService struct {
+	client *ent.Client
+}
+
+// NewCorrectionService creates a new CorrectionService
+func NewCorrectionService() *CorrectionService {
+	return &CorrectionService{
+		client: config.GetEntClient(),
+	}
+}
+
+// SubmitCorrection corrects a sentence of another user's published post
+func (s *CorrectionService) SubmitCorrection(ctx context.Context, input model.NewCorrection) (*ent.Correction, error) {
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	postUUID, err := uuid.Parse(input.PostID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid post ID: %w", err)
+	}
+
+	p, err := s.client.Post.Get(ctx, postUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if p.Draft {
+		return nil, fmt.Errorf("failed to submit correction: %w", ErrForbidden)
+	}
+	if p.UserID == userUUID {
+		return nil, errors.New("authors cannot correct their own posts")
+	}
+
+	sentence, err := PostSentence(p, input.Sentence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit correction: %w", err)
+	}
+	corrected := strings.TrimSpace(input.Corrected)
+	if corrected == "" {
+		return nil, errors.New("corrected text must not be empty")
+	}
+	if corrected == sentence.Text {
+		return nil, errors.New("correction does not change the sentence")
+	}
+
+	create := s.client.Correction.
+		Create().
+		SetPostID(postUUID).
+		SetUserID(userUUID).
+		SetSentence(input.Sentence).
+		SetStart(sentence.Start).
+		SetEnd(sentence.End).
+		SetOriginal(sentence.Text).
+		SetCorrected(corrected)
+	if input.Explanation != nil && strings.TrimSpace(*input.Explanation) != "" {
+		create.SetExplanation(strings.TrimSpace(*input.Explanation))
+	}
+
+	created, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit correction: %w", err)
+	}
+
+	return created, nil
+}
+
+// AcceptCorrection marks a correction as accepted by the post's author
+func (s *CorrectionService) AcceptCorrection(ctx context.Context, id, userID uuid.UUID) (*ent.Correction, error) {
+	c, err := s.authorsCorrection(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept correction: %w", err)
+	}
+	if c.AcceptedAt != nil {
+		return c, nil
+	}
+
+	c, err = c.Update().
+		SetAcceptedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept correction: %w", err)
+	}
+	return c, nil
+}
+
+// ThankCorrection records the post's author thanking the corrector
+func (s *CorrectionService) ThankCorrection(ctx context.Context, id, userID uuid.UUID) (*ent.Correction, error) {
+	c, err := s.authorsCorrection(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to thank corrector: %w", err)
+	}
+	if c.ThankedAt != nil {
+		return c, nil
+	}
+
+	c, err = c.Update().
+		SetThankedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to thank corrector: %w", err)
+	}
+	return c, nil
+}
+
+// GetCorrections returns the corrections of a post ordered by sentence, then
+// oldest first
+func (s *CorrectionService) GetCorrections(ctx context.Context, postID uuid.UUID) ([]*ent.Correction, error) {
+	corrections, err := s.client.Correction.
+		Query().
+		Where(correction.PostID(postID)).
+		WithPost().
+		Order(ent.Asc(correction.FieldSentence), ent.Asc(correction.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get corrections: %w", err)
+	}
+	return corrections, nil
+}
+
+// PostsToCorrect returns the newest published posts of other users written in
+// one of the user's native languages. Regional variants match their base
+// language, so a native "pt" speaker sees posts in "pt-br".
+func (s *CorrectionService) PostsToCorrect(ctx context.Context, userID uuid.UUID, limit int) ([]*ent.Post, error) {
+	if limit < 1 || limit > maxCorrectionMatches {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxCorrectionMatches)
+	}
+
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	bases := BaseLanguages(u.NativeLanguages)
+	if len(bases) == 0 {
+		return []*ent.Post{}, nil
+	}
+
+	posts, err := s.client.Post.
+		Query().
+		Where(
+			func(s *sql.Selector) {
+				s.Where(sql.P(func(b *sql.Builder) {
+					writeBaseLanguage(b, s.C(post.FieldLanguage))
+					b.WriteString(" IN ").Wrap(func(b *sql.Builder) {
+						for i, base := range bases {
+							if i > 0 {
+								b.Comma()
+							}
+							b.Arg(base)
+						}
+					})
+				}))
+			},
+			post.Draft(false),
+			post.UserIDNEQ(userID),
+		).
+		Order(ent.Desc(post.FieldPublishedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts to correct: %w", err)
+	}
+	return posts, nil
+}
+
+// CorrectionHelpers returns users other than the author who speak the post's
+// base language natively, in any regional variant. A post without a language
+// has no helpers.
+func (s *CorrectionService) CorrectionHelpers(ctx context.Context, postID uuid.UUID, limit int) ([]*ent.User, error) {
+	if limit < 1 || limit > maxCorrectionMatches {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxCorrectionMatches)
+	}
+
+	p, err := s.client.Post.Get(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if p.Language == nil {
+		return []*ent.User{}, nil
+	}
+
+	helpers, err := s.client.User.
+		Query().
+		Where(
+			user.IDNEQ(p.UserID),
+			func(s *sql.Selector) {
+				column := s.C(user.FieldNativeLanguages)
+				s.Where(sql.P(func(b *sql.Builder) {
+					// native_languages may hold a JSON null, which has no elements.
+					b.WriteString("EXISTS (SELECT 1 FROM jsonb_array_elements_text(CASE WHEN jsonb_typeof(").
+						WriteString(column).
+						WriteString(") = 'array' THEN ").
+						WriteString(column).
+						WriteString(" ELSE '[]' END) AS native(language) WHERE ")
+					writeBaseLanguage(b, "native.language")
+					b.WriteString(" = ").Arg(baseLanguage(*p.Language)).WriteString(")")
+				}))
+			},
+		).
+		Order(ent.Asc(user.FieldName)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get correction helpers: %w", err)
+	}
+	return helpers, nil
+}
+
+// BaseLanguages returns the distinct base languages of language codes, without
+// their region or script: "pt-BR" and "pt_PT" both become "pt".
+func BaseLanguages(languages []string) []string {
+	result := make([]string, 0, len(languages))
+	for _, language := range languages {
+		if base := baseLanguage(language); base != "" && !slices.Contains(result, base) {
+			result = append(result, base)
+		}
+	}
+	return result
+}
+
+func baseLanguage(language string) string {
+	language = normalizeLanguage(language)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return language
+}
+
+// writeBaseLanguage writes the SQL for the base language of a language column,
+// matching baseLanguage.
+func writeBaseLanguage(b *sql.Builder, column string) {
+	b.WriteString("lower(split_part(translate(").
+		WriteString(column).
+		WriteString(", '_', '-'), '-', 1))")
+}
+
+// CorrectionDiff compares the corrected sentence with the original word by
+// word, in the language of the corrected post
+func CorrectionDiff(c *ent.Correction, p *ent.Post) []*model.DiffChunk {
+	return diffChunks(textdiff.Words(c.Original, c.Corrected, postLanguage(p)))
+}
+
+// PostSentences splits the body of a post into sentences
+func PostSentences(p *ent.Post) []*model.PostSentence {
+	sentences := tokenizer.Sentences(p.Body, postLanguage(p))
+	result := make([]*model.PostSentence, len(sentences))
+	for i, sentence := range sentences {
+		result[i] = &model.PostSentence{
+			Index: i,
+			Text:  sentence.Text,
+			Start: sentence.Start,
+			End:   sentence.End,
+		}
+	}
+	return result
+}
+
+// PostSentence returns the sentence of a post at index
+func PostSentence(p *ent.Post, index int) (tokenizer.Sentence, error) {
+	sentences := tokenizer.Sentences(p.Body, postLanguage(p))
+	if index < 0 || index >= len(sentences) {
+		return tokenizer.Sentence{}, fmt.Errorf("post has no sentence %d", index)
+	}
+	return sentences[index], nil
+}
+
+func postLanguage(p *ent.Post) string {
+	if p.Language == nil {
+		return ""
+	}
+	return *p.Language
+}
+
+func (s *CorrectionService) authorsCorrection(ctx context.Context, id, userID uuid.UUID) (*ent.Correction, error) {
+	c, err := s.client.Correction.
+		Query().
+		Where(correction.ID(id)).
+		WithPost().
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get correction: %w", err)
+	}
+	if c.Edges.Post.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return c, nil
+}
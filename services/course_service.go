@@ -0,0 +1,686 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/course"
+	"LinganoGO/ent/courseenrollment"
+	"LinganoGO/ent/courseitem"
+	"LinganoGO/ent/flashcard"
+	"LinganoGO/ent/readingprogress"
+	"LinganoGO/graph/model"
+
+	"github.com/google/uuid"
+)
+
+// ErrCourseNotPublished is returned when enrolling in a course that isn't published.
+var ErrCourseNotPublished = errors.New("course is not published")
+
+// CourseService provides methods for courses, their items and enrollments using Ent
+type CourseService struct {
+	client *ent.Client
+}
+
+// NewCourseService creates a new CourseService
+func NewCourseService() *CourseService {
+	return &CourseService{
+		client: config.GetEntClient(),
+	}
+}
+
+// CreateCourse creates a draft course owned by the user in the input
+func (s *CourseService) CreateCourse(ctx context.Context, input model.NewCourse) (*ent.Course, error) {
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	create := s.client.Course.
+		Create().
+		SetOwnerID(userUUID).
+		SetTitle(input.Title).
+		SetNillableLevel(input.Level)
+	if input.Description != nil {
+		create.SetDescription(*input.Description)
+	}
+	if input.Language != nil {
+		create.SetLanguage(normalizeLanguage(*input.Language))
+	}
+
+	created, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create course: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateCourse updates a course owned by the user. Publishing requires the
+// course to have items and every reading in it to be public, so that enrolled
+// learners can open them.
+func (s *CourseService) UpdateCourse(ctx context.Context, id, userID uuid.UUID, input model.UpdateCourse) (*ent.Course, error) {
+	existing, err := s.getOwnedCourse(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	update := existing.Update()
+	if input.Title != nil {
+		update.SetTitle(*input.Title)
+	}
+	if input.Description != nil {
+		update.SetDescription(*input.Description)
+	}
+	if input.Language != nil {
+		update.SetLanguage(normalizeLanguage(*input.Language))
+	}
+	if input.Level != nil {
+		update.SetLevel(*input.Level)
+	}
+	if input.State != nil && *input.State != existing.State {
+		if *input.State == course.StatePUBLISHED {
+			if err := s.checkPublishable(ctx, id); err != nil {
+				return nil, err
+			}
+			if existing.PublishedAt == nil {
+				update.SetPublishedAt(time.Now())
+			}
+		}
+		update.SetState(*input.State)
+	}
+
+	updated, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update course: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteCourse deletes a course owned by the user, with its items and enrollments
+func (s *CourseService) DeleteCourse(ctx context.Context, id, userID uuid.UUID) error {
+	if _, err := s.getOwnedCourse(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.client.Course.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete course: %w", err)
+	}
+
+	return nil
+}
+
+// GetCourseForUser retrieves a course if it is published, owned by the given
+// user, or archived and the user is enrolled in it. A nil userID means an
+// anonymous viewer.
+func (s *CourseService) GetCourseForUser(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*ent.Course, error) {
+	c, err := s.client.Course.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course: %w", err)
+	}
+
+	visible, err := s.canViewCourse(ctx, c, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, fmt.Errorf("failed to get course: %w", ErrForbidden)
+	}
+
+	return c, nil
+}
+
+// GetPublishedCourses retrieves the published courses matching the filter, newest first
+func (s *CourseService) GetPublishedCourses(ctx context.Context, filter *model.CourseFilter) ([]*ent.Course, error) {
+	query := s.client.Course.
+		Query().
+		Where(course.StateEQ(course.StatePUBLISHED))
+
+	if filter != nil {
+		if filter.Language != nil {
+			query.Where(course.Language(normalizeLanguage(*filter.Language)))
+		}
+		if filter.MinLevel != nil || filter.MaxLevel != nil {
+			query.Where(course.LevelIn(levelRange(filter.MinLevel, filter.MaxLevel)...))
+		}
+		if filter.Search != nil && strings.TrimSpace(*filter.Search) != "" {
+			search := strings.TrimSpace(*filter.Search)
+			query.Where(course.Or(
+				course.TitleContainsFold(search),
+				course.DescriptionContainsFold(search),
+			))
+		}
+	}
+
+	courses, err := query.
+		Order(ent.Desc(course.FieldPublishedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courses: %w", err)
+	}
+
+	return courses, nil
+}
+
+// GetCoursesByOwner retrieves the courses a user created, in any state
+func (s *CourseService) GetCoursesByOwner(ctx context.Context, userID uuid.UUID) ([]*ent.Course, error) {
+	courses, err := s.client.Course.
+		Query().
+		Where(course.OwnerID(userID)).
+		Order(ent.Desc(course.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courses by owner: %w", err)
+	}
+
+	return courses, nil
+}
+
+// GetEnrolledCourses retrieves the courses a user is enrolled in, most recent enrollment first
+func (s *CourseService) GetEnrolledCourses(ctx context.Context, userID uuid.UUID) ([]*ent.Course, error) {
+	enrollments, err := s.client.CourseEnrollment.
+		Query().
+		Where(courseenrollment.UserID(userID)).
+		Order(ent.Desc(courseenrollment.FieldEnrolledAt)).
+		WithCourse().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrolled courses: %w", err)
+	}
+
+	courses := make([]*ent.Course, len(enrollments))
+	for i, e := range enrollments {
+		courses[i] = e.Edges.Course
+	}
+
+	return courses, nil
+}
+
+// GetItems retrieves the items of a course in order
+func (s *CourseService) GetItems(ctx context.Context, courseID uuid.UUID) ([]*ent.CourseItem, error) {
+	items, err := s.client.CourseItem.
+		Query().
+		Where(courseitem.CourseID(courseID)).
+		Order(ent.Asc(courseitem.FieldPosition)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course items: %w", err)
+	}
+
+	return items, nil
+}
+
+// CountEnrollments returns the number of learners enrolled in a course
+func (s *CourseService) CountEnrollments(ctx context.Context, courseID uuid.UUID) (int, error) {
+	count, err := s.client.CourseEnrollment.
+		Query().
+		Where(courseenrollment.CourseID(courseID)).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count enrollments: %w", err)
+	}
+
+	return count, nil
+}
+
+// AddItem adds a reading or a deck of flashcards to a course owned by the
+// user. Readings must be public or the owner's, and public once the course is
+// no longer a draft; flashcards must be the owner's.
+func (s *CourseService) AddItem(ctx context.Context, input model.NewCourseItem) (*ent.CourseItem, error) {
+	courseUUID, err := uuid.Parse(input.CourseID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid course ID: %w", err)
+	}
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	c, err := s.getOwnedCourse(ctx, courseUUID, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		readingUUID    *uuid.UUID
+		flashcardUUIDs []uuid.UUID
+	)
+	switch input.Kind {
+	case courseitem.KindREADING:
+		if input.ReadingID == nil {
+			return nil, fmt.Errorf("reading items need a reading ID")
+		}
+		id, err := uuid.Parse(*input.ReadingID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reading ID: %w", err)
+		}
+		reading, err := s.client.Reading.Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reading: %w", err)
+		}
+		if !canViewReading(reading, &userUUID) {
+			return nil, fmt.Errorf("failed to add course item: %w", ErrForbidden)
+		}
+		// Learners enrolled in a published course must be able to open it.
+		if c.State != course.StateDRAFT && !reading.Public {
+			return nil, fmt.Errorf("reading %q must be public before it can be added to a %s course", reading.Title, strings.ToLower(string(c.State)))
+		}
+		readingUUID = &id
+	case courseitem.KindDECK:
+		if len(input.FlashcardIDs) == 0 {
+			return nil, fmt.Errorf("deck items need at least one flashcard")
+		}
+		for _, raw := range input.FlashcardIDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid flashcard ID: %w", err)
+			}
+			flashcardUUIDs = append(flashcardUUIDs, id)
+		}
+		flashcardUUIDs = uniqueIDs(flashcardUUIDs)
+		owned, err := s.client.Flashcard.
+			Query().
+			Where(
+				flashcard.IDIn(flashcardUUIDs...),
+				flashcard.UserID(userUUID),
+			).
+			Count(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get flashcards: %w", err)
+		}
+		if owned != len(flashcardUUIDs) {
+			return nil, fmt.Errorf("failed to add course item: %w", ErrForbidden)
+		}
+	default:
+		return nil, fmt.Errorf("invalid course item kind %q", input.Kind)
+	}
+
+	var created *ent.CourseItem
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		count, err := tx.CourseItem.
+			Query().
+			Where(courseitem.CourseID(courseUUID)).
+			Count(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count course items: %w", err)
+		}
+
+		position := count
+		if input.Position != nil && *input.Position >= 0 && *input.Position < count {
+			position = *input.Position
+			err := tx.CourseItem.
+				Update().
+				Where(
+					courseitem.CourseID(courseUUID),
+					courseitem.PositionGTE(position),
+				).
+				AddPosition(1).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to move course items: %w", err)
+			}
+		}
+
+		create := tx.CourseItem.
+			Create().
+			SetCourseID(courseUUID).
+			SetKind(input.Kind).
+			SetPosition(position).
+			SetNillableReadingID(readingUUID).
+			AddFlashcardIDs(flashcardUUIDs...)
+		if input.Title != nil {
+			create.SetTitle(*input.Title)
+		}
+		created, err = create.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create course item: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// RemoveItem removes an item from a course owned by the user and closes the gap it leaves
+func (s *CourseService) RemoveItem(ctx context.Context, id, userID uuid.UUID) error {
+	item, err := s.client.CourseItem.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get course item: %w", err)
+	}
+	if _, err := s.getOwnedCourse(ctx, item.CourseID, userID); err != nil {
+		return err
+	}
+
+	return withTx(ctx, s.client, func(tx *ent.Tx) error {
+		if err := tx.CourseItem.DeleteOneID(id).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to delete course item: %w", err)
+		}
+		err := tx.CourseItem.
+			Update().
+			Where(
+				courseitem.CourseID(item.CourseID),
+				courseitem.PositionGT(item.Position),
+			).
+			AddPosition(-1).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to move course items: %w", err)
+		}
+		return nil
+	})
+}
+
+// ReorderItems puts the items of a course owned by the user in the given
+// order. itemIDs must list every item of the course exactly once.
+func (s *CourseService) ReorderItems(ctx context.Context, courseID, userID uuid.UUID, itemIDs []uuid.UUID) ([]*ent.CourseItem, error) {
+	if _, err := s.getOwnedCourse(ctx, courseID, userID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.GetItems(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if len(uniqueIDs(itemIDs)) != len(itemIDs) || len(itemIDs) != len(items) {
+		return nil, fmt.Errorf("item IDs must list every item of the course exactly once")
+	}
+	for _, item := range items {
+		if !slices.Contains(itemIDs, item.ID) {
+			return nil, fmt.Errorf("item IDs must list every item of the course exactly once")
+		}
+	}
+
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		for position, id := range itemIDs {
+			if err := tx.CourseItem.UpdateOneID(id).SetPosition(position).Exec(ctx); err != nil {
+				return fmt.Errorf("failed to move course item: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetItems(ctx, courseID)
+}
+
+// Enroll enrolls a user in a published course. Enrolling twice returns the
+// existing enrollment. An enrollment in a course whose items are all complete
+// already starts out completed.
+func (s *CourseService) Enroll(ctx context.Context, courseID, userID uuid.UUID) (*ent.CourseEnrollment, error) {
+	c, err := s.client.Course.Get(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course: %w", err)
+	}
+	if c.State != course.StatePUBLISHED {
+		return nil, fmt.Errorf("failed to enroll: %w", ErrCourseNotPublished)
+	}
+
+	enrollment, err := s.getEnrollment(ctx, courseID, userID)
+	if err != nil || enrollment != nil {
+		return enrollment, err
+	}
+
+	enrollment, err = s.client.CourseEnrollment.
+		Create().
+		SetCourseID(courseID).
+		SetUserID(userID).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll: %w", err)
+	}
+
+	// Learners may have finished every reading of the course already.
+	progress, err := s.updateCompletion(ctx, courseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return progress.Enrollment, nil
+}
+
+// Leave removes a user's enrollment in a course
+func (s *CourseService) Leave(ctx context.Context, courseID, userID uuid.UUID) error {
+	_, err := s.client.CourseEnrollment.
+		Delete().
+		Where(
+			courseenrollment.CourseID(courseID),
+			courseenrollment.UserID(userID),
+		).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to leave course: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteItem marks a deck item as done for an enrolled learner. Reading
+// items are completed by finishing the reading instead.
+func (s *CourseService) CompleteItem(ctx context.Context, itemID, userID uuid.UUID) (*model.CourseProgress, error) {
+	item, err := s.client.CourseItem.Get(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course item: %w", err)
+	}
+	if item.Kind != courseitem.KindDECK {
+		return nil, fmt.Errorf("reading items are completed by finishing the reading")
+	}
+
+	enrollment, err := s.getEnrollment(ctx, item.CourseID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment == nil {
+		return nil, fmt.Errorf("failed to complete course item: %w", ErrForbidden)
+	}
+
+	if !slices.Contains(enrollment.CompletedDeckItems, itemID) {
+		err := enrollment.Update().
+			SetCompletedDeckItems(append(enrollment.CompletedDeckItems, itemID)).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to complete course item: %w", err)
+		}
+	}
+
+	return s.updateCompletion(ctx, item.CourseID, userID)
+}
+
+// GetProgress computes a learner's progress through a course from their
+// reading progress and completed decks. The first item that isn't complete is
+// the next one to study.
+func (s *CourseService) GetProgress(ctx context.Context, courseID, userID uuid.UUID) (*model.CourseProgress, error) {
+	c, err := s.GetCourseForUser(ctx, courseID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.GetItems(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := s.getEnrollment(ctx, courseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var readingIDs []uuid.UUID
+	for _, item := range items {
+		if item.ReadingID != nil {
+			readingIDs = append(readingIDs, *item.ReadingID)
+		}
+	}
+	progresses, err := s.client.ReadingProgress.
+		Query().
+		Where(
+			readingprogress.UserID(userID),
+			readingprogress.ReadingIDIn(readingIDs...),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading progress: %w", err)
+	}
+	byReading := make(map[uuid.UUID]*ent.ReadingProgress, len(progresses))
+	for _, p := range progresses {
+		byReading[p.ReadingID] = p
+	}
+
+	progress := &model.CourseProgress{
+		Course:     c,
+		Enrollment: enrollment,
+		TotalItems: len(items),
+		Items:      make([]*model.CourseItemProgress, len(items)),
+	}
+	var percentSum float64
+	for i, item := range items {
+		itemProgress := &model.CourseItemProgress{Item: item}
+		switch {
+		case item.ReadingID != nil:
+			if p, ok := byReading[*item.ReadingID]; ok {
+				itemProgress.Completed = p.FinishedAt != nil
+				itemProgress.Percent = p.Percent
+			}
+		case enrollment != nil:
+			itemProgress.Completed = slices.Contains(enrollment.CompletedDeckItems, item.ID)
+		}
+		if itemProgress.Completed {
+			itemProgress.Percent = 100
+			progress.CompletedItems++
+		} else if progress.NextItem == nil {
+			progress.NextItem = item
+		}
+		percentSum += itemProgress.Percent
+		progress.Items[i] = itemProgress
+	}
+	if len(items) > 0 {
+		progress.Percent = percentSum / float64(len(items))
+	}
+
+	return progress, nil
+}
+
+// CompleteCoursesWithReading marks the user's enrollments completed in the
+// courses that include a reading they finished, once every item is complete.
+func (s *CourseService) CompleteCoursesWithReading(ctx context.Context, userID, readingID uuid.UUID) error {
+	enrollments, err := s.client.CourseEnrollment.
+		Query().
+		Where(
+			courseenrollment.UserID(userID),
+			courseenrollment.CompletedAtIsNil(),
+			courseenrollment.HasCourseWith(course.HasItemsWith(courseitem.ReadingID(readingID))),
+		).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get enrollments: %w", err)
+	}
+
+	for _, enrollment := range enrollments {
+		if _, err := s.updateCompletion(ctx, enrollment.CourseID, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateCompletion returns a learner's progress through a course, marking
+// their enrollment completed first if every item is complete. Completion is
+// only recorded where progress changes, so reading progress never writes.
+func (s *CourseService) updateCompletion(ctx context.Context, courseID, userID uuid.UUID) (*model.CourseProgress, error) {
+	progress, err := s.GetProgress(ctx, courseID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment := progress.Enrollment
+	if enrollment != nil && enrollment.CompletedAt == nil && progress.TotalItems > 0 && progress.CompletedItems == progress.TotalItems {
+		enrollment, err = enrollment.Update().SetCompletedAt(time.Now()).Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to complete enrollment: %w", err)
+		}
+		progress.Enrollment = enrollment
+	}
+
+	return progress, nil
+}
+
+// getOwnedCourse retrieves a course and checks that the user owns it
+func (s *CourseService) getOwnedCourse(ctx context.Context, id, userID uuid.UUID) (*ent.Course, error) {
+	c, err := s.client.Course.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course: %w", err)
+	}
+	if c.OwnerID != userID {
+		return nil, fmt.Errorf("failed to change course: %w", ErrForbidden)
+	}
+
+	return c, nil
+}
+
+// getEnrollment returns the user's enrollment in a course, or nil if there is none
+func (s *CourseService) getEnrollment(ctx context.Context, courseID, userID uuid.UUID) (*ent.CourseEnrollment, error) {
+	enrollment, err := s.client.CourseEnrollment.
+		Query().
+		Where(
+			courseenrollment.CourseID(courseID),
+			courseenrollment.UserID(userID),
+		).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollment: %w", err)
+	}
+
+	return enrollment, nil
+}
+
+// canViewCourse reports whether a course is published, owned by the user, or
+// archived with the user enrolled. A nil userID means an anonymous viewer.
+func (s *CourseService) canViewCourse(ctx context.Context, c *ent.Course, userID *uuid.UUID) (bool, error) {
+	switch {
+	case c.State == course.StatePUBLISHED:
+		return true, nil
+	case userID == nil:
+		return false, nil
+	case c.OwnerID == *userID:
+		return true, nil
+	case c.State == course.StateARCHIVED:
+		enrollment, err := s.getEnrollment(ctx, c.ID, *userID)
+		return enrollment != nil, err
+	}
+	return false, nil
+}
+
+// checkPublishable returns an error if a course has no items or contains
+// readings that aren't public.
+func (s *CourseService) checkPublishable(ctx context.Context, courseID uuid.UUID) error {
+	items, err := s.client.CourseItem.
+		Query().
+		Where(courseitem.CourseID(courseID)).
+		WithReading().
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get course items: %w", err)
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("a course needs at least one item to be published")
+	}
+	for _, item := range items {
+		if r := item.Edges.Reading; r != nil && !r.Public {
+			return fmt.Errorf("reading %q must be public before the course can be published", r.Title)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,439 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"LinganoGO/config"
+	"LinganoGO/dictionary"
+	"LinganoGO/ent"
+	entdictionary "LinganoGO/ent/dictionary"
+	"LinganoGO/ent/dictionaryentry"
+	"LinganoGO/ent/inflectionform"
+	"LinganoGO/ent/user"
+	"LinganoGO/graph/model"
+	"LinganoGO/tokenizer"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxDictionarySize is the largest dictionary file importDictionary accepts.
+	MaxDictionarySize = 200 << 20
+	// maxLookupEntries is the most entries a lookup returns.
+	maxLookupEntries = 50
+	// dictionaryBatchSize is the number of rows inserted per statement.
+	dictionaryBatchSize = 1000
+	// maxHeadwordLength is the longest headword, in characters, stored.
+	maxHeadwordLength = 255
+
+	lookupCacheSize = 10000
+	lookupCacheTTL  = 10 * time.Minute
+)
+
+// DictionaryService provides methods for dictionaries and word lookups using Ent
+type DictionaryService struct {
+	client *ent.Client
+	cache  *lruCache[*model.WordLookup]
+}
+
+// NewDictionaryService creates a new DictionaryService
+func NewDictionaryService() *DictionaryService {
+	return &DictionaryService{
+		client: config.GetEntClient(),
+		cache:  newLRUCache[*model.WordLookup](lookupCacheSize, lookupCacheTTL),
+	}
+}
+
+// ImportDictionary imports a StarDict, Yomichan or TSV dictionary. Dictionaries
+// are shared by everyone, so only admins can import them.
+func (s *DictionaryService) ImportDictionary(ctx context.Context, file graphql.Upload, input model.ImportDictionaryInput) (*ent.Dictionary, error) {
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	if err := s.requireAdmin(ctx, userUUID); err != nil {
+		return nil, err
+	}
+	from, to := normalizeLanguage(input.SourceLanguage), normalizeLanguage(input.TargetLanguage)
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("source and target languages are required")
+	}
+
+	data, size, err := bufferUpload(file, MaxDictionarySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import dictionary: %w", err)
+	}
+	defer os.Remove(data.Name())
+	defer data.Close()
+
+	var format dictionary.Format
+	if input.Format != nil {
+		format = dictionary.Format(*input.Format)
+	} else if format, err = dictionary.DetectFormat(data, size); err != nil {
+		return nil, fmt.Errorf("failed to import dictionary: %w", err)
+	}
+	parsed, err := dictionary.Parse(data, size, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import dictionary: %w", err)
+	}
+
+	name := parsed.Title
+	if input.Name != nil && strings.TrimSpace(*input.Name) != "" {
+		name = strings.TrimSpace(*input.Name)
+	}
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(file.Filename), filepath.Ext(file.Filename))
+	}
+
+	var dict *ent.Dictionary
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		var err error
+		dict, err = tx.Dictionary.
+			Create().
+			SetName(name).
+			SetSourceLanguage(from).
+			SetTargetLanguage(to).
+			SetFormat(entdictionary.Format(format)).
+			SetUserID(userUUID).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create dictionary: %w", err)
+		}
+
+		var (
+			creates []*ent.DictionaryEntryCreate
+			count   int
+		)
+		flush := func() error {
+			if len(creates) == 0 {
+				return nil
+			}
+			err := tx.DictionaryEntry.CreateBulk(creates...).Exec(ctx)
+			creates = creates[:0]
+			if err != nil {
+				return fmt.Errorf("failed to create dictionary entries: %w", err)
+			}
+			return nil
+		}
+		for _, entry := range parsed.Entries {
+			normalized := tokenizer.Normalize(entry.Headword, from)
+			if normalized == "" || utf8.RuneCountInString(entry.Headword) > maxHeadwordLength {
+				continue
+			}
+			create := tx.DictionaryEntry.
+				Create().
+				SetDictionaryID(dict.ID).
+				SetHeadword(entry.Headword).
+				SetNormalizedHeadword(normalized).
+				SetDefinitions(entry.Definitions).
+				SetExamples(entry.Examples).
+				SetRank(count)
+			if entry.Reading != "" {
+				create.SetReading(truncate(entry.Reading, maxHeadwordLength))
+			}
+			if entry.PartOfSpeech != "" {
+				create.SetPartOfSpeech(truncate(entry.PartOfSpeech, maxHeadwordLength))
+			}
+			creates = append(creates, create)
+			count++
+			if len(creates) == dictionaryBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("failed to import dictionary: it has no entries")
+		}
+
+		dictID := dict.ID
+		if err := createInflections(ctx, tx, from, &dictID, parsed.Inflections); err != nil {
+			return err
+		}
+
+		dict, err = dict.Update().SetEntryCount(count).Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update dictionary: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.Clear()
+	return dict, nil
+}
+
+// ImportInflectionTable replaces the inflection table of a language with the
+// forms of a tab-separated file and returns the number of forms imported.
+// Forms that came with dictionaries are kept. Only admins can import tables.
+func (s *DictionaryService) ImportInflectionTable(ctx context.Context, userID uuid.UUID, language string, file graphql.Upload) (int, error) {
+	if err := s.requireAdmin(ctx, userID); err != nil {
+		return 0, err
+	}
+	language = normalizeLanguage(language)
+	if language == "" {
+		return 0, fmt.Errorf("language is required")
+	}
+
+	data, _, err := bufferUpload(file, MaxDictionarySize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import inflection table: %w", err)
+	}
+	defer os.Remove(data.Name())
+	defer data.Close()
+	inflections, err := dictionary.ParseInflections(data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import inflection table: %w", err)
+	}
+
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		_, err := tx.InflectionForm.
+			Delete().
+			Where(
+				inflectionform.Language(language),
+				inflectionform.DictionaryIDIsNil(),
+			).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to replace inflection table: %w", err)
+		}
+		return createInflections(ctx, tx, language, nil, inflections)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.cache.Clear()
+	return len(inflections), nil
+}
+
+// DeleteDictionary deletes a dictionary with its entries. Only admins can
+// delete dictionaries.
+func (s *DictionaryService) DeleteDictionary(ctx context.Context, id, userID uuid.UUID) error {
+	if err := s.requireAdmin(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.client.Dictionary.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete dictionary: %w", err)
+	}
+
+	s.cache.Clear()
+	return nil
+}
+
+// GetDictionaries returns the dictionaries, optionally only those for a
+// source or target language
+func (s *DictionaryService) GetDictionaries(ctx context.Context, from, to *string) ([]*ent.Dictionary, error) {
+	query := s.client.Dictionary.Query()
+	if from != nil {
+		query.Where(entdictionary.SourceLanguage(normalizeLanguage(*from)))
+	}
+	if to != nil {
+		query.Where(entdictionary.TargetLanguage(normalizeLanguage(*to)))
+	}
+
+	dictionaries, err := query.
+		Order(ent.Asc(entdictionary.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dictionaries: %w", err)
+	}
+
+	return dictionaries, nil
+}
+
+// LookupWord finds a word in the dictionaries from one language to another.
+// Inflected forms are found under their lemmas, from the inflection tables
+// and by stripping inflectional endings. Entries for the word itself come
+// first. Results are cached.
+func (s *DictionaryService) LookupWord(ctx context.Context, term, from, to string) (*model.WordLookup, error) {
+	term = strings.TrimSpace(term)
+	from, to = normalizeLanguage(from), normalizeLanguage(to)
+	if term == "" {
+		return nil, fmt.Errorf("term must not be empty")
+	}
+
+	normalized := tokenizer.Normalize(term, from)
+	key := from + "\x00" + to + "\x00" + normalized
+	if lookup, ok := s.cache.Get(key); ok {
+		return lookup, nil
+	}
+
+	lookup := &model.WordLookup{Term: term, Lemmas: []string{}, Entries: []*ent.DictionaryEntry{}}
+
+	dictionaries, err := s.client.Dictionary.
+		Query().
+		Where(
+			entdictionary.SourceLanguage(from),
+			entdictionary.TargetLanguage(to),
+		).
+		Order(ent.Asc(entdictionary.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dictionaries: %w", err)
+	}
+	if len(dictionaries) == 0 {
+		s.cache.Put(key, lookup)
+		return lookup, nil
+	}
+	dictionaryOrder := make(map[uuid.UUID]int, len(dictionaries))
+	dictionaryIDs := make([]uuid.UUID, len(dictionaries))
+	for i, d := range dictionaries {
+		dictionaryOrder[d.ID] = i
+		dictionaryIDs[i] = d.ID
+	}
+
+	tableLemmas, err := s.client.InflectionForm.
+		Query().
+		Where(
+			inflectionform.Language(from),
+			inflectionform.Form(normalized),
+		).
+		Select(inflectionform.FieldLemma).
+		Strings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inflections: %w", err)
+	}
+
+	forms := []string{normalized}
+	formOrder := map[string]int{normalized: 0}
+	for _, form := range append(tableLemmas, dictionary.Lemmas(normalized, from)...) {
+		if _, ok := formOrder[form]; !ok {
+			formOrder[form] = len(forms)
+			forms = append(forms, form)
+		}
+	}
+
+	entries, err := s.client.DictionaryEntry.
+		Query().
+		Where(
+			dictionaryentry.DictionaryIDIn(dictionaryIDs...),
+			dictionaryentry.NormalizedHeadwordIn(forms...),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up word: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if fa, fb := formOrder[a.NormalizedHeadword], formOrder[b.NormalizedHeadword]; fa != fb {
+			return fa < fb
+		}
+		if da, db := dictionaryOrder[a.DictionaryID], dictionaryOrder[b.DictionaryID]; da != db {
+			return da < db
+		}
+		return a.Rank < b.Rank
+	})
+	if len(entries) > maxLookupEntries {
+		entries = entries[:maxLookupEntries]
+	}
+
+	seen := map[string]bool{normalized: true}
+	for _, entry := range entries {
+		if !seen[entry.NormalizedHeadword] {
+			seen[entry.NormalizedHeadword] = true
+			lookup.Lemmas = append(lookup.Lemmas, entry.Headword)
+		}
+	}
+	lookup.Entries = entries
+
+	s.cache.Put(key, lookup)
+	return lookup, nil
+}
+
+// bufferUpload copies an uploaded file of at most limit bytes to a temporary
+// file, so that it can be read at random without holding it in memory, and
+// returns it with its size. The caller closes and removes the file.
+func bufferUpload(file graphql.Upload, limit int64) (*os.File, int64, error) {
+	tmp, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to buffer uploaded file: %w", err)
+	}
+	size, err := io.Copy(tmp, io.LimitReader(file.File, limit+1))
+	if err == nil && size > limit {
+		err = ErrFileTooLarge
+	}
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		if !errors.Is(err, ErrFileTooLarge) {
+			err = fmt.Errorf("failed to read uploaded file: %w", err)
+		}
+		return nil, 0, err
+	}
+	return tmp, size, nil
+}
+
+func (s *DictionaryService) requireAdmin(ctx context.Context, userID uuid.UUID) error {
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if u.Role != user.RoleADMIN {
+		return fmt.Errorf("only admins can manage dictionaries: %w", ErrForbidden)
+	}
+	return nil
+}
+
+// createInflections stores normalized inflections for a language, skipping
+// duplicates, optionally as part of a dictionary.
+func createInflections(ctx context.Context, tx *ent.Tx, language string, dictionaryID *uuid.UUID, inflections []dictionary.Inflection) error {
+	seen := make(map[[2]string]bool, len(inflections))
+	var creates []*ent.InflectionFormCreate
+	for _, inflection := range inflections {
+		form := tokenizer.Normalize(inflection.Form, language)
+		lemma := tokenizer.Normalize(inflection.Lemma, language)
+		key := [2]string{form, lemma}
+		if form == "" || lemma == "" || form == lemma || seen[key] ||
+			utf8.RuneCountInString(form) > maxHeadwordLength || utf8.RuneCountInString(lemma) > maxHeadwordLength {
+			continue
+		}
+		seen[key] = true
+
+		creates = append(creates, tx.InflectionForm.
+			Create().
+			SetLanguage(language).
+			SetForm(form).
+			SetLemma(lemma).
+			SetNillableDictionaryID(dictionaryID))
+		if len(creates) == dictionaryBatchSize {
+			if err := tx.InflectionForm.CreateBulk(creates...).Exec(ctx); err != nil {
+				return fmt.Errorf("failed to create inflections: %w", err)
+			}
+			creates = creates[:0]
+		}
+	}
+	if len(creates) > 0 {
+		if err := tx.InflectionForm.CreateBulk(creates...).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create inflections: %w", err)
+		}
+	}
+	return nil
+}
+
+// truncate cuts s to at most n characters.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,7 @@
+package services
+
+import "errors"
+
+// ErrForbidden is returned when a user tries to read or change a resource
+// they are not allowed to access.
+var ErrForbidden = errors.New("forbidden")
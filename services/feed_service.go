@@ -0,0 +1,489 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/comment"
+	"LinganoGO/ent/course"
+	"LinganoGO/ent/follow"
+	"LinganoGO/ent/post"
+	"LinganoGO/ent/reaction"
+	"LinganoGO/ent/reading"
+	"LinganoGO/ent/user"
+	"LinganoGO/graph/model"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxFeedPage is the largest number of feed items returned at once.
+	maxFeedPage = 50
+	// maxPopularFeed is how many items the popular feed can be paged through.
+	maxPopularFeed = 500
+)
+
+// FeedCursor is the position of an item in a feed. The feed of followed
+// users is ordered by time and ID; the popular feed is ranked, so its cursors
+// hold how many items were already shown.
+type FeedCursor struct {
+	Popular bool
+	Time    time.Time
+	ID      uuid.UUID
+	Offset  int
+}
+
+// EncodeFeedCursor turns a cursor into the opaque string given to clients
+func EncodeFeedCursor(c FeedCursor) string {
+	raw := fmt.Sprintf("following:%d:%s", c.Time.UnixNano(), c.ID)
+	if c.Popular {
+		raw = fmt.Sprintf("popular:%d", c.Offset)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeFeedCursor parses a cursor made by EncodeFeedCursor
+func DecodeFeedCursor(cursor string) (FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return FeedCursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	parts := strings.Split(string(raw), ":")
+	switch {
+	case len(parts) == 2 && parts[0] == "popular":
+		offset, err := strconv.Atoi(parts[1])
+		if err != nil || offset < 0 {
+			return FeedCursor{}, errors.New("invalid cursor")
+		}
+		return FeedCursor{Popular: true, Offset: offset}, nil
+	case len(parts) == 3 && parts[0] == "following":
+		nanos, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return FeedCursor{}, errors.New("invalid cursor")
+		}
+		id, err := uuid.Parse(parts[2])
+		if err != nil {
+			return FeedCursor{}, fmt.Errorf("invalid cursor: %w", err)
+		}
+		return FeedCursor{Time: time.Unix(0, nanos), ID: id}, nil
+	default:
+		return FeedCursor{}, errors.New("invalid cursor")
+	}
+}
+
+// feedEntry is a feed item with the values it is ordered by
+type feedEntry struct {
+	score int
+	time  time.Time
+	id    uuid.UUID
+	item  *model.FeedItem
+}
+
+// FeedService provides methods for the activity feed of users using Ent
+type FeedService struct {
+	client *ent.Client
+}
+
+// NewFeedService creates a new FeedService
+func NewFeedService() *FeedService {
+	return &FeedService{
+		client: config.GetEntClient(),
+	}
+}
+
+// GetFeed returns up to first feed items after the cursor. Users who follow
+// someone get their activity, newest first; everyone else gets popular posts
+// and readings in their target language.
+func (s *FeedService) GetFeed(ctx context.Context, userID uuid.UUID, after *string, first int) (*model.FeedPage, error) {
+	if first < 1 || first > maxFeedPage {
+		return nil, fmt.Errorf("first must be between 1 and %d", maxFeedPage)
+	}
+	var cursor *FeedCursor
+	if after != nil {
+		c, err := DecodeFeedCursor(*after)
+		if err != nil {
+			return nil, err
+		}
+		cursor = &c
+	}
+
+	followees, err := s.client.User.
+		Query().
+		Where(user.HasFollowersWith(follow.FollowerID(userID))).
+		IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followed users: %w", err)
+	}
+
+	if len(followees) == 0 {
+		if cursor != nil && !cursor.Popular {
+			return nil, errors.New("cursor does not belong to this feed")
+		}
+		return s.popularFeed(ctx, userID, cursor, first)
+	}
+	if cursor != nil && cursor.Popular {
+		return nil, errors.New("cursor does not belong to this feed")
+	}
+	return s.followingFeed(ctx, followees, cursor, first)
+}
+
+func (s *FeedService) followingFeed(ctx context.Context, followees []uuid.UUID, cursor *FeedCursor, first int) (*model.FeedPage, error) {
+	var entries []feedEntry
+	for _, source := range []func(context.Context, []uuid.UUID, *FeedCursor, int) ([]feedEntry, error){
+		s.followedPosts,
+		s.followedReadings,
+		s.followedCourses,
+		s.followedComments,
+	} {
+		found, err := source(ctx, followees, cursor, first+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get feed: %w", err)
+		}
+		entries = append(entries, found...)
+	}
+	sortFeedEntries(entries)
+
+	page := &model.FeedPage{Items: []*model.FeedItem{}}
+	for i, entry := range entries {
+		if i == first {
+			page.HasNextPage = true
+			break
+		}
+		entry.item.Cursor = EncodeFeedCursor(FeedCursor{Time: entry.time, ID: entry.id})
+		page.Items = append(page.Items, entry.item)
+	}
+	if len(page.Items) > 0 {
+		page.EndCursor = &page.Items[len(page.Items)-1].Cursor
+	}
+	return page, nil
+}
+
+func (s *FeedService) followedPosts(ctx context.Context, followees []uuid.UUID, cursor *FeedCursor, limit int) ([]feedEntry, error) {
+	query := s.client.Post.
+		Query().
+		Where(
+			post.UserIDIn(followees...),
+			post.Draft(false),
+			post.PublishedAtNotNil(),
+		)
+	if cursor != nil {
+		query.Where(post.Or(
+			post.PublishedAtLT(cursor.Time),
+			post.And(post.PublishedAtEQ(cursor.Time), post.IDLT(cursor.ID)),
+		))
+	}
+	posts, err := query.
+		WithUser().
+		Order(post.ByPublishedAt(sql.OrderDesc()), post.ByID(sql.OrderDesc())).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]feedEntry, len(posts))
+	for i, p := range posts {
+		entries[i] = postEntry(p)
+	}
+	return entries, nil
+}
+
+func (s *FeedService) followedReadings(ctx context.Context, followees []uuid.UUID, cursor *FeedCursor, limit int) ([]feedEntry, error) {
+	query := s.client.Reading.
+		Query().
+		Where(
+			reading.UserIDIn(followees...),
+			reading.PublicationStatusEQ(reading.PublicationStatusPUBLISHED),
+			reading.ParentIDIsNil(),
+			reading.PublishedAtNotNil(),
+		)
+	if cursor != nil {
+		query.Where(reading.Or(
+			reading.PublishedAtLT(cursor.Time),
+			reading.And(reading.PublishedAtEQ(cursor.Time), reading.IDLT(cursor.ID)),
+		))
+	}
+	readings, err := query.
+		WithUser().
+		Order(reading.ByPublishedAt(sql.OrderDesc()), reading.ByID(sql.OrderDesc())).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]feedEntry, len(readings))
+	for i, r := range readings {
+		entries[i] = readingEntry(r)
+	}
+	return entries, nil
+}
+
+func (s *FeedService) followedCourses(ctx context.Context, followees []uuid.UUID, cursor *FeedCursor, limit int) ([]feedEntry, error) {
+	query := s.client.Course.
+		Query().
+		Where(
+			course.OwnerIDIn(followees...),
+			course.StateEQ(course.StatePUBLISHED),
+			course.PublishedAtNotNil(),
+		)
+	if cursor != nil {
+		query.Where(course.Or(
+			course.PublishedAtLT(cursor.Time),
+			course.And(course.PublishedAtEQ(cursor.Time), course.IDLT(cursor.ID)),
+		))
+	}
+	courses, err := query.
+		WithOwner().
+		Order(course.ByPublishedAt(sql.OrderDesc()), course.ByID(sql.OrderDesc())).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]feedEntry, len(courses))
+	for i, c := range courses {
+		entries[i] = feedEntry{
+			time: *c.PublishedAt,
+			id:   c.ID,
+			item: &model.FeedItem{
+				Kind:      model.FeedItemKindCoursePublished,
+				Actor:     c.Edges.Owner,
+				Course:    c,
+				CreatedAt: c.PublishedAt.Format("2006-01-02T15:04:05Z07:00"),
+			},
+		}
+	}
+	return entries, nil
+}
+
+func (s *FeedService) followedComments(ctx context.Context, followees []uuid.UUID, cursor *FeedCursor, limit int) ([]feedEntry, error) {
+	query := s.client.Comment.
+		Query().
+		Where(
+			comment.UserIDIn(followees...),
+			comment.DeletedAtIsNil(),
+			comment.Or(
+				comment.HasPostWith(post.Draft(false)),
+				comment.HasReadingWith(reading.Public(true)),
+			),
+		)
+	if cursor != nil {
+		query.Where(comment.Or(
+			comment.CreatedAtLT(cursor.Time),
+			comment.And(comment.CreatedAtEQ(cursor.Time), comment.IDLT(cursor.ID)),
+		))
+	}
+	comments, err := query.
+		WithUser().
+		Order(comment.ByCreatedAt(sql.OrderDesc()), comment.ByID(sql.OrderDesc())).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]feedEntry, len(comments))
+	for i, c := range comments {
+		entries[i] = feedEntry{
+			time: c.CreatedAt,
+			id:   c.ID,
+			item: &model.FeedItem{
+				Kind:      model.FeedItemKindCommented,
+				Actor:     c.Edges.User,
+				Comment:   c,
+				CreatedAt: c.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			},
+		}
+	}
+	return entries, nil
+}
+
+// popularFeed ranks published posts and public readings in the user's target
+// language by how many reactions they got. Users without a target language
+// get popular content in every language.
+func (s *FeedService) popularFeed(ctx context.Context, userID uuid.UUID, cursor *FeedCursor, first int) (*model.FeedPage, error) {
+	page := &model.FeedPage{Items: []*model.FeedItem{}, Popular: true}
+	offset := 0
+	if cursor != nil {
+		offset = cursor.Offset
+	}
+	if offset >= maxPopularFeed {
+		return page, nil
+	}
+	limit := offset + first + 1
+
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	postQuery := s.client.Post.
+		Query().
+		Where(
+			post.Draft(false),
+			post.PublishedAtNotNil(),
+			post.UserIDNEQ(userID),
+		)
+	readingQuery := s.client.Reading.
+		Query().
+		Where(
+			reading.PublicationStatusEQ(reading.PublicationStatusPUBLISHED),
+			reading.ParentIDIsNil(),
+			reading.PublishedAtNotNil(),
+			reading.UserIDNEQ(userID),
+		)
+	if u.TargetLanguage != nil {
+		postQuery.Where(post.Language(*u.TargetLanguage))
+		readingQuery.Where(reading.Language(*u.TargetLanguage))
+	}
+
+	posts, err := postQuery.
+		WithUser().
+		Order(post.ByReactionsCount(sql.OrderDesc()), post.ByPublishedAt(sql.OrderDesc()), post.ByID(sql.OrderDesc())).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get popular posts: %w", err)
+	}
+	readings, err := readingQuery.
+		WithUser().
+		Order(reading.ByReactionsCount(sql.OrderDesc()), reading.ByPublishedAt(sql.OrderDesc()), reading.ByID(sql.OrderDesc())).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get popular readings: %w", err)
+	}
+
+	postIDs := make([]uuid.UUID, len(posts))
+	for i, p := range posts {
+		postIDs[i] = p.ID
+	}
+	readingIDs := make([]uuid.UUID, len(readings))
+	for i, r := range readings {
+		readingIDs[i] = r.ID
+	}
+	scores, err := s.reactionCounts(ctx, postIDs, readingIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]feedEntry, 0, len(posts)+len(readings))
+	for _, p := range posts {
+		entry := postEntry(p)
+		entry.score = scores[p.ID]
+		entries = append(entries, entry)
+	}
+	for _, r := range readings {
+		entry := readingEntry(r)
+		entry.score = scores[r.ID]
+		entries = append(entries, entry)
+	}
+	sortFeedEntries(entries)
+
+	for i := offset; i < len(entries); i++ {
+		if i == offset+first {
+			page.HasNextPage = i < maxPopularFeed
+			break
+		}
+		entries[i].item.Cursor = EncodeFeedCursor(FeedCursor{Popular: true, Offset: i + 1})
+		page.Items = append(page.Items, entries[i].item)
+	}
+	if len(page.Items) > 0 {
+		page.EndCursor = &page.Items[len(page.Items)-1].Cursor
+	}
+	return page, nil
+}
+
+// reactionCounts counts the reactions to the given posts and readings
+func (s *FeedService) reactionCounts(ctx context.Context, postIDs, readingIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	var postRows []struct {
+		PostID uuid.UUID `json:"post_id"`
+		Count  int       `json:"count"`
+	}
+	err := s.client.Reaction.
+		Query().
+		Where(reaction.PostIDIn(postIDs...)).
+		GroupBy(reaction.FieldPostID).
+		Aggregate(ent.Count()).
+		Scan(ctx, &postRows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+
+	var readingRows []struct {
+		ReadingID uuid.UUID `json:"reading_id"`
+		Count     int       `json:"count"`
+	}
+	err = s.client.Reaction.
+		Query().
+		Where(reaction.ReadingIDIn(readingIDs...)).
+		GroupBy(reaction.FieldReadingID).
+		Aggregate(ent.Count()).
+		Scan(ctx, &readingRows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+
+	counts := make(map[uuid.UUID]int, len(postRows)+len(readingRows))
+	for _, row := range postRows {
+		counts[row.PostID] = row.Count
+	}
+	for _, row := range readingRows {
+		counts[row.ReadingID] = row.Count
+	}
+	return counts, nil
+}
+
+func postEntry(p *ent.Post) feedEntry {
+	return feedEntry{
+		time: *p.PublishedAt,
+		id:   p.ID,
+		item: &model.FeedItem{
+			Kind:      model.FeedItemKindPostPublished,
+			Actor:     p.Edges.User,
+			Post:      p,
+			CreatedAt: p.PublishedAt.Format("2006-01-02T15:04:05Z07:00"),
+		},
+	}
+}
+
+func readingEntry(r *ent.Reading) feedEntry {
+	return feedEntry{
+		time: *r.PublishedAt,
+		id:   r.ID,
+		item: &model.FeedItem{
+			Kind:      model.FeedItemKindReadingPublished,
+			Actor:     r.Edges.User,
+			Reading:   r,
+			CreatedAt: r.PublishedAt.Format("2006-01-02T15:04:05Z07:00"),
+		},
+	}
+}
+
+// sortFeedEntries orders entries by score, then newest first. IDs break ties
+// the same way the database orders them, so cursors never skip an item.
+func sortFeedEntries(entries []feedEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if !a.time.Equal(b.time) {
+			return a.time.After(b.time)
+		}
+		return bytes.Compare(a.id[:], b.id[:]) > 0
+	})
+}
@@ -3,12 +3,20 @@ package services
 import (
 	"LinganoGO/config"
 	"LinganoGO/ent"
+	"LinganoGO/ent/flashcard"
+	"LinganoGO/ent/vocabularyitem"
+	"LinganoGO/graph/model"
+	"LinganoGO/tokenizer"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 )
 
+// clozeBlank replaces the studied term in cloze cards.
+const clozeBlank = "____"
+
 type FlashcardService struct {
 	client *ent.Client
 }
@@ -35,3 +43,153 @@ func (s *FlashcardService) UpdateFlashcard(ctx context.Context, id string, quest
 	}
 	return flashcard, nil
 }
+
+// GenerateFlashcards builds flashcards for the user from their vocabulary items. Items that
+// already have a card, either generated from the item or created by hand with the term as its
+// question, are skipped, as are items without the data the template needs (a translation for
+// BASIC, REVERSE and EXAMPLE cards). CLOZE cards fall back to BASIC when the saved sentence
+// does not contain the term.
+func (s *FlashcardService) GenerateFlashcards(ctx context.Context, userID uuid.UUID, itemIDs []uuid.UUID, template model.FlashcardTemplate) ([]*ent.Flashcard, error) {
+	items, err := s.client.VocabularyItem.
+		Query().
+		Where(
+			vocabularyitem.IDIn(itemIDs...),
+			vocabularyitem.UserID(userID),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vocabulary items: %w", err)
+	}
+	if len(items) != len(uniqueIDs(itemIDs)) {
+		return nil, fmt.Errorf("failed to generate flashcards: %w", ErrForbidden)
+	}
+
+	terms := make([]string, len(items))
+	for i, item := range items {
+		terms[i] = item.Term
+	}
+	existing, err := s.client.Flashcard.
+		Query().
+		Where(
+			flashcard.UserID(userID),
+			flashcard.Or(
+				flashcard.VocabularyItemIDIn(itemIDs...),
+				flashcard.QuestionIn(terms...),
+			),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing flashcards: %w", err)
+	}
+	covered := make(map[uuid.UUID]bool, len(existing))
+	coveredTerms := make(map[string]bool, len(existing))
+	for _, card := range existing {
+		if card.VocabularyItemID != nil {
+			covered[*card.VocabularyItemID] = true
+		}
+		coveredTerms[card.Question] = true
+	}
+
+	var builders []*ent.FlashcardCreate
+	for _, item := range items {
+		if covered[item.ID] || coveredTerms[item.Term] {
+			continue
+		}
+		question, answer, ok := flashcardContent(item, template)
+		if !ok {
+			continue
+		}
+		builders = append(builders, s.client.Flashcard.
+			Create().
+			SetQuestion(question).
+			SetAnswer(answer).
+			SetUserID(userID).
+			SetVocabularyItemID(item.ID).
+			SetNillableReadingID(item.ReadingID))
+	}
+	if len(builders) == 0 {
+		return []*ent.Flashcard{}, nil
+	}
+
+	flashcards, err := s.client.Flashcard.CreateBulk(builders...).Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create flashcards: %w", err)
+	}
+
+	return flashcards, nil
+}
+
+// flashcardContent returns the question and answer of a card built from a vocabulary item.
+func flashcardContent(item *ent.VocabularyItem, template model.FlashcardTemplate) (string, string, bool) {
+	meaning := item.Translation
+	if meaning == "" {
+		meaning = item.Lemma
+	}
+
+	switch template {
+	case model.FlashcardTemplateCloze:
+		if cloze, ok := clozeSentence(item.Sentence, item.Term, item.Language); ok {
+			if item.Translation != "" {
+				cloze += "\n\n(" + item.Translation + ")"
+			}
+			return cloze, item.Term, true
+		}
+	case model.FlashcardTemplateReverse:
+		if item.Translation == "" {
+			return "", "", false
+		}
+		return item.Translation, item.Term, true
+	case model.FlashcardTemplateExample:
+		if meaning == "" {
+			return "", "", false
+		}
+		if item.Sentence != "" {
+			return item.Term + "\n\n" + item.Sentence, meaning, true
+		}
+	}
+
+	if meaning == "" {
+		return "", "", false
+	}
+	return item.Term, meaning, true
+}
+
+// clozeSentence blanks out the first occurrence of term in sentence. Words are compared in
+// their normalized form, so "Casa" matches "casa" and multi-word terms match across spacing.
+func clozeSentence(sentence, term, language string) (string, bool) {
+	termWords := tokenizer.Words(term, language)
+	if sentence == "" || len(termWords) == 0 {
+		return "", false
+	}
+
+	words := tokenizer.Words(sentence, language)
+	for i := 0; i+len(termWords) <= len(words); i++ {
+		match := true
+		for j, tw := range termWords {
+			if words[i+j].Normalized != tw.Normalized {
+				match = false
+				break
+			}
+		}
+		if match {
+			runes := []rune(sentence)
+			start, end := words[i].Start, words[i+len(termWords)-1].End
+			return strings.TrimSpace(string(runes[:start]) + clozeBlank + string(runes[end:])), true
+		}
+	}
+
+	return "", false
+}
+
+// uniqueIDs returns ids without duplicates.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/follow"
+	"LinganoGO/ent/predicate"
+
+	"github.com/google/uuid"
+)
+
+// maxFollowPage is the largest number of followers or followed users
+// returned at once.
+const maxFollowPage = 100
+
+// FollowService provides methods for users following each other using Ent
+type FollowService struct {
+	client *ent.Client
+}
+
+// NewFollowService creates a new FollowService
+func NewFollowService() *FollowService {
+	return &FollowService{
+		client: config.GetEntClient(),
+	}
+}
+
+// Follow makes followerID follow followeeID and returns the followed user.
+// Following someone twice is not an error.
+func (s *FollowService) Follow(ctx context.Context, followerID, followeeID uuid.UUID) (*ent.User, error) {
+	if followerID == followeeID {
+		return nil, errors.New("users cannot follow themselves")
+	}
+
+	followee, err := s.client.User.Get(ctx, followeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	following, err := s.client.Follow.
+		Query().
+		Where(follow.FollowerID(followerID), follow.FolloweeID(followeeID)).
+		Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow: %w", err)
+	}
+	if following {
+		return followee, nil
+	}
+
+	err = s.client.Follow.
+		Create().
+		SetFollowerID(followerID).
+		SetFolloweeID(followeeID).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow user: %w", err)
+	}
+
+	return followee, nil
+}
+
+// Unfollow makes followerID stop following followeeID and returns the
+// user who was followed
+func (s *FollowService) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) (*ent.User, error) {
+	followee, err := s.client.User.Get(ctx, followeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	_, err = s.client.Follow.
+		Delete().
+		Where(follow.FollowerID(followerID), follow.FolloweeID(followeeID)).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unfollow user: %w", err)
+	}
+
+	return followee, nil
+}
+
+// GetFollowers returns a page of the users following userID, most recent
+// followers first
+func (s *FollowService) GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*ent.User, error) {
+	query, err := s.page(limit, offset, follow.FolloweeID(userID))
+	if err != nil {
+		return nil, err
+	}
+	follows, err := query.WithFollower().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers: %w", err)
+	}
+
+	users := make([]*ent.User, len(follows))
+	for i, f := range follows {
+		users[i] = f.Edges.Follower
+	}
+	return users, nil
+}
+
+// GetFollowing returns a page of the users userID follows, most recently
+// followed first
+func (s *FollowService) GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*ent.User, error) {
+	query, err := s.page(limit, offset, follow.FollowerID(userID))
+	if err != nil {
+		return nil, err
+	}
+	follows, err := query.WithFollowee().All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followed users: %w", err)
+	}
+
+	users := make([]*ent.User, len(follows))
+	for i, f := range follows {
+		users[i] = f.Edges.Followee
+	}
+	return users, nil
+}
+
+// CountFollowers returns how many users follow userID
+func (s *FollowService) CountFollowers(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := s.client.Follow.
+		Query().
+		Where(follow.FolloweeID(userID)).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count followers: %w", err)
+	}
+	return count, nil
+}
+
+// CountFollowing returns how many users userID follows
+func (s *FollowService) CountFollowing(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := s.client.Follow.
+		Query().
+		Where(follow.FollowerID(userID)).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count followed users: %w", err)
+	}
+	return count, nil
+}
+
+// IsFollowing tells whether the viewer follows userID. Anonymous viewers
+// follow no one.
+func (s *FollowService) IsFollowing(ctx context.Context, viewerID *uuid.UUID, userID uuid.UUID) (bool, error) {
+	if viewerID == nil {
+		return false, nil
+	}
+	exists, err := s.client.Follow.
+		Query().
+		Where(follow.FollowerID(*viewerID), follow.FolloweeID(userID)).
+		Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get follow: %w", err)
+	}
+	return exists, nil
+}
+
+// page queries a page of the follows matching where, newest first
+func (s *FollowService) page(limit, offset int, where predicate.Follow) (*ent.FollowQuery, error) {
+	if limit < 1 || limit > maxFollowPage {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxFollowPage)
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+
+	return s.client.Follow.
+		Query().
+		Where(where).
+		Order(ent.Desc(follow.FieldCreatedAt), ent.Desc(follow.FieldID)).
+		Limit(limit).
+		Offset(offset), nil
+}
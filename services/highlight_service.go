@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"LinganoGO/anchor"
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/highlight"
+	"LinganoGO/graph/model"
+
+	"github.com/google/uuid"
+)
+
+// HighlightService provides methods for the passages users highlight in readings
+type HighlightService struct {
+	client *ent.Client
+}
+
+// NewHighlightService creates a new HighlightService
+func NewHighlightService() *HighlightService {
+	return &HighlightService{
+		client: config.GetEntClient(),
+	}
+}
+
+// CreateHighlight highlights a passage of a reading the user can view
+func (s *HighlightService) CreateHighlight(ctx context.Context, input model.NewHighlight) (*ent.Highlight, error) {
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	readingUUID, err := uuid.Parse(input.ReadingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+
+	reading, err := s.client.Reading.Get(ctx, readingUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if !canViewReading(reading, &userUUID) {
+		return nil, fmt.Errorf("failed to create highlight: %w", ErrForbidden)
+	}
+
+	selector, err := anchor.New(reading.Body, input.Start, input.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create highlight: %w", err)
+	}
+
+	create := s.client.Highlight.
+		Create().
+		SetUserID(userUUID).
+		SetReadingID(readingUUID).
+		SetStart(selector.Start).
+		SetEnd(selector.End).
+		SetQuote(selector.Quote).
+		SetPrefix(selector.Prefix).
+		SetSuffix(selector.Suffix).
+		SetNillableNote(input.Note)
+	if input.Color != nil {
+		create.SetColor(*input.Color)
+	}
+
+	created, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create highlight: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateHighlight changes the color or note of a highlight owned by the user.
+// An empty note removes it.
+func (s *HighlightService) UpdateHighlight(ctx context.Context, id, userID uuid.UUID, input model.UpdateHighlight) (*ent.Highlight, error) {
+	existing, err := s.client.Highlight.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get highlight: %w", err)
+	}
+	if existing.UserID != userID {
+		return nil, fmt.Errorf("failed to update highlight: %w", ErrForbidden)
+	}
+
+	update := existing.Update()
+	if input.Color != nil {
+		update.SetColor(*input.Color)
+	}
+	if input.Note != nil {
+		if *input.Note == "" {
+			update.ClearNote()
+		} else {
+			update.SetNote(*input.Note)
+		}
+	}
+
+	updated, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update highlight: %w", err)
+	}
+
+	return updated, nil
+}
+
+// DeleteHighlight deletes a highlight owned by the user
+func (s *HighlightService) DeleteHighlight(ctx context.Context, id, userID uuid.UUID) error {
+	deleted, err := s.client.Highlight.
+		Delete().
+		Where(
+			highlight.ID(id),
+			highlight.UserID(userID),
+		).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete highlight: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("failed to delete highlight: %w", ErrForbidden)
+	}
+
+	return nil
+}
+
+// GetHighlights returns the user's highlights on a reading in text order
+func (s *HighlightService) GetHighlights(ctx context.Context, readingID, userID uuid.UUID) ([]*ent.Highlight, error) {
+	highlights, err := s.client.Highlight.
+		Query().
+		Where(
+			highlight.ReadingID(readingID),
+			highlight.UserID(userID),
+		).
+		Order(ent.Asc(highlight.FieldOrphaned), ent.Asc(highlight.FieldStart), ent.Asc(highlight.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get highlights: %w", err)
+	}
+
+	return highlights, nil
+}
+
+// reanchorHighlights moves the highlights of a reading whose body changed to
+// where their passages now are. Highlights whose passage can't be found are
+// marked orphaned and keep their old offsets; they are re-anchored again on
+// later edits, so restoring the passage brings them back.
+func reanchorHighlights(ctx context.Context, tx *ent.Tx, readingID uuid.UUID, body string) error {
+	highlights, err := tx.Highlight.
+		Query().
+		Where(highlight.ReadingID(readingID)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get highlights: %w", err)
+	}
+
+	for _, h := range highlights {
+		old := anchor.Selector{
+			Start:  h.Start,
+			End:    h.End,
+			Quote:  h.Quote,
+			Prefix: h.Prefix,
+			Suffix: h.Suffix,
+		}
+		selector, ok := anchor.Reanchor(body, old)
+
+		update := h.Update()
+		switch {
+		case ok && selector == old && !h.Orphaned:
+			continue
+		case ok:
+			update.
+				SetStart(selector.Start).
+				SetEnd(selector.End).
+				SetQuote(selector.Quote).
+				SetPrefix(selector.Prefix).
+				SetSuffix(selector.Suffix).
+				SetOrphaned(false)
+		case h.Orphaned:
+			continue
+		default:
+			update.SetOrphaned(true)
+		}
+
+		if err := update.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to re-anchor highlight: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/importjob"
+	"LinganoGO/ent/reading"
+	"LinganoGO/graph/model"
+	"LinganoGO/importer"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxImportSize is the largest file importReading accepts.
+	MaxImportSize = 50 << 20
+	// backgroundImportSize is the file size above which an import runs as a
+	// background job instead of within the request.
+	backgroundImportSize = 1 << 20
+	// backgroundImportTimeout bounds how long a background import may run.
+	backgroundImportTimeout = 10 * time.Minute
+)
+
+// ErrFileTooLarge is returned when an uploaded file exceeds MaxImportSize.
+var ErrFileTooLarge = errors.New("file is too large")
+
+// ImportService turns uploaded documents and web pages into readings using Ent
+type ImportService struct {
+	client  *ent.Client
+	fetcher *importer.Fetcher
+}
+
+// NewImportService creates a new ImportService. Private addresses listed in
+// the comma separated IMPORT_URL_ALLOWLIST environment variable can be
+// imported from by URL.
+func NewImportService() *ImportService {
+	fetcher, err := importer.NewFetcher(strings.Split(os.Getenv("IMPORT_URL_ALLOWLIST"), ","))
+	if err != nil {
+		log.Printf("ignoring IMPORT_URL_ALLOWLIST: %v", err)
+		fetcher, _ = importer.NewFetcher(nil)
+	}
+
+	return &ImportService{
+		client:  config.GetEntClient(),
+		fetcher: fetcher,
+	}
+}
+
+// ImportReading extracts the text of an uploaded file into readings owned by the
+// user in the options. Books with several chapters become a parent reading with
+// one child reading per chapter. Small files are imported before returning;
+// larger ones are imported in the background and the returned job reports
+// their progress.
+func (s *ImportService) ImportReading(ctx context.Context, file graphql.Upload, options model.ImportReadingOptions) (*ent.ImportJob, error) {
+	userUUID, err := uuid.Parse(options.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file.File, MaxImportSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	if len(data) > MaxImportSize {
+		return nil, fmt.Errorf("failed to import reading: %w", ErrFileTooLarge)
+	}
+
+	var format importer.Format
+	if options.Format != nil {
+		format = importer.Format(*options.Format)
+	} else {
+		format, err = importer.DetectFormat(file.Filename, file.ContentType, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import reading: %w", err)
+		}
+	}
+
+	job, err := s.client.ImportJob.
+		Create().
+		SetUserID(userUUID).
+		SetFilename(file.Filename).
+		SetFormat(importjob.Format(format)).
+		SetSize(int64(len(data))).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	if len(data) > backgroundImportSize {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), backgroundImportTimeout)
+			defer cancel()
+			s.runImport(ctx, job.ID, userUUID, data, format, file.Filename, options)
+		}()
+		return job, nil
+	}
+
+	s.runImport(ctx, job.ID, userUUID, data, format, file.Filename, options)
+
+	job, err = s.client.ImportJob.Get(ctx, job.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ImportReadingFromURL fetches a web page and creates a reading from its main
+// content, keeping the page address as the reading's source.
+func (s *ImportService) ImportReadingFromURL(ctx context.Context, rawURL string, options model.ImportURLOptions) (*ent.Reading, error) {
+	userUUID, err := uuid.Parse(options.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	doc, err := s.fetcher.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import reading: %w", err)
+	}
+
+	var created *ent.Reading
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		var err error
+		created, err = s.createReadings(ctx, tx, uuid.Nil, userUUID, doc, model.ImportReadingOptions{
+			UserID:   options.UserID,
+			Title:    options.Title,
+			Language: options.Language,
+			Level:    options.Level,
+			Public:   options.Public,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetImportJob retrieves an import job owned by the given user
+func (s *ImportService) GetImportJob(ctx context.Context, id, userID uuid.UUID) (*ent.ImportJob, error) {
+	job, err := s.client.ImportJob.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+	if job.UserID != userID {
+		return nil, fmt.Errorf("failed to get import job: %w", ErrForbidden)
+	}
+
+	return job, nil
+}
+
+// GetChapters retrieves the chapters of a book reading in order
+func (s *ImportService) GetChapters(ctx context.Context, parentID uuid.UUID) ([]*ent.Reading, error) {
+	chapters, err := s.client.Reading.
+		Query().
+		Where(reading.ParentID(parentID)).
+		Order(ent.Asc(reading.FieldChapterIndex)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chapters: %w", err)
+	}
+
+	return chapters, nil
+}
+
+// runImport extracts the document and stores it, recording the outcome and
+// progress on the job. Failures are recorded on the job rather than returned.
+func (s *ImportService) runImport(ctx context.Context, jobID, userID uuid.UUID, data []byte, format importer.Format, filename string, options model.ImportReadingOptions) {
+	defer func() {
+		if v := recover(); v != nil {
+			s.failJob(ctx, jobID, fmt.Errorf("import panicked: %v", v))
+		}
+	}()
+
+	if err := s.client.ImportJob.UpdateOneID(jobID).SetStatus(importjob.StatusRUNNING).Exec(ctx); err != nil {
+		log.Printf("failed to start import job %s: %v", jobID, err)
+		return
+	}
+
+	doc, err := importer.Extract(data, format, filename)
+	if err != nil {
+		s.failJob(ctx, jobID, err)
+		return
+	}
+	s.setJobProgress(ctx, jobID, 10)
+
+	var root *ent.Reading
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		var err error
+		root, err = s.createReadings(ctx, tx, jobID, userID, doc, options)
+		return err
+	})
+	if err != nil {
+		s.failJob(ctx, jobID, err)
+		return
+	}
+
+	err = s.client.ImportJob.
+		UpdateOneID(jobID).
+		SetStatus(importjob.StatusCOMPLETED).
+		SetProgress(100).
+		SetReadingID(root.ID).
+		SetFinishedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		log.Printf("failed to complete import job %s: %v", jobID, err)
+	}
+}
+
+// createReadings stores a document as a single reading or, when it has several
+// chapters, as a parent reading with one child per chapter. It returns the
+// top-level reading. Progress is recorded on the job unless jobID is uuid.Nil.
+func (s *ImportService) createReadings(ctx context.Context, tx *ent.Tx, jobID, userID uuid.UUID, doc *importer.Document, options model.ImportReadingOptions) (*ent.Reading, error) {
+	title := doc.Title
+	if options.Title != nil && strings.TrimSpace(*options.Title) != "" {
+		title = strings.TrimSpace(*options.Title)
+	}
+	language := doc.Language
+	if options.Language != nil {
+		language = *options.Language
+	}
+	language = normalizeLanguage(language)
+	author := doc.Author
+	if options.Author != nil {
+		author = *options.Author
+	}
+
+	newReading := func(title string, body string, markdown bool) *ent.ReadingCreate {
+		create := tx.Reading.
+			Create().
+			SetTitle(title).
+			SetUserID(userID).
+			SetFinished(false).
+			SetBody(body)
+		if markdown {
+			create.SetFormat(reading.FormatMARKDOWN)
+		}
+		if language != "" {
+			create.SetLanguage(language)
+		}
+		if options.Level != nil {
+			create.SetLevel(*options.Level)
+		}
+		if author != "" {
+			create.SetAuthor(author)
+		}
+		if doc.SourceURL != "" {
+			create.SetSourceURL(doc.SourceURL)
+		}
+		analyzeContent(create.Mutation(), body, language)
+		return create
+	}
+
+	// Only the top-level reading is submitted; the chapters of a book follow
+	// it through review.
+	public := options.Public != nil && *options.Public
+
+	if len(doc.Chapters) == 1 {
+		chapter := doc.Chapters[0]
+		create := newReading(title, chapter.Body, chapter.Markdown)
+		if public {
+			if err := requireContent(ctx, tx.Client(), uuid.Nil, chapter.Body); err != nil {
+				return nil, err
+			}
+			setPublicationStatus(create.Mutation(), reading.PublicationStatusSUBMITTED)
+		}
+		root, err := create.Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create reading: %w", err)
+		}
+		return root, nil
+	}
+
+	// The parent reading has no body of its own; its word count and estimated
+	// level cover the whole book.
+	bodies := make([]string, len(doc.Chapters))
+	for i, chapter := range doc.Chapters {
+		bodies[i] = chapter.Body
+	}
+	create := newReading(title, "", false)
+	analyzeContent(create.Mutation(), strings.Join(bodies, "\n\n"), language)
+	if public {
+		setPublicationStatus(create.Mutation(), reading.PublicationStatusSUBMITTED)
+	}
+	root, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reading: %w", err)
+	}
+
+	for i, chapter := range doc.Chapters {
+		_, err := newReading(chapter.Title, chapter.Body, chapter.Markdown).
+			SetParentID(root.ID).
+			SetChapterIndex(i).
+			Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create chapter %d: %w", i+1, err)
+		}
+		if jobID != uuid.Nil {
+			s.setJobProgress(ctx, jobID, 10+90*float64(i+1)/float64(len(doc.Chapters)+1))
+		}
+	}
+
+	return root, nil
+}
+
+// setJobProgress records the percentage of an import that is done.
+func (s *ImportService) setJobProgress(ctx context.Context, jobID uuid.UUID, progress float64) {
+	if err := s.client.ImportJob.UpdateOneID(jobID).SetProgress(progress).Exec(ctx); err != nil {
+		log.Printf("failed to update import job %s: %v", jobID, err)
+	}
+}
+
+// failJob marks an import job as failed with the given error.
+func (s *ImportService) failJob(ctx context.Context, jobID uuid.UUID, cause error) {
+	// Record the failure even when it was caused by the context ending.
+	ctx = context.WithoutCancel(ctx)
+	err := s.client.ImportJob.
+		UpdateOneID(jobID).
+		SetStatus(importjob.StatusFAILED).
+		SetError(cause.Error()).
+		SetFinishedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		log.Printf("failed to record failure of import job %s: %v", jobID, err)
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lruCache is a fixed-size in-memory cache that evicts the least recently
+// used entries. Entries also expire after ttl, so that changes made by other
+// server instances show up eventually.
+type lruCache[V any] struct {
+	capacity int
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+}
+
+type lruItem[V any] struct {
+	key     string
+	value   V
+	expires time.Time
+}
+
+func newLRUCache[V any](capacity int, ttl time.Duration) *lruCache[V] {
+	return &lruCache[V]{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the value cached for key, if any.
+func (c *lruCache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	element, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	item := element.Value.(*lruItem[V])
+	if time.Now().After(item.expires) {
+		c.order.Remove(element)
+		delete(c.items, key)
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return item.value, true
+}
+
+// Put caches value for key.
+func (c *lruCache[V]) Put(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(c.ttl)
+	if element, ok := c.items[key]; ok {
+		item := element.Value.(*lruItem[V])
+		item.value, item.expires = value, expires
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruItem[V]{key: key, value: value, expires: expires})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruItem[V]).key)
+	}
+}
+
+// Clear empties the cache.
+func (c *lruCache[V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+}
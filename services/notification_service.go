@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/notification"
+
+	"github.com/google/uuid"
+)
+
+// maxNotifications is the largest number of notifications returned at once.
+const maxNotifications = 200
+
+// NotificationService provides methods for the notifications of users using Ent
+type NotificationService struct {
+	client *ent.Client
+}
+
+// NewNotificationService creates a new NotificationService
+func NewNotificationService() *NotificationService {
+	return &NotificationService{
+		client: config.GetEntClient(),
+	}
+}
+
+// GetNotifications returns the user's notifications, newest first
+func (s *NotificationService) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit int) ([]*ent.Notification, error) {
+	if limit < 1 || limit > maxNotifications {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxNotifications)
+	}
+
+	query := s.client.Notification.
+		Query().
+		Where(notification.UserID(userID))
+	if unreadOnly {
+		query.Where(notification.ReadAtIsNil())
+	}
+
+	notifications, err := query.
+		Order(ent.Desc(notification.FieldCreatedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CountUnread returns how many of the user's notifications are unread
+func (s *NotificationService) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := s.client.Notification.
+		Query().
+		Where(
+			notification.UserID(userID),
+			notification.ReadAtIsNil(),
+		).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead marks the given notifications of the user as read, or all of them when ids is nil,
+// and returns how many were unread
+func (s *NotificationService) MarkRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error) {
+	update := s.client.Notification.
+		Update().
+		Where(
+			notification.UserID(userID),
+			notification.ReadAtIsNil(),
+		)
+	if ids != nil {
+		update.Where(notification.IDIn(ids...))
+	}
+
+	count, err := update.SetReadAt(time.Now()).Save(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return count, nil
+}
+
+// notificationDraft is a notification about to be sent
+type notificationDraft struct {
+	userID    uuid.UUID
+	actorID   *uuid.UUID
+	kind      notification.Kind
+	readingID *uuid.UUID
+	message   string
+}
+
+// notify records a notification as part of tx. Users are not notified of
+// their own actions.
+func notify(ctx context.Context, tx *ent.Tx, n notificationDraft) error {
+	if n.actorID != nil && *n.actorID == n.userID {
+		return nil
+	}
+
+	err := tx.Notification.
+		Create().
+		SetUserID(n.userID).
+		SetNillableActorID(n.actorID).
+		SetKind(n.kind).
+		SetNillableReadingID(n.readingID).
+		SetMessage(n.message).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	return nil
+}
@@ -3,13 +3,21 @@ package services
 import (
 	"LinganoGO/config"
 	"LinganoGO/ent"
+	"LinganoGO/ent/post"
 	"LinganoGO/graph/model"
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// postSchedulerInterval is how often the scheduler looks for due posts.
+const postSchedulerInterval = time.Minute
+
+// PostService provides methods for writing, publishing and scheduling posts using Ent
 type PostService struct {
 	client *ent.Client
 }
@@ -26,23 +34,182 @@ func (s *PostService) CreatePost(ctx context.Context, input model.NewPost) (*ent
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
-	
-	var draft bool
-	if input.Draft != nil {
-		draft = *input.Draft
-	} else {
-		draft = false
+
+	var publishAt *time.Time
+	if input.PublishAt != nil {
+		parsed, err := time.Parse(time.RFC3339, *input.PublishAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid publishAt: %w", err)
+		}
+		publishAt = &parsed
 	}
-	post, err := s.client.Post.
+	now := time.Now()
+	if err := ValidatePublishAt(input.Draft, publishAt, now); err != nil {
+		return nil, fmt.Errorf("failed to create post: %w", err)
+	}
+
+	create := s.client.Post.
 		Create().
 		SetBody(input.Body).
-		SetDraft(draft).
+		SetDraft(input.Draft).
 		SetUserID(userUUID).
-		Save(ctx)
+		SetNillablePublishAt(publishAt)
+	if input.Language != nil && normalizeLanguage(*input.Language) != "" {
+		create.SetLanguage(normalizeLanguage(*input.Language))
+	}
+	if !input.Draft {
+		create.SetPublishedAt(now)
+	}
+	post, err := create.Save(ctx)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
 	return post, nil
-}
\ No newline at end of file
+}
+
+// UpdatePost changes the body and draft state of a post owned by userID.
+// Publishing a draft clears any schedule; turning a post back into a draft
+// unpublishes it, and publishAt schedules it again. A nil language keeps the
+// post's language and an empty one removes it.
+func (s *PostService) UpdatePost(ctx context.Context, id, userID uuid.UUID, body string, draft bool, publishAt *time.Time, language *string) (*ent.Post, error) {
+	existing, err := s.ownedPost(ctx, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update post: %w", err)
+	}
+	now := time.Now()
+	if err := ValidatePublishAt(draft, publishAt, now); err != nil {
+		return nil, fmt.Errorf("failed to update post: %w", err)
+	}
+
+	update := existing.Update().
+		SetBody(body).
+		SetDraft(draft)
+	switch {
+	case draft:
+		update.ClearPublishedAt()
+	case existing.Draft:
+		update.SetPublishedAt(now)
+	}
+	if publishAt != nil {
+		update.SetPublishAt(*publishAt)
+	} else {
+		update.ClearPublishAt()
+	}
+	switch {
+	case language == nil:
+	case normalizeLanguage(*language) == "":
+		update.ClearLanguage()
+	default:
+		update.SetLanguage(normalizeLanguage(*language))
+	}
+
+	post, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update post: %w", err)
+	}
+	return post, nil
+}
+
+// DeletePost removes a post owned by userID.
+func (s *PostService) DeletePost(ctx context.Context, id, userID uuid.UUID) error {
+	if _, err := s.ownedPost(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to delete post: %w", err)
+	}
+	if err := s.client.Post.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete post: %w", err)
+	}
+	return nil
+}
+
+// GetPublishedPosts returns all published posts, newest first.
+func (s *PostService) GetPublishedPosts(ctx context.Context) ([]*ent.Post, error) {
+	posts, err := s.client.Post.Query().
+		Where(post.Draft(false)).
+		Order(ent.Desc(post.FieldPublishedAt), ent.Desc(post.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+	return posts, nil
+}
+
+// GetPostsByUser returns the posts written by userID, newest first. Drafts
+// are only included when the viewer is the author.
+func (s *PostService) GetPostsByUser(ctx context.Context, userID uuid.UUID, viewerID *uuid.UUID) ([]*ent.Post, error) {
+	query := s.client.Post.Query().
+		Where(post.UserID(userID))
+	if viewerID == nil || *viewerID != userID {
+		query.Where(post.Draft(false))
+	}
+	posts, err := query.
+		Order(ent.Desc(post.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user posts: %w", err)
+	}
+	return posts, nil
+}
+
+// PublishDuePosts publishes every draft whose publish_at is not after now
+// and returns how many posts were published.
+func (s *PostService) PublishDuePosts(ctx context.Context, now time.Time) (int, error) {
+	n, err := s.client.Post.Update().
+		Where(post.Draft(true), post.PublishAtLTE(now)).
+		SetDraft(false).
+		SetPublishedAt(now).
+		ClearPublishAt().
+		Save(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to publish scheduled posts: %w", err)
+	}
+	return n, nil
+}
+
+// RunScheduler publishes due posts every postSchedulerInterval until ctx is
+// cancelled. Publishing is a single conditional update, so several servers
+// may run the scheduler at once.
+func (s *PostService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(postSchedulerInterval)
+	defer ticker.Stop()
+	for {
+		n, err := s.PublishDuePosts(ctx, time.Now())
+		if err != nil {
+			log.Printf("post scheduler: %v", err)
+		} else if n > 0 {
+			log.Printf("post scheduler: published %d posts", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ValidatePublishAt checks that a publish time is only given for drafts and
+// lies in the future.
+func ValidatePublishAt(draft bool, publishAt *time.Time, now time.Time) error {
+	if publishAt == nil {
+		return nil
+	}
+	if !draft {
+		return errors.New("only drafts can be scheduled for publishing")
+	}
+	if !publishAt.After(now) {
+		return errors.New("publish time must be in the future")
+	}
+	return nil
+}
+
+func (s *PostService) ownedPost(ctx context.Context, id, userID uuid.UUID) (*ent.Post, error) {
+	p, err := s.client.Post.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	if p.UserID != userID {
+		return nil, ErrForbidden
+	}
+	return p, nil
+}
@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/notification"
+	"LinganoGO/ent/reading"
+	"LinganoGO/ent/readingreview"
+	"LinganoGO/ent/user"
+
+	"github.com/google/uuid"
+)
+
+// publicationTransitions lists the publication statuses a reading can move to
+// from each status. Approved and published readings go back to review when
+// their content changes.
+var publicationTransitions = map[reading.PublicationStatus][]reading.PublicationStatus{
+	reading.PublicationStatusPRIVATE:   {reading.PublicationStatusSUBMITTED},
+	reading.PublicationStatusSUBMITTED: {reading.PublicationStatusAPPROVED, reading.PublicationStatusREJECTED, reading.PublicationStatusPRIVATE},
+	reading.PublicationStatusAPPROVED:  {reading.PublicationStatusPUBLISHED, reading.PublicationStatusSUBMITTED, reading.PublicationStatusPRIVATE},
+	reading.PublicationStatusREJECTED:  {reading.PublicationStatusSUBMITTED, reading.PublicationStatusPRIVATE},
+	reading.PublicationStatusPUBLISHED: {reading.PublicationStatusSUBMITTED, reading.PublicationStatusPRIVATE},
+}
+
+// errNeedsContent is returned when a reading with nothing to review is
+// submitted.
+var errNeedsContent = errors.New("a reading needs content before it can be submitted")
+
+// PublicationService provides methods for the editorial review of readings before they are
+// published using Ent
+type PublicationService struct {
+	client *ent.Client
+}
+
+// NewPublicationService creates a new PublicationService
+func NewPublicationService() *PublicationService {
+	return &PublicationService{
+		client: config.GetEntClient(),
+	}
+}
+
+// Submit sends a private or rejected reading owned by the user to the review queue
+func (s *PublicationService) Submit(ctx context.Context, id, userID uuid.UUID) (*ent.Reading, error) {
+	r, err := s.ownedReading(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.moveReading(ctx, r, reading.PublicationStatusSUBMITTED)
+}
+
+// Review records an admin's decision on a submitted reading and notifies its author
+func (s *PublicationService) Review(ctx context.Context, id, reviewerID uuid.UUID, decision readingreview.Decision, comment *string) (*ent.ReadingReview, error) {
+	if err := readingreview.DecisionValidator(decision); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, reviewerID); err != nil {
+		return nil, err
+	}
+	r, err := s.client.Reading.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if r.PublicationStatus != reading.PublicationStatusSUBMITTED {
+		return nil, fmt.Errorf("only submitted readings can be reviewed, this one is %s", r.PublicationStatus)
+	}
+	if comment != nil {
+		trimmed := strings.TrimSpace(*comment)
+		comment = &trimmed
+		if trimmed == "" {
+			comment = nil
+		}
+	}
+
+	status, kind := reading.PublicationStatusAPPROVED, notification.KindREADING_APPROVED
+	message := fmt.Sprintf("Your reading %q was approved and can now be published.", r.Title)
+	if decision == readingreview.DecisionREJECTED {
+		status, kind = reading.PublicationStatusREJECTED, notification.KindREADING_REJECTED
+		message = fmt.Sprintf("Your reading %q was not approved for publication.", r.Title)
+	}
+
+	var review *ent.ReadingReview
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		update := tx.Reading.UpdateOneID(id)
+		if err := changePublicationStatus(ctx, tx.Client(), update, r.Body, status); err != nil {
+			return err
+		}
+		if err := update.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to update reading: %w", err)
+		}
+
+		review, err = tx.ReadingReview.
+			Create().
+			SetReadingID(id).
+			SetReviewerID(reviewerID).
+			SetDecision(decision).
+			SetNillableComment(comment).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to record review: %w", err)
+		}
+
+		return notify(ctx, tx, notificationDraft{
+			userID:    r.UserID,
+			actorID:   &reviewerID,
+			kind:      kind,
+			readingID: &id,
+			message:   withComment(message, comment),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// Publish makes an approved reading owned by the user public
+func (s *PublicationService) Publish(ctx context.Context, id, userID uuid.UUID) (*ent.Reading, error) {
+	r, err := s.ownedReading(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.moveReading(ctx, r, reading.PublicationStatusPUBLISHED)
+}
+
+// Unpublish makes a reading private again, withdrawing it from review if it
+// was not published yet. Authors can unpublish their own readings and admins
+// any reading; the author is notified when an admin takes their reading down.
+func (s *PublicationService) Unpublish(ctx context.Context, id, userID uuid.UUID, comment *string) (*ent.Reading, error) {
+	r, err := s.client.Reading.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if r.UserID != userID {
+		if err := s.requireAdmin(ctx, userID); err != nil {
+			return nil, fmt.Errorf("failed to unpublish reading: %w", ErrForbidden)
+		}
+	}
+	if !CanChangePublicationStatus(r.PublicationStatus, reading.PublicationStatusPRIVATE) {
+		return nil, fmt.Errorf("cannot move a %s reading to %s", r.PublicationStatus, reading.PublicationStatusPRIVATE)
+	}
+
+	var updated *ent.Reading
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		update := tx.Reading.UpdateOneID(id)
+		if err := changePublicationStatus(ctx, tx.Client(), update, r.Body, reading.PublicationStatusPRIVATE); err != nil {
+			return err
+		}
+		updated, err = update.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update reading: %w", err)
+		}
+
+		if r.PublicationStatus != reading.PublicationStatusPUBLISHED {
+			return nil
+		}
+		return notify(ctx, tx, notificationDraft{
+			userID:    r.UserID,
+			actorID:   &userID,
+			kind:      notification.KindREADING_UNPUBLISHED,
+			readingID: &id,
+			message:   withComment(fmt.Sprintf("Your reading %q was unpublished by an admin.", r.Title), comment),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// ReviewQueue returns the readings with the given publication status for an admin, those
+// submitted first coming first
+func (s *PublicationService) ReviewQueue(ctx context.Context, adminID uuid.UUID, status reading.PublicationStatus) ([]*ent.Reading, error) {
+	if err := reading.PublicationStatusValidator(status); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	readings, err := s.client.Reading.
+		Query().
+		Where(reading.PublicationStatusEQ(status)).
+		Order(ent.Asc(reading.FieldSubmittedAt), ent.Asc(reading.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	return readings, nil
+}
+
+// GetReviews returns the reviews of a reading to its author or an admin, newest first
+func (s *PublicationService) GetReviews(ctx context.Context, id, userID uuid.UUID) ([]*ent.ReadingReview, error) {
+	r, err := s.client.Reading.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if r.UserID != userID {
+		if err := s.requireAdmin(ctx, userID); err != nil {
+			return nil, fmt.Errorf("failed to get reviews: %w", ErrForbidden)
+		}
+	}
+
+	reviews, err := s.client.ReadingReview.
+		Query().
+		Where(readingreview.ReadingID(id)).
+		Order(ent.Desc(readingreview.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// CanChangePublicationStatus reports whether a reading can move from one
+// publication status to another.
+func CanChangePublicationStatus(from, to reading.PublicationStatus) bool {
+	return slices.Contains(publicationTransitions[from], to)
+}
+
+// PublicationStatusForPublic returns the publication status asked for by
+// setting the public flag of a reading the way clients did before readings
+// were reviewed. Making a reading public submits it for review, or publishes
+// it once it is approved; making it private withdraws it.
+func PublicationStatusForPublic(current reading.PublicationStatus, public bool) reading.PublicationStatus {
+	switch {
+	case !public:
+		return reading.PublicationStatusPRIVATE
+	case current == reading.PublicationStatusAPPROVED || current == reading.PublicationStatusPUBLISHED:
+		return reading.PublicationStatusPUBLISHED
+	default:
+		return reading.PublicationStatusSUBMITTED
+	}
+}
+
+// PublicationStatusAfterUpdate returns the publication status of a reading
+// after an update that may set its public flag or change its content.
+// Approved or published content that changes needs to be approved again, so
+// a published reading is taken down until the new content is reviewed.
+func PublicationStatusAfterUpdate(current reading.PublicationStatus, public *bool, contentChanged bool) reading.PublicationStatus {
+	status := current
+	if public != nil {
+		status = PublicationStatusForPublic(current, *public)
+	}
+	if contentChanged && (status == reading.PublicationStatusAPPROVED || status == reading.PublicationStatusPUBLISHED) {
+		status = reading.PublicationStatusSUBMITTED
+	}
+	return status
+}
+
+// setPublicationStatus moves a reading being created or updated to a
+// publication status, keeping public and the timestamps in step.
+func setPublicationStatus(m *ent.ReadingMutation, status reading.PublicationStatus) {
+	m.SetPublicationStatus(status)
+	m.SetPublic(status == reading.PublicationStatusPUBLISHED)
+	switch status {
+	case reading.PublicationStatusSUBMITTED:
+		m.SetSubmittedAt(time.Now())
+	case reading.PublicationStatusPUBLISHED:
+		m.SetPublishedAt(time.Now())
+	}
+}
+
+// changePublicationStatus sets the publication status of a reading being
+// updated and moves the chapters of a book with it. A reading going to review
+// needs content: body is its text after the update.
+func changePublicationStatus(ctx context.Context, client *ent.Client, update *ent.ReadingUpdateOne, body string, status reading.PublicationStatus) error {
+	id, _ := update.Mutation().ID()
+	if status == reading.PublicationStatusSUBMITTED {
+		if err := requireContent(ctx, client, id, body); err != nil {
+			return err
+		}
+	}
+	setPublicationStatus(update.Mutation(), status)
+	return setChaptersPublicationStatus(ctx, client, id, status)
+}
+
+// setChaptersPublicationStatus moves the chapters of a book along with it, so
+// that a book is reviewed and published as a whole.
+func setChaptersPublicationStatus(ctx context.Context, client *ent.Client, parentID uuid.UUID, status reading.PublicationStatus) error {
+	update := client.Reading.Update().Where(reading.ParentID(parentID))
+	setPublicationStatus(update.Mutation(), status)
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update chapters: %w", err)
+	}
+	return nil
+}
+
+// requireContent fails unless a reading going to review has something to
+// review: a body or, for a book, chapters. Readings being created have no
+// chapters yet; pass uuid.Nil to check only the body.
+func requireContent(ctx context.Context, client *ent.Client, id uuid.UUID, body string) error {
+	if strings.TrimSpace(body) != "" {
+		return nil
+	}
+	if id != uuid.Nil {
+		hasChapters, err := client.Reading.Query().Where(reading.ParentID(id)).Exist(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get chapters: %w", err)
+		}
+		if hasChapters {
+			return nil
+		}
+	}
+	return errNeedsContent
+}
+
+// moveReading changes the publication status of a reading if the workflow allows it
+func (s *PublicationService) moveReading(ctx context.Context, r *ent.Reading, status reading.PublicationStatus) (*ent.Reading, error) {
+	if !CanChangePublicationStatus(r.PublicationStatus, status) {
+		return nil, fmt.Errorf("cannot move a %s reading to %s", r.PublicationStatus, status)
+	}
+
+	var updated *ent.Reading
+	err := withTx(ctx, s.client, func(tx *ent.Tx) error {
+		update := tx.Reading.UpdateOneID(r.ID)
+		if err := changePublicationStatus(ctx, tx.Client(), update, r.Body, status); err != nil {
+			return err
+		}
+		var err error
+		updated, err = update.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update reading: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *PublicationService) ownedReading(ctx context.Context, id, userID uuid.UUID) (*ent.Reading, error) {
+	r, err := s.client.Reading.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if r.UserID != userID {
+		return nil, fmt.Errorf("failed to change publication status: %w", ErrForbidden)
+	}
+	return r, nil
+}
+
+func (s *PublicationService) requireAdmin(ctx context.Context, userID uuid.UUID) error {
+	u, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if u.Role != user.RoleADMIN {
+		return fmt.Errorf("only admins can review readings: %w", ErrForbidden)
+	}
+	return nil
+}
+
+// withComment appends a reviewer's comment to a notification message
+func withComment(message string, comment *string) string {
+	if comment == nil || strings.TrimSpace(*comment) == "" {
+		return message
+	}
+	return message + " Comment: " + strings.TrimSpace(*comment)
+}
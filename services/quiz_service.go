@@ -0,0 +1,718 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"LinganoGO/config"
+	"LinganoGO/dictionary"
+	"LinganoGO/ent"
+	entdictionary "LinganoGO/ent/dictionary"
+	"LinganoGO/ent/dictionaryentry"
+	"LinganoGO/ent/quizattempt"
+	"LinganoGO/ent/vocabularyitem"
+	"LinganoGO/estimator"
+	"LinganoGO/graph/model"
+	"LinganoGO/tokenizer"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxQuizItems is the largest number of blanks in a generated quiz.
+	maxQuizItems = 50
+	// quizDistractors is the number of wrong choices offered with each blank
+	// of a multiple choice quiz.
+	quizDistractors = 3
+	// quizPoolPerAnswer is the number of words closest in frequency to each
+	// answer that distractors are chosen from.
+	quizPoolPerAnswer = 40
+	// headwordChunkSize bounds the headwords looked up per query, well under
+	// the number of parameters PostgreSQL accepts in one statement.
+	headwordChunkSize = 5000
+)
+
+// QuizService generates cloze quizzes from the sentences of readings and records the attempts
+// readers make at them using Ent
+type QuizService struct {
+	client     *ent.Client
+	sentences  *SentenceService
+	vocabulary *VocabularyService
+}
+
+// NewQuizService creates a new QuizService
+func NewQuizService() *QuizService {
+	return &QuizService{
+		client:     config.GetEntClient(),
+		sentences:  NewSentenceService(),
+		vocabulary: NewVocabularyService(),
+	}
+}
+
+// ClozeBlank is a word to blank out of a sentence. Sentence is an index into
+// the sentences the blank was picked from; Start and End are character
+// offsets into that sentence.
+type ClozeBlank struct {
+	Sentence int
+	Start    int
+	End      int
+	Word     string
+}
+
+// quizOutcome is how one blank of a quiz was answered.
+type quizOutcome struct {
+	sentence string
+	word     tokenizer.Token
+	answer   string
+	correct  bool
+}
+
+// QuizWord is a word that can fill a blank, either as its answer or as a
+// distractor. Rank is the word's frequency rank in its language, 0 when it
+// isn't known, and PartOfSpeech is empty when no dictionary lists the word.
+type QuizWord struct {
+	Text         string
+	Normalized   string
+	Rank         int
+	PartOfSpeech string
+}
+
+// GenerateQuiz builds a cloze quiz of up to count sentences of a reading the
+// user can view. Sentences with words the user is learning come first, then
+// sentences with words they haven't saved yet. Multiple choice blanks get
+// distractors of a similar frequency and, when a dictionary knows them, the
+// same part of speech.
+func (s *QuizService) GenerateQuiz(ctx context.Context, readingID, userID uuid.UUID, count int, kind quizattempt.Kind) (*model.Quiz, error) {
+	if err := quizattempt.KindValidator(kind); err != nil {
+		return nil, err
+	}
+	if count < 1 || count > maxQuizItems {
+		return nil, fmt.Errorf("count must be between 1 and %d", maxQuizItems)
+	}
+
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if !canViewReading(reading, &userID) {
+		return nil, fmt.Errorf("failed to generate quiz: %w", ErrForbidden)
+	}
+
+	sentences, err := s.sentences.GetSentences(ctx, readingID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.vocabulary.GetWordStatuses(ctx, userID, reading.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, len(sentences))
+	for i, sentence := range sentences {
+		texts[i] = sentence.Text
+	}
+	blanks := PickClozeBlanks(texts, reading.Language, statuses, count)
+
+	quiz := &model.Quiz{Reading: reading, Kind: kind, Items: make([]*model.QuizItem, 0, len(blanks))}
+	if len(blanks) == 0 {
+		return quiz, nil
+	}
+
+	var words map[string]QuizWord
+	var pool []QuizWord
+	if kind == quizattempt.KindMULTIPLE_CHOICE {
+		answers := make([]string, len(blanks))
+		for i, blank := range blanks {
+			answers[i] = blank.Word
+		}
+		words, pool, err = s.quizWords(ctx, reading, answers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, blank := range blanks {
+		sentence := sentences[blank.Sentence]
+		item := &model.QuizItem{
+			Sentence: sentence,
+			Start:    blank.Start,
+			End:      blank.End,
+			Prompt:   clozePrompt(sentence.Text, blank.Start, blank.End),
+			Choices:  []string{},
+		}
+		if kind == quizattempt.KindMULTIPLE_CHOICE {
+			answer := words[tokenizer.Normalize(blank.Word, reading.Language)]
+			answer.Text = blank.Word
+			distractors := Distractors(answer, pool, quizDistractors)
+			if len(distractors) == 0 {
+				continue
+			}
+			item.Choices = append(distractors, blank.Word)
+			rand.Shuffle(len(item.Choices), func(i, j int) {
+				item.Choices[i], item.Choices[j] = item.Choices[j], item.Choices[i]
+			})
+		}
+		quiz.Items = append(quiz.Items, item)
+	}
+
+	return quiz, nil
+}
+
+// SubmitQuiz scores the user's answers to the blanks of a quiz on a reading
+// they can view and records the attempt. Each blank is checked against the
+// word found at its offsets in the sentence. The results feed the user's
+// vocabulary: a word always answered right moves one status up, a word
+// answered wrong moves one status down, and a wrong word the user hadn't
+// saved yet is saved as learning.
+func (s *QuizService) SubmitQuiz(ctx context.Context, readingID, userID uuid.UUID, kind quizattempt.Kind, answers []*model.QuizAnswerInput) (*ent.QuizAttempt, error) {
+	if err := quizattempt.KindValidator(kind); err != nil {
+		return nil, err
+	}
+	if len(answers) == 0 {
+		return nil, fmt.Errorf("a quiz needs at least one answer")
+	}
+
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if !canViewReading(reading, &userID) {
+		return nil, fmt.Errorf("failed to submit quiz: %w", ErrForbidden)
+	}
+
+	sentences, err := s.sentences.GetSentences(ctx, readingID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*ent.ReadingSentence, len(sentences))
+	for _, sentence := range sentences {
+		byID[sentence.ID] = sentence
+	}
+
+	results := make([]quizOutcome, 0, len(answers))
+	type blank struct {
+		sentence   uuid.UUID
+		start, end int
+	}
+	seen := make(map[blank]bool, len(answers))
+	for _, answer := range answers {
+		id, err := uuid.Parse(answer.SentenceID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sentence ID: %w", err)
+		}
+		sentence, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("sentence %s is not part of this reading", id)
+		}
+		word, ok := wordAt(sentence.Text, reading.Language, answer.Start, answer.End)
+		if !ok {
+			return nil, fmt.Errorf("no word at %d-%d in sentence %s", answer.Start, answer.End, id)
+		}
+		key := blank{id, answer.Start, answer.End}
+		if seen[key] {
+			return nil, fmt.Errorf("blank %d-%d of sentence %s is answered twice", answer.Start, answer.End, id)
+		}
+		seen[key] = true
+
+		given := truncate(strings.TrimSpace(answer.Answer), maxHeadwordLength)
+		results = append(results, quizOutcome{
+			sentence: sentence.Text,
+			word:     word,
+			answer:   given,
+			correct:  ScoreClozeAnswer(word.Text, given, reading.Language),
+		})
+	}
+
+	var attempt *ent.QuizAttempt
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		score := 0
+		for _, result := range results {
+			if result.correct {
+				score++
+			}
+		}
+
+		attempt, err = tx.QuizAttempt.
+			Create().
+			SetReadingID(readingID).
+			SetUserID(userID).
+			SetKind(kind).
+			SetScore(score).
+			SetTotal(len(results)).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to record attempt: %w", err)
+		}
+
+		builders := make([]*ent.QuizAnswerCreate, len(results))
+		for i, result := range results {
+			builders[i] = tx.QuizAnswer.
+				Create().
+				SetAttemptID(attempt.ID).
+				SetPosition(i).
+				SetSentence(result.sentence).
+				SetWord(result.word.Text).
+				SetAnswer(result.answer).
+				SetCorrect(result.correct)
+		}
+		if _, err := tx.QuizAnswer.CreateBulk(builders...).Save(ctx); err != nil {
+			return fmt.Errorf("failed to record answers: %w", err)
+		}
+
+		// A word blanked more than once counts as right only if every blank was.
+		outcomes := make(map[string]quizOutcome, len(results))
+		for _, result := range results {
+			if prev, ok := outcomes[result.word.Normalized]; ok && !prev.correct {
+				continue
+			}
+			outcomes[result.word.Normalized] = result
+		}
+		return updateQuizVocabulary(ctx, tx, userID, reading, outcomes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+// GetAttempts returns the user's own attempts at quizzes on a reading, newest first
+func (s *QuizService) GetAttempts(ctx context.Context, readingID, userID uuid.UUID) ([]*ent.QuizAttempt, error) {
+	attempts, err := s.client.QuizAttempt.
+		Query().
+		Where(
+			quizattempt.ReadingID(readingID),
+			quizattempt.UserID(userID),
+		).
+		Order(ent.Desc(quizattempt.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// updateQuizVocabulary moves the status of every quizzed word according to
+// its outcome. Words saved for the reading's language win over words saved
+// without a language, like in GetWordStatuses.
+func updateQuizVocabulary(ctx context.Context, tx *ent.Tx, userID uuid.UUID, reading *ent.Reading, outcomes map[string]quizOutcome) error {
+	words := make([]string, 0, len(outcomes))
+	for word := range outcomes {
+		words = append(words, word)
+	}
+
+	items, err := tx.VocabularyItem.
+		Query().
+		Where(
+			vocabularyitem.UserID(userID),
+			vocabularyitem.LanguageIn(reading.Language, ""),
+			vocabularyitem.NormalizedTermIn(words...),
+		).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get vocabulary: %w", err)
+	}
+	saved := make(map[string]*ent.VocabularyItem, len(items))
+	for _, item := range items {
+		if prev, ok := saved[item.NormalizedTerm]; ok && prev.Language != "" {
+			continue
+		}
+		saved[item.NormalizedTerm] = item
+	}
+
+	for _, word := range words {
+		outcome := outcomes[word]
+		item, ok := saved[word]
+		if !ok {
+			if outcome.correct {
+				continue
+			}
+			err := tx.VocabularyItem.
+				Create().
+				SetUserID(userID).
+				SetTerm(outcome.word.Text).
+				SetNormalizedTerm(outcome.word.Normalized).
+				SetLanguage(reading.Language).
+				SetStatus(QuizStatus(0, false)).
+				SetSentence(outcome.sentence).
+				SetReadingID(reading.ID).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to save word: %w", err)
+			}
+			continue
+		}
+		if item.Ignored {
+			continue
+		}
+		if status := QuizStatus(item.Status, outcome.correct); status != item.Status {
+			err := item.Update().SetStatus(status).SetStatusUpdatedAt(time.Now()).Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to update word status: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// quizWords looks up the frequency rank and part of speech of the answers of
+// a quiz and returns them by normalized form, along with the pool of words
+// distractors are drawn from: for each answer, the quizPoolPerAnswer words of
+// the reading and of the language's frequency list closest to it in frequency.
+func (s *QuizService) quizWords(ctx context.Context, reading *ent.Reading, answers []string) (map[string]QuizWord, []QuizWord, error) {
+	language := reading.Language
+
+	candidates := make(map[string]QuizWord)
+	add := func(words map[string]QuizWord, text string) {
+		normalized := tokenizer.Normalize(text, language)
+		if _, ok := words[normalized]; ok || normalized == "" {
+			return
+		}
+		words[normalized] = QuizWord{Text: text, Normalized: normalized, Rank: estimator.Rank(language, normalized)}
+	}
+	for _, token := range tokenizer.Words(reading.Body, language) {
+		add(candidates, token.Normalized)
+	}
+	for _, word := range estimator.Words(language) {
+		add(candidates, word)
+	}
+
+	words := make(map[string]QuizWord)
+	for _, answer := range answers {
+		add(words, answer)
+	}
+	for _, answer := range answers {
+		for _, word := range nearestByRank(words[tokenizer.Normalize(answer, language)], candidates, quizPoolPerAnswer) {
+			if _, ok := words[word.Normalized]; !ok {
+				words[word.Normalized] = word
+			}
+		}
+	}
+
+	// Dictionaries list lemmas, so inflected forms take the part of speech
+	// of the first lemma found.
+	lookups := make(map[string][]string, len(words))
+	var headwords []string
+	for normalized := range words {
+		forms := append([]string{normalized}, dictionary.Lemmas(normalized, language)...)
+		lookups[normalized] = forms
+		headwords = append(headwords, forms...)
+	}
+	slices.Sort(headwords)
+	headwords = slices.Compact(headwords)
+
+	partsOfSpeech := make(map[string]string)
+	for chunk := range slices.Chunk(headwords, headwordChunkSize) {
+		entries, err := s.client.DictionaryEntry.
+			Query().
+			Where(
+				dictionaryentry.HasDictionaryWith(entdictionary.SourceLanguage(language)),
+				dictionaryentry.NormalizedHeadwordIn(chunk...),
+				dictionaryentry.PartOfSpeechNotNil(),
+			).
+			Order(ent.Asc(dictionaryentry.FieldRank)).
+			Select(dictionaryentry.FieldNormalizedHeadword, dictionaryentry.FieldPartOfSpeech).
+			All(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get parts of speech: %w", err)
+		}
+		for _, entry := range entries {
+			if _, ok := partsOfSpeech[entry.NormalizedHeadword]; !ok {
+				partsOfSpeech[entry.NormalizedHeadword] = partOfSpeechKey(*entry.PartOfSpeech)
+			}
+		}
+	}
+
+	pool := make([]QuizWord, 0, len(words))
+	for normalized, word := range words {
+		for _, form := range lookups[normalized] {
+			if pos := partsOfSpeech[form]; pos != "" {
+				word.PartOfSpeech = pos
+				break
+			}
+		}
+		words[normalized] = word
+		pool = append(pool, word)
+	}
+	return words, pool, nil
+}
+
+// nearestByRank returns the n words closest to answer in frequency rank, by
+// the same measure as Distractors. Words without a rank count as the rarest.
+func nearestByRank(answer QuizWord, words map[string]QuizWord, n int) []QuizWord {
+	rarest := 1
+	for _, word := range words {
+		rarest = max(rarest, word.Rank+1)
+	}
+	logRank := func(rank int) float64 {
+		if rank == 0 {
+			rank = rarest
+		}
+		return math.Log(float64(rank))
+	}
+
+	nearest := make([]QuizWord, 0, len(words))
+	for _, word := range words {
+		nearest = append(nearest, word)
+	}
+	distance := func(w QuizWord) float64 { return math.Abs(logRank(answer.Rank) - logRank(w.Rank)) }
+	slices.SortFunc(nearest, func(a, b QuizWord) int {
+		if da, db := distance(a), distance(b); da != db {
+			if da < db {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Normalized, b.Normalized)
+	})
+	return nearest[:min(n, len(nearest))]
+}
+
+// PickClozeBlanks picks up to count words to blank out of sentences, at most
+// one per sentence and never the same word twice. Words the user is learning
+// are picked first and the remaining blanks go to words the user hasn't
+// saved, rarer words first in both cases. Known and ignored words are never
+// picked, nor are capitalized words in the middle of a sentence, which are
+// mostly names. The blanks are returned in the order of the sentences.
+func PickClozeBlanks(sentences []string, language string, statuses map[string]model.WordStatus, count int) []ClozeBlank {
+	type candidate struct {
+		blank    ClozeBlank
+		learning bool
+		rank     int
+	}
+	rarest := len(estimator.Words(language)) + 1
+	// German capitalizes every noun, not only names.
+	nameCase := !strings.HasPrefix(normalizeLanguage(language), "de")
+
+	var candidates []candidate
+	for i, sentence := range sentences {
+		for j, token := range tokenizer.Words(sentence, language) {
+			status, saved := statuses[token.Normalized]
+			if saved && status != model.WordStatusLearning && status != model.WordStatusNew {
+				continue
+			}
+			learning := status == model.WordStatusLearning
+			if !learning && (utf8.RuneCountInString(token.Normalized) < 3 ||
+				(nameCase && j > 0 && startsUpper(token.Text))) {
+				continue
+			}
+			rank := estimator.Rank(language, token.Normalized)
+			if rank == 0 {
+				rank = rarest
+			}
+			candidates = append(candidates, candidate{ClozeBlank{i, token.Start, token.End, token.Text}, learning, rank})
+		}
+	}
+
+	// Sort the best blanks first, keeping the text order between equals.
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.learning != b.learning {
+			if a.learning {
+				return -1
+			}
+			return 1
+		}
+		return b.rank - a.rank
+	})
+
+	var blanks []ClozeBlank
+	usedWords := make(map[string]bool)
+	usedSentences := make(map[int]bool)
+	for _, c := range candidates {
+		if len(blanks) == count {
+			break
+		}
+		normalized := tokenizer.Normalize(c.blank.Word, language)
+		if usedWords[normalized] || usedSentences[c.blank.Sentence] {
+			continue
+		}
+		usedWords[normalized] = true
+		usedSentences[c.blank.Sentence] = true
+		blanks = append(blanks, c.blank)
+	}
+	slices.SortFunc(blanks, func(a, b ClozeBlank) int { return a.Sentence - b.Sentence })
+	return blanks
+}
+
+// Distractors returns up to n wrong choices for a blank whose answer is
+// answer, closest first: words of a similar frequency, of the same part of
+// speech when both are known and otherwise ending like the answer, so that
+// they fit the sentence about as well. Forms of the answer itself are left
+// out. The distractors take the capitalization of the answer.
+func Distractors(answer QuizWord, pool []QuizWord, n int) []string {
+	rarest := 1
+	for _, word := range pool {
+		rarest = max(rarest, word.Rank+1)
+	}
+	logRank := func(rank int) float64 {
+		if rank == 0 {
+			rank = rarest
+		}
+		return math.Log(float64(rank))
+	}
+
+	type scored struct {
+		word     QuizWord
+		distance float64
+	}
+	var candidates []scored
+	seen := map[string]bool{answer.Normalized: true}
+	for _, word := range pool {
+		if seen[word.Normalized] || sameStem(word.Normalized, answer.Normalized) ||
+			strings.IndexFunc(word.Normalized, unicode.IsLetter) < 0 {
+			continue
+		}
+		seen[word.Normalized] = true
+
+		distance := math.Abs(logRank(answer.Rank) - logRank(word.Rank))
+		switch {
+		case answer.PartOfSpeech != "" && word.PartOfSpeech != "":
+			if answer.PartOfSpeech != word.PartOfSpeech {
+				distance += 10
+			}
+		case commonSuffix(answer.Normalized, word.Normalized) < 2:
+			distance++
+		}
+		lengths := utf8.RuneCountInString(answer.Normalized) - utf8.RuneCountInString(word.Normalized)
+		distance += 0.1 * math.Abs(float64(lengths))
+
+		candidates = append(candidates, scored{word, distance})
+	}
+
+	slices.SortFunc(candidates, func(a, b scored) int {
+		if a.distance != b.distance {
+			if a.distance < b.distance {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.word.Normalized, b.word.Normalized)
+	})
+
+	distractors := make([]string, 0, n)
+	for _, c := range candidates {
+		if len(distractors) == n {
+			break
+		}
+		distractors = append(distractors, matchCase(answer.Text, c.word.Text))
+	}
+	return distractors
+}
+
+// ScoreClozeAnswer reports whether answer fills a blank whose word is word.
+// Case and surrounding punctuation don't matter, accents do.
+func ScoreClozeAnswer(word, answer, language string) bool {
+	answer = strings.TrimFunc(answer, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSpace(r)
+	})
+	return answer != "" && tokenizer.Normalize(answer, language) == tokenizer.Normalize(word, language)
+}
+
+// QuizStatus returns the vocabulary status of a word after it was answered
+// in a quiz: one step towards known when it was right and one step back,
+// but never below learning, when it was wrong.
+func QuizStatus(status int, correct bool) int {
+	if correct {
+		return min(status+1, KnownStatus)
+	}
+	return max(status-1, 1)
+}
+
+// QuizPercentage returns the score of a quiz attempt as a percentage of its
+// blanks.
+func QuizPercentage(attempt *ent.QuizAttempt) float64 {
+	if attempt.Total == 0 {
+		return 0
+	}
+	return float64(attempt.Score) / float64(attempt.Total) * 100
+}
+
+// clozePrompt replaces the characters from start to end of text with
+// underscores.
+func clozePrompt(text string, start, end int) string {
+	runes := []rune(text)
+	return string(runes[:start]) + "_____" + string(runes[end:])
+}
+
+// wordAt returns the word token found exactly from start to end of text.
+func wordAt(text, language string, start, end int) (tokenizer.Token, bool) {
+	for _, token := range tokenizer.Words(text, language) {
+		if token.Start == start && token.End == end {
+			return token, true
+		}
+	}
+	return tokenizer.Token{}, false
+}
+
+// partOfSpeechKey reduces the part of speech written in a dictionary to a
+// form that can be compared across dictionaries: "n.", "Noun" and "noun
+// (countable)" all become "noun".
+func partOfSpeechKey(pos string) string {
+	pos = strings.ToLower(strings.TrimSpace(pos))
+	if i := strings.IndexFunc(pos, func(r rune) bool { return !unicode.IsLetter(r) }); i >= 0 {
+		pos = pos[:i]
+	}
+	switch pos {
+	case "n", "nn", "s", "sub", "subst":
+		return "noun"
+	case "v", "vb", "vt", "vi":
+		return "verb"
+	case "a", "adj":
+		return "adjective"
+	case "adv":
+		return "adverb"
+	case "prep":
+		return "preposition"
+	case "pron":
+		return "pronoun"
+	case "conj":
+		return "conjunction"
+	}
+	return pos
+}
+
+// sameStem reports whether one word looks like an inflection of the other,
+// such as "walk" and "walked".
+func sameStem(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return utf8.RuneCountInString(a) >= 3 && strings.HasPrefix(b, a) &&
+		utf8.RuneCountInString(b)-utf8.RuneCountInString(a) <= 3
+}
+
+// commonSuffix returns how many trailing characters a and b share.
+func commonSuffix(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	n := 0
+	for n < len(ra) && n < len(rb) && ra[len(ra)-1-n] == rb[len(rb)-1-n] {
+		n++
+	}
+	return n
+}
+
+// matchCase writes word with the capitalization of model: all capitals or a
+// capital first letter.
+func matchCase(model, word string) string {
+	if utf8.RuneCountInString(model) > 1 && strings.ToUpper(model) == model && strings.ToLower(model) != model {
+		return strings.ToUpper(word)
+	}
+	if startsUpper(model) {
+		r, size := utf8.DecodeRuneInString(word)
+		return string(unicode.ToUpper(r)) + word[size:]
+	}
+	return word
+}
+
+func startsUpper(word string) bool {
+	r, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(r)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a user has made too many requests.
+var ErrRateLimited = errors.New("rate limit exceeded, try again later")
+
+// maxRateBuckets is the number of keys tracked before idle ones are dropped.
+const maxRateBuckets = 10000
+
+// RateLimiter allows each key a number of requests per window. Requests are
+// counted in a token bucket per key that refills steadily, so a key can
+// burst up to the limit and then continues at the average rate.
+type RateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*rateBucket
+}
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing limit requests per window.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:   limit,
+		window:  window,
+		buckets: make(map[string]*rateBucket),
+	}
+}
+
+// Allow reports whether a request for key may go ahead, and counts it if so.
+func (l *RateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxRateBuckets {
+			l.prune(now)
+		}
+		bucket = &rateBucket{tokens: float64(l.limit), last: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = l.refill(bucket, now)
+	bucket.last = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+func (l *RateLimiter) refill(bucket *rateBucket, now time.Time) float64 {
+	rate := float64(l.limit) / float64(l.window)
+	return min(float64(l.limit), bucket.tokens+float64(now.Sub(bucket.last))*rate)
+}
+
+// prune drops the buckets that have refilled completely; they behave the same
+// as new ones.
+func (l *RateLimiter) prune(now time.Time) {
+	for key, bucket := range l.buckets {
+		if l.refill(bucket, now) >= float64(l.limit) {
+			delete(l.buckets, key)
+		}
+	}
+}
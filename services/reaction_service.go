@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/reaction"
+	"LinganoGO/graph/model"
+
+	"github.com/google/uuid"
+)
+
+// reactionKinds lists the kinds of reaction in the order they are declared.
+var reactionKinds = []reaction.Kind{
+	reaction.KindLIKE,
+	reaction.KindLOVE,
+	reaction.KindLAUGH,
+	reaction.KindWOW,
+	reaction.KindSAD,
+	reaction.KindTHANKS,
+}
+
+// ReactionService provides methods for emoji reactions to posts, readings and comments using Ent
+type ReactionService struct {
+	client *ent.Client
+}
+
+// NewReactionService creates a new ReactionService
+func NewReactionService() *ReactionService {
+	return &ReactionService{
+		client: config.GetEntClient(),
+	}
+}
+
+// ToggleReaction reacts to a target with kind. Reacting again with the same
+// kind removes the reaction and another kind replaces it. It returns whether
+// the user now has a reaction of kind.
+func (s *ReactionService) ToggleReaction(ctx context.Context, userID uuid.UUID, target Target, kind reaction.Kind) (bool, error) {
+	if err := reaction.KindValidator(kind); err != nil {
+		return false, fmt.Errorf("invalid reaction kind: %w", err)
+	}
+	if err := checkTargetVisible(ctx, s.client, target, userID); err != nil {
+		return false, fmt.Errorf("failed to react: %w", err)
+	}
+
+	var reacted bool
+	err := withTx(ctx, s.client, func(tx *ent.Tx) error {
+		existing, err := tx.Reaction.
+			Query().
+			Where(reaction.UserID(userID), target.reactionPredicate()).
+			Only(ctx)
+		switch {
+		case ent.IsNotFound(err):
+			reacted = true
+			return tx.Reaction.
+				Create().
+				SetUserID(userID).
+				SetNillablePostID(target.PostID).
+				SetNillableReadingID(target.ReadingID).
+				SetNillableCommentID(target.CommentID).
+				SetKind(kind).
+				Exec(ctx)
+		case err != nil:
+			return err
+		case existing.Kind == kind:
+			return tx.Reaction.DeleteOne(existing).Exec(ctx)
+		default:
+			reacted = true
+			return tx.Reaction.UpdateOne(existing).SetKind(kind).Exec(ctx)
+		}
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to react: %w", err)
+	}
+
+	return reacted, nil
+}
+
+// GetReactionCounts counts the reactions to a target by kind. viewerID may be
+// nil for anonymous viewers.
+func (s *ReactionService) GetReactionCounts(ctx context.Context, target Target, viewerID *uuid.UUID) ([]*model.ReactionCount, error) {
+	var rows []struct {
+		Kind  reaction.Kind `json:"kind"`
+		Count int           `json:"count"`
+	}
+	err := s.client.Reaction.
+		Query().
+		Where(target.reactionPredicate()).
+		GroupBy(reaction.FieldKind).
+		Aggregate(ent.Count()).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	counts := make(map[reaction.Kind]int, len(rows))
+	for _, row := range rows {
+		counts[row.Kind] = row.Count
+	}
+
+	var viewerKind *reaction.Kind
+	if viewerID != nil {
+		own, err := s.client.Reaction.
+			Query().
+			Where(reaction.UserID(*viewerID), target.reactionPredicate()).
+			Only(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get reaction: %w", err)
+		}
+		if own != nil {
+			viewerKind = &own.Kind
+		}
+	}
+
+	return SummarizeReactions(counts, viewerKind), nil
+}
+
+// CountReactions returns how many users reacted to a target
+func (s *ReactionService) CountReactions(ctx context.Context, target Target) (int, error) {
+	count, err := s.client.Reaction.
+		Query().
+		Where(target.reactionPredicate()).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reactions: %w", err)
+	}
+	return count, nil
+}
+
+// SummarizeReactions turns reaction counts by kind into ReactionCounts,
+// most used kind first and ties in the order kinds are declared. Kinds
+// nobody used are left out.
+func SummarizeReactions(counts map[reaction.Kind]int, viewerKind *reaction.Kind) []*model.ReactionCount {
+	result := make([]*model.ReactionCount, 0, len(counts))
+	for _, kind := range reactionKinds {
+		if counts[kind] == 0 {
+			continue
+		}
+		result = append(result, &model.ReactionCount{
+			Kind:             kind,
+			Count:            counts[kind],
+			ViewerHasReacted: viewerKind != nil && *viewerKind == kind,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+	return result
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/readingprogress"
+	"LinganoGO/graph/model"
+
+	"github.com/google/uuid"
+)
+
+// ReadingProgressService provides methods for tracking how far users got in their readings
+type ReadingProgressService struct {
+	client  *ent.Client
+	courses *CourseService
+}
+
+// NewReadingProgressService creates a new ReadingProgressService
+func NewReadingProgressService() *ReadingProgressService {
+	return &ReadingProgressService{
+		client:  config.GetEntClient(),
+		courses: NewCourseService(),
+	}
+}
+
+// RecordProgress creates or updates the user's progress on a reading. Users can
+// track progress on their own readings and on any public reading. Finishing a
+// reading completes the enrollments in courses it was the last item left of.
+func (s *ReadingProgressService) RecordProgress(ctx context.Context, input model.ReadingProgressInput) (*ent.ReadingProgress, error) {
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	readingUUID, err := uuid.Parse(input.ReadingID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reading ID: %w", err)
+	}
+
+	reading, err := s.client.Reading.Get(ctx, readingUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if !canViewReading(reading, &userUUID) {
+		return nil, fmt.Errorf("failed to record reading progress: %w", ErrForbidden)
+	}
+
+	var progress *ent.ReadingProgress
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		existing, err := tx.ReadingProgress.
+			Query().
+			Where(
+				readingprogress.UserID(userUUID),
+				readingprogress.ReadingID(readingUUID),
+			).
+			Only(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			return fmt.Errorf("failed to get reading progress: %w", err)
+		}
+		if existing == nil {
+			existing, err = tx.ReadingProgress.
+				Create().
+				SetUserID(userUUID).
+				SetReadingID(readingUUID).
+				Save(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to create reading progress: %w", err)
+			}
+		}
+
+		now := time.Now()
+		update := existing.Update().SetLastOpenedAt(now)
+
+		unit := existing.PositionUnit
+		if input.PositionUnit != nil {
+			unit = *input.PositionUnit
+			update.SetPositionUnit(unit)
+		}
+		if input.Position != nil {
+			if *input.Position < 0 {
+				return fmt.Errorf("position must not be negative")
+			}
+			update.SetPosition(*input.Position)
+		}
+		if input.TimeSpentSeconds != nil {
+			if *input.TimeSpentSeconds < 0 {
+				return fmt.Errorf("time spent must not be negative")
+			}
+			update.AddTimeSpentSeconds(*input.TimeSpentSeconds)
+		}
+
+		switch {
+		case input.Percent != nil:
+			update.SetPercent(clampPercent(*input.Percent))
+		case input.Position != nil && unit == readingprogress.PositionUnitCHARACTER:
+			if length := utf8.RuneCountInString(reading.Body); length > 0 {
+				update.SetPercent(clampPercent(float64(*input.Position) / float64(length) * 100))
+			}
+		}
+
+		if input.Finished != nil {
+			if *input.Finished {
+				update.SetPercent(100)
+				if existing.FinishedAt == nil {
+					update.SetFinishedAt(now)
+				}
+			} else {
+				update.ClearFinishedAt()
+			}
+
+			// The owner's progress is mirrored on the reading itself so that
+			// Reading.finished keeps working for older clients.
+			if reading.UserID == userUUID {
+				if err := tx.Reading.UpdateOneID(readingUUID).SetFinished(*input.Finished).Exec(ctx); err != nil {
+					return fmt.Errorf("failed to update reading: %w", err)
+				}
+			}
+		}
+
+		progress, err = update.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update reading progress: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if progress.FinishedAt != nil {
+		if err := s.courses.CompleteCoursesWithReading(ctx, userUUID, readingUUID); err != nil {
+			return nil, err
+		}
+	}
+
+	return progress, nil
+}
+
+// GetProgress returns the user's progress on a reading, or nil if they never opened it
+func (s *ReadingProgressService) GetProgress(ctx context.Context, userID, readingID uuid.UUID) (*ent.ReadingProgress, error) {
+	progress, err := s.client.ReadingProgress.
+		Query().
+		Where(
+			readingprogress.UserID(userID),
+			readingprogress.ReadingID(readingID),
+		).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading progress: %w", err)
+	}
+
+	return progress, nil
+}
+
+// GetProgressByUser returns all progress records of a user, most recently opened first
+func (s *ReadingProgressService) GetProgressByUser(ctx context.Context, userID uuid.UUID) ([]*ent.ReadingProgress, error) {
+	progress, err := s.client.ReadingProgress.
+		Query().
+		Where(readingprogress.UserID(userID)).
+		Order(ent.Desc(readingprogress.FieldLastOpenedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading progress by user: %w", err)
+	}
+
+	return progress, nil
+}
+
+// clampPercent keeps a completion percentage within 0 and 100.
+func clampPercent(percent float64) float64 {
+	if percent < 0 {
+		return 0
+	}
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
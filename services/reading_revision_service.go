@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/reading"
+	"LinganoGO/ent/readingrevision"
+	"LinganoGO/graph/model"
+	"LinganoGO/textdiff"
+
+	"github.com/google/uuid"
+)
+
+// ReadingRevisionService provides methods for the revision history of readings using Ent
+type ReadingRevisionService struct {
+	client   *ent.Client
+	readings *ReadingService
+}
+
+// NewReadingRevisionService creates a new ReadingRevisionService
+func NewReadingRevisionService() *ReadingRevisionService {
+	return &ReadingRevisionService{
+		client:   config.GetEntClient(),
+		readings: NewReadingService(),
+	}
+}
+
+// GetRevisions returns the revisions of a reading owned by the user, newest first.
+// A reading that was never edited has no revisions.
+func (s *ReadingRevisionService) GetRevisions(ctx context.Context, readingID, userID uuid.UUID) ([]*ent.ReadingRevision, error) {
+	if _, err := s.ownedReading(ctx, readingID, userID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.client.ReadingRevision.
+		Query().
+		Where(readingrevision.ReadingID(readingID)).
+		Order(ent.Desc(readingrevision.FieldNumber)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// DiffRevisions compares two revisions of a reading owned by the user. The
+// title is compared word by word and the body with the given granularity.
+func (s *ReadingRevisionService) DiffRevisions(ctx context.Context, readingID, userID uuid.UUID, from, to int, granularity model.DiffGranularity) (*model.ReadingRevisionDiff, error) {
+	r, err := s.ownedReading(ctx, readingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.revision(ctx, readingID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.revision(ctx, readingID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []textdiff.Chunk
+	switch granularity {
+	case model.DiffGranularityLine:
+		body = textdiff.Lines(fromRevision.Body, toRevision.Body)
+	case model.DiffGranularityWord:
+		body = textdiff.Words(fromRevision.Body, toRevision.Body, r.Language)
+	default:
+		return nil, fmt.Errorf("unknown diff granularity %q", granularity)
+	}
+
+	return &model.ReadingRevisionDiff{
+		From:  fromRevision,
+		To:    toRevision,
+		Title: diffChunks(textdiff.Words(fromRevision.Title, toRevision.Title, r.Language)),
+		Body:  diffChunks(body),
+	}, nil
+}
+
+// RestoreRevision puts the content of one of its revisions back into a reading
+// owned by the user. The restored content becomes a new revision, so the
+// restore can itself be undone.
+func (s *ReadingRevisionService) RestoreRevision(ctx context.Context, readingID, userID uuid.UUID, number int) (*ent.Reading, error) {
+	existing, err := s.ownedReading(ctx, readingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.revision(ctx, readingID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	format := reading.Format(revision.Format)
+	input := model.UpdateReading{
+		Title:  &revision.Title,
+		Body:   &revision.Body,
+		Format: &format,
+	}
+	return s.readings.updateReading(ctx, existing, input, &revision.Number)
+}
+
+func (s *ReadingRevisionService) ownedReading(ctx context.Context, readingID, userID uuid.UUID) (*ent.Reading, error) {
+	r, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if r.UserID != userID {
+		return nil, fmt.Errorf("failed to access revisions: %w", ErrForbidden)
+	}
+	return r, nil
+}
+
+func (s *ReadingRevisionService) revision(ctx context.Context, readingID uuid.UUID, number int) (*ent.ReadingRevision, error) {
+	revision, err := s.client.ReadingRevision.
+		Query().
+		Where(
+			readingrevision.ReadingID(readingID),
+			readingrevision.Number(number),
+		).
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision %d: %w", number, err)
+	}
+	return revision, nil
+}
+
+// recordRevision adds a revision to the history of a reading whose content
+// (title, body or format) changed from before to after. The first time a
+// reading is edited, its original content is recorded as revision 1 so that
+// the edit can be undone.
+func recordRevision(ctx context.Context, tx *ent.Tx, before, after *ent.Reading, restoredFrom *int) error {
+	if before.Title == after.Title && before.Body == after.Body && before.Format == after.Format {
+		return nil
+	}
+
+	last, err := tx.ReadingRevision.
+		Query().
+		Where(readingrevision.ReadingID(after.ID)).
+		Order(ent.Desc(readingrevision.FieldNumber)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	number := 1
+	if last != nil {
+		number = last.Number + 1
+	} else {
+		err := tx.ReadingRevision.
+			Create().
+			SetReadingID(before.ID).
+			SetNumber(number).
+			SetTitle(before.Title).
+			SetBody(before.Body).
+			SetFormat(readingrevision.Format(before.Format)).
+			SetCreatedAt(before.UpdatedAt).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to record revision: %w", err)
+		}
+		number++
+	}
+
+	err = tx.ReadingRevision.
+		Create().
+		SetReadingID(after.ID).
+		SetNumber(number).
+		SetTitle(after.Title).
+		SetBody(after.Body).
+		SetFormat(readingrevision.Format(after.Format)).
+		SetNillableRestoredFrom(restoredFrom).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record revision: %w", err)
+	}
+	return nil
+}
+
+func diffChunks(chunks []textdiff.Chunk) []*model.DiffChunk {
+	kinds := map[textdiff.Kind]model.DiffKind{
+		textdiff.Equal:  model.DiffKindEqual,
+		textdiff.Insert: model.DiffKindInsert,
+		textdiff.Delete: model.DiffKindDelete,
+	}
+	result := make([]*model.DiffChunk, len(chunks))
+	for i, chunk := range chunks {
+		result[i] = &model.DiffChunk{Kind: kinds[chunk.Kind], Text: chunk.Text}
+	}
+	return result
+}
@@ -3,12 +3,19 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"LinganoGO/cefr"
 	"LinganoGO/config"
 	"LinganoGO/ent"
+	"LinganoGO/estimator"
 	"LinganoGO/ent/reading"
 	"LinganoGO/ent/user"
+	"LinganoGO/graph/model"
+	"LinganoGO/tokenizer"
 
+	"entgo.io/contrib/entgql"
+	"entgo.io/ent/dialect/sql"
 	"github.com/google/uuid"
 )
 
@@ -24,15 +31,18 @@ func NewReadingService() *ReadingService {
 	}
 }
 
-// CreateReading creates a new reading using Ent
+// CreateReading creates a new reading using Ent. It is not exposed to clients: a public
+// reading is published right away, without review.
 func (s *ReadingService) CreateReading(ctx context.Context, title string, userID uuid.UUID, public bool) (*ent.Reading, error) {
-	reading, err := s.client.Reading.
+	create := s.client.Reading.
 		Create().
 		SetTitle(title).
 		SetUserID(userID).
-		SetPublic(public).
-		SetFinished(false).
-		Save(ctx)
+		SetFinished(false)
+	if public {
+		setPublicationStatus(create.Mutation(), reading.PublicationStatusPUBLISHED)
+	}
+	reading, err := create.Save(ctx)
 	
 	if err != nil {
 		return nil, fmt.Errorf("failed to create reading: %w", err)
@@ -41,6 +51,74 @@ func (s *ReadingService) CreateReading(ctx context.Context, title string, userID
 	return reading, nil
 }
 
+// CreateReadingWithContent creates a new reading with its body and metadata using Ent
+func (s *ReadingService) CreateReadingWithContent(ctx context.Context, input model.NewReading) (*ent.Reading, error) {
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	create := s.client.Reading.
+		Create().
+		SetTitle(input.Title).
+		SetUserID(userUUID).
+		SetFinished(false)
+
+	if input.Public != nil && *input.Public {
+		body := ""
+		if input.Body != nil {
+			body = *input.Body
+		}
+		if err := requireContent(ctx, s.client, uuid.Nil, body); err != nil {
+			return nil, err
+		}
+		setPublicationStatus(create.Mutation(), reading.PublicationStatusSUBMITTED)
+	}
+	var language string
+	if input.Language != nil {
+		language = normalizeLanguage(*input.Language)
+		create.SetLanguage(language)
+	}
+	if input.Body != nil {
+		create.SetBody(*input.Body)
+		analyzeContent(create.Mutation(), *input.Body, language)
+	}
+	if input.Format != nil {
+		create.SetFormat(*input.Format)
+	}
+	if input.Level != nil {
+		create.SetLevel(*input.Level)
+	}
+	if input.SourceURL != nil {
+		create.SetSourceURL(*input.SourceURL)
+	}
+	if input.Author != nil {
+		create.SetAuthor(*input.Author)
+	}
+
+	reading, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// GetReadingForUser retrieves a reading by ID if it is public or owned by the given user.
+// A nil userID means an anonymous viewer, who can only see public readings.
+func (s *ReadingService) GetReadingForUser(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*ent.Reading, error) {
+	reading, err := s.client.Reading.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+
+	if !canViewReading(reading, userID) {
+		return nil, fmt.Errorf("failed to get reading: %w", ErrForbidden)
+	}
+
+	return reading, nil
+}
+
 // GetReadingByID retrieves a reading by ID using Ent
 func (s *ReadingService) GetReadingByID(ctx context.Context, id uuid.UUID) (*ent.Reading, error) {
 	reading, err := s.client.Reading.
@@ -82,6 +160,51 @@ func (s *ReadingService) GetPublicReadings(ctx context.Context) ([]*ent.Reading,
 	return readings, nil
 }
 
+// GetPublicReadingsFiltered retrieves public readings matching the filter, optionally ordered by level.
+// A reading's level is the one set by its author, or the estimated level when the author did not set one.
+func (s *ReadingService) GetPublicReadingsFiltered(ctx context.Context, filter *model.ReadingFilter, levelOrder *entgql.OrderDirection) ([]*ent.Reading, error) {
+	query := s.client.Reading.
+		Query().
+		Where(reading.PublicEQ(true))
+
+	if filter != nil {
+		if filter.Language != nil {
+			query.Where(reading.Language(normalizeLanguage(*filter.Language)))
+		}
+		if filter.MinLevel != nil || filter.MaxLevel != nil {
+			levels := levelRange(filter.MinLevel, filter.MaxLevel)
+			query.Where(reading.Or(
+				reading.LevelIn(levels...),
+				reading.And(
+					reading.LevelIsNil(),
+					reading.EstimatedLevelIn(levels...),
+				),
+			))
+		}
+	}
+
+	if levelOrder != nil {
+		direction := "ASC"
+		if *levelOrder == entgql.OrderDirectionDesc {
+			direction = "DESC"
+		}
+		query.Order(func(s *sql.Selector) {
+			s.OrderExpr(sql.Expr(fmt.Sprintf(
+				"COALESCE(%s, %s) %s NULLS LAST, %s %s NULLS LAST",
+				s.C(reading.FieldLevel), s.C(reading.FieldEstimatedLevel), direction,
+				s.C(reading.FieldDifficultyScore), direction,
+			)))
+		})
+	}
+
+	readings, err := query.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public readings: %w", err)
+	}
+
+	return readings, nil
+}
+
 // GetReadingsByUser retrieves all readings for a specific user using Ent
 func (s *ReadingService) GetReadingsByUser(ctx context.Context, userID uuid.UUID) ([]*ent.Reading, error) {
 	readings, err := s.client.Reading.
@@ -96,19 +219,101 @@ func (s *ReadingService) GetReadingsByUser(ctx context.Context, userID uuid.UUID
 	return readings, nil
 }
 
-// UpdateReading updates a reading using Ent
-func (s *ReadingService) UpdateReading(ctx context.Context, id uuid.UUID, title string, finished, public bool) (*ent.Reading, error) {
-	reading, err := s.client.Reading.
-		UpdateOneID(id).
-		SetTitle(title).
-		SetFinished(finished).
-		SetPublic(public).
-		Save(ctx)
-	
+// UpdateReading updates the fields set in the input on a reading owned by the given user
+func (s *ReadingService) UpdateReading(ctx context.Context, id, userID uuid.UUID, input model.UpdateReading) (*ent.Reading, error) {
+	existing, err := s.client.Reading.Get(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update reading: %w", err)
+		return nil, fmt.Errorf("failed to get reading: %w", err)
 	}
-	
+	if existing.UserID != userID {
+		return nil, fmt.Errorf("failed to update reading: %w", ErrForbidden)
+	}
+
+	return s.updateReading(ctx, existing, input, nil)
+}
+
+// updateReading applies changes to a reading and keeps what depends on its
+// text in step. A change of content is recorded as a new revision;
+// restoredFrom is the number of the revision being restored, if any.
+func (s *ReadingService) updateReading(ctx context.Context, existing *ent.Reading, input model.UpdateReading, restoredFrom *int) (*ent.Reading, error) {
+	id := existing.ID
+
+	var reading *ent.Reading
+	err := withTx(ctx, s.client, func(tx *ent.Tx) error {
+		update := tx.Reading.UpdateOneID(id)
+
+		if input.Title != nil {
+			update.SetTitle(*input.Title)
+		}
+		language := existing.Language
+		if input.Language != nil {
+			language = normalizeLanguage(*input.Language)
+			update.SetLanguage(language)
+		}
+		body := existing.Body
+		if input.Body != nil {
+			body = *input.Body
+			update.SetBody(body)
+		}
+		if input.Body != nil || input.Language != nil {
+			analyzeContent(update.Mutation(), body, language)
+		}
+		if input.Format != nil {
+			update.SetFormat(*input.Format)
+		}
+		if input.Level != nil {
+			update.SetLevel(*input.Level)
+		}
+		if input.SourceURL != nil {
+			update.SetSourceURL(*input.SourceURL)
+		}
+		if input.Author != nil {
+			update.SetAuthor(*input.Author)
+		}
+		if input.Finished != nil {
+			update.SetFinished(*input.Finished)
+		}
+		contentChanged := (input.Title != nil && *input.Title != existing.Title) || body != existing.Body ||
+			(input.Format != nil && *input.Format != existing.Format)
+		if status := PublicationStatusAfterUpdate(existing.PublicationStatus, input.Public, contentChanged); status != existing.PublicationStatus {
+			if !CanChangePublicationStatus(existing.PublicationStatus, status) {
+				return fmt.Errorf("cannot move a %s reading to %s", existing.PublicationStatus, status)
+			}
+			if err := changePublicationStatus(ctx, tx.Client(), update, body, status); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		reading, err = update.Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update reading: %w", err)
+		}
+		if err := recordRevision(ctx, tx, existing, reading, restoredFrom); err != nil {
+			return err
+		}
+
+		// Keep highlights and audio cues on the passages they marked and
+		// sentences, with their translations, in step with the text.
+		if body != existing.Body {
+			if err := reanchorHighlights(ctx, tx, id, body); err != nil {
+				return err
+			}
+			if err := reanchorAudioCues(ctx, tx, id, body); err != nil {
+				return err
+			}
+		}
+		if body != existing.Body || language != existing.Language {
+			if _, err := segmentSentences(ctx, tx, reading); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return reading, nil
 }
 
@@ -205,3 +410,97 @@ func (s *ReadingService) GetFinishedReadings(ctx context.Context) ([]*ent.Readin
 	
 	return readings, nil
 }
+
+// backfillBatchSize is the number of rows a backfill step loads at a time.
+const backfillBatchSize = 100
+
+// BackfillTokenCounts computes the word and token counts of readings stored
+// before they were precomputed and returns how many it updated. Their
+// update time and difficulty estimate are left as they are.
+func (s *ReadingService) BackfillTokenCounts(ctx context.Context) (int, error) {
+	updated := 0
+	for {
+		missing, err := s.client.Reading.
+			Query().
+			Where(reading.TokenCountsIsNil()).
+			Order(ent.Asc(reading.FieldID)).
+			Limit(backfillBatchSize).
+			Select(reading.FieldID, reading.FieldBody, reading.FieldLanguage, reading.FieldUpdatedAt).
+			All(ctx)
+		if err != nil {
+			return updated, fmt.Errorf("failed to get readings without token counts: %w", err)
+		}
+		if len(missing) == 0 {
+			return updated, nil
+		}
+
+		for _, r := range missing {
+			counts, wordCount := countTokens(r.Body, r.Language)
+			err := s.client.Reading.
+				UpdateOneID(r.ID).
+				SetTokenCounts(counts).
+				SetWordCount(wordCount).
+				SetUpdatedAt(r.UpdatedAt).
+				Exec(ctx)
+			if err != nil {
+				return updated, fmt.Errorf("failed to store token counts: %w", err)
+			}
+			updated++
+		}
+	}
+}
+
+// analyzeContent sets the fields derived from a reading's body: its word count,
+// the number of occurrences of each normalized word and its estimated difficulty.
+func analyzeContent(m *ent.ReadingMutation, body, language string) {
+	counts, wordCount := countTokens(body, language)
+	m.SetWordCount(wordCount)
+	m.SetTokenCounts(counts)
+
+	if estimate, ok := estimator.EstimateText(body, language); ok {
+		m.SetEstimatedLevel(estimate.Level)
+		m.SetDifficultyScore(estimate.Score)
+	} else if m.Op().Is(ent.OpUpdateOne) {
+		m.ClearEstimatedLevel()
+		m.ClearDifficultyScore()
+	}
+}
+
+// countTokens counts the running words of a body by normalized form and
+// returns the counts with the number of running words.
+func countTokens(body, language string) (map[string]int, int) {
+	words := tokenizer.Words(body, language)
+	counts := make(map[string]int)
+	for _, w := range words {
+		counts[w.Normalized]++
+	}
+	return counts, len(words)
+}
+
+// canViewReading reports whether a reading is public or owned by the given user.
+// A nil userID means an anonymous viewer.
+func canViewReading(reading *ent.Reading, userID *uuid.UUID) bool {
+	return reading.Public || (userID != nil && reading.UserID == *userID)
+}
+
+// normalizeLanguage turns a language code such as "EN" or " pt-BR " into its
+// canonical lowercase form.
+func normalizeLanguage(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// levelRange returns the CEFR levels between min and max, both inclusive.
+// A nil bound leaves that side of the range open.
+func levelRange(min, max *cefr.Level) []cefr.Level {
+	var levels []cefr.Level
+	for _, level := range cefr.All {
+		if min != nil && level.Rank() < min.Rank() {
+			continue
+		}
+		if max != nil && level.Rank() > max.Rank() {
+			continue
+		}
+		levels = append(levels, level)
+	}
+	return levels
+}
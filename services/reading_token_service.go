@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/graph/model"
+	"LinganoGO/tokenizer"
+	"LinganoGO/translit"
+
+	"github.com/google/uuid"
+)
+
+// ReadingTokenService splits readings into tokens annotated with the viewer's knowledge of each word
+type ReadingTokenService struct {
+	client     *ent.Client
+	vocabulary *VocabularyService
+}
+
+// NewReadingTokenService creates a new ReadingTokenService
+func NewReadingTokenService() *ReadingTokenService {
+	return &ReadingTokenService{
+		client:     config.GetEntClient(),
+		vocabulary: NewVocabularyService(),
+	}
+}
+
+// GetReadingTokens tokenizes a reading the user can see and annotates every word with its status
+// for that user. The known percentage is computed over running words, ignoring words the user
+// marked as ignored (names, numbers spelled out, ...). Words in a non-Latin script also get
+// their reading when the user turned a reading aid on.
+func (s *ReadingTokenService) GetReadingTokens(ctx context.Context, readingID, userID uuid.UUID) (*model.ReadingTokens, error) {
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if !canViewReading(reading, &userID) {
+		return nil, fmt.Errorf("failed to get reading tokens: %w", ErrForbidden)
+	}
+
+	statuses, err := s.vocabulary.GetWordStatuses(ctx, userID, reading.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	viewer, err := s.client.User.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	var readings *tokenReadings
+	switch ReadingAidOf(viewer) {
+	case model.ReadingAidKana:
+		readings = newTokenReadings(reading.Body, reading.Language, translit.Kana)
+	case model.ReadingAidLatin:
+		readings = newTokenReadings(reading.Body, reading.Language, translit.Latin)
+	}
+
+	result := &model.ReadingTokens{
+		ReadingID: reading.ID.String(),
+		Tokens:    []*model.ReadingToken{},
+	}
+	if reading.Language != "" {
+		result.Language = &reading.Language
+	}
+	unique := make(map[string]bool)
+
+	for _, t := range tokenizer.Tokenize(reading.Body, reading.Language) {
+		token := &model.ReadingToken{
+			Text:   t.Text,
+			Start:  t.Start,
+			End:    t.End,
+			IsWord: t.IsWord,
+		}
+		if t.IsWord {
+			status, ok := statuses[t.Normalized]
+			if !ok {
+				status = model.WordStatusNew
+			}
+			token.Normalized = &t.Normalized
+			token.Status = &status
+			if readings != nil {
+				token.Reading = readings.of(t)
+			}
+
+			result.WordCount++
+			unique[t.Normalized] = true
+			switch status {
+			case model.WordStatusKnown:
+				result.KnownCount++
+			case model.WordStatusLearning:
+				result.LearningCount++
+			case model.WordStatusIgnored:
+				result.IgnoredCount++
+			default:
+				result.NewCount++
+			}
+		}
+		result.Tokens = append(result.Tokens, token)
+	}
+
+	result.UniqueWordCount = len(unique)
+	if counted := result.WordCount - result.IgnoredCount; counted > 0 {
+		result.KnownPercentage = float64(result.KnownCount) / float64(counted) * 100
+	}
+
+	return result, nil
+}
+
+// tokenReadings hands out the readings of a text token by token. Tokens must
+// be asked for in order.
+type tokenReadings struct {
+	runes []rune
+	spans []translit.Span
+	next  int
+}
+
+func newTokenReadings(text, language string, style translit.Style) *tokenReadings {
+	return &tokenReadings{
+		runes: []rune(text),
+		spans: translit.Annotate(text, language, style),
+	}
+}
+
+// of returns the reading of a token, the parts of it that need no reading
+// aid (a hyphen, Japanese okurigana) being kept as written. It returns nil
+// when no reading starts inside the token.
+func (r *tokenReadings) of(t tokenizer.Token) *string {
+	for r.next < len(r.spans) && r.spans[r.next].Start < t.Start {
+		r.next++
+	}
+	if r.next == len(r.spans) || r.spans[r.next].Start >= t.End {
+		return nil
+	}
+
+	var b strings.Builder
+	for i := t.Start; i < t.End; {
+		if r.next < len(r.spans) && r.spans[r.next].Start == i {
+			b.WriteString(r.spans[r.next].Reading)
+			i = r.spans[r.next].End
+			r.next++
+			continue
+		}
+		b.WriteRune(r.runes[i])
+		i++
+	}
+	reading := b.String()
+	return &reading
+}
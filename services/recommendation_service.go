@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"LinganoGO/cefr"
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/reading"
+	"LinganoGO/ent/readingprogress"
+	"LinganoGO/estimator"
+	"LinganoGO/graph/model"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultTargetCoverage is the share of known running words, in percent,
+	// that makes a text comfortable to read while still teaching new words.
+	DefaultTargetCoverage  = 95.0
+	defaultRecommendations = 10
+	maxRecommendations     = 50
+	// maxCandidates bounds the readings whose coverage is computed per request.
+	maxCandidates = 500
+)
+
+// vocabularySizes are the known-word counts a learner needs to reach each
+// level but the first, from A2 to C2.
+var vocabularySizes = []struct {
+	level cefr.Level
+	words int
+}{
+	{cefr.A2, 500},
+	{cefr.B1, 1000},
+	{cefr.B2, 2000},
+	{cefr.C1, 4000},
+	{cefr.C2, 8000},
+}
+
+// RecommendationService suggests readings that match what a learner already knows
+type RecommendationService struct {
+	client     *ent.Client
+	vocabulary *VocabularyService
+}
+
+// NewRecommendationService creates a new RecommendationService
+func NewRecommendationService() *RecommendationService {
+	return &RecommendationService{
+		client:     config.GetEntClient(),
+		vocabulary: NewVocabularyService(),
+	}
+}
+
+// RecommendReadings ranks the public readings in a language by how close the
+// user's known-word coverage is to targetCoverage (in percent), then by how
+// few new words they introduce. Coverage is computed like in readingTokens:
+// known running words over all running words except ignored ones. Readings
+// the user owns or finished are left out, as are books, whose chapters are
+// recommended instead. Only the maxCandidates readings
+// whose difficulty is closest to the level of the user's vocabulary are
+// ranked, using their precomputed token counts, so the bodies are never
+// tokenized here.
+func (s *RecommendationService) RecommendReadings(ctx context.Context, userID uuid.UUID, language string, targetCoverage *float64, limit *int) ([]*model.ReadingRecommendation, error) {
+	language = normalizeLanguage(language)
+	target := DefaultTargetCoverage
+	if targetCoverage != nil {
+		target = math.Max(0, math.Min(100, *targetCoverage))
+	}
+	n := defaultRecommendations
+	if limit != nil && *limit > 0 {
+		n = min(*limit, maxRecommendations)
+	}
+
+	statuses, err := s.vocabulary.GetWordStatuses(ctx, userID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	expected := estimator.ScoreForLevel(LevelForVocabularySize(countKnown(statuses)))
+	candidates, err := s.client.Reading.
+		Query().
+		Where(
+			reading.PublicEQ(true),
+			reading.Language(language),
+			reading.UserIDNEQ(userID),
+			reading.WordCountGT(0),
+			reading.TokenCountsNotNil(),
+			reading.Not(reading.HasChapters()),
+			reading.Not(reading.HasProgressWith(
+				readingprogress.UserID(userID),
+				readingprogress.FinishedAtNotNil(),
+			)),
+		).
+		// Only the readings closest to the learner's level are scored.
+		Order(func(s *sql.Selector) {
+			// Arg numbers the placeholder for the dialect; a raw ? is
+			// not valid in PostgreSQL.
+			s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+				b.WriteString("ABS(").
+					Ident(s.C(reading.FieldDifficultyScore)).
+					WriteString(" - ").
+					Arg(expected).
+					WriteString(") NULLS LAST")
+			}))
+		}, ent.Asc(reading.FieldID)).
+		Limit(maxCandidates).
+		Select(reading.FieldID, reading.FieldTokenCounts).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candidate readings: %w", err)
+	}
+
+	recommendations := make([]*model.ReadingRecommendation, 0, len(candidates))
+	ids := make(map[*model.ReadingRecommendation]uuid.UUID, len(candidates))
+	for _, c := range candidates {
+		rec := scoreCoverage(c.TokenCounts, statuses)
+		if rec.UniqueWordCount == 0 {
+			continue
+		}
+		recommendations = append(recommendations, rec)
+		ids[rec] = c.ID
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		// Differences under one percentage point don't matter to a reader.
+		da := math.Round(math.Abs(a.Coverage - target))
+		db := math.Round(math.Abs(b.Coverage - target))
+		if da != db {
+			return da < db
+		}
+		return a.NewWordCount < b.NewWordCount
+	})
+	if len(recommendations) > n {
+		recommendations = recommendations[:n]
+	}
+
+	selected := make([]uuid.UUID, len(recommendations))
+	for i, rec := range recommendations {
+		selected[i] = ids[rec]
+	}
+	readings, err := s.client.Reading.
+		Query().
+		Where(reading.IDIn(selected...)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommended readings: %w", err)
+	}
+	byID := make(map[uuid.UUID]*ent.Reading, len(readings))
+	for _, r := range readings {
+		byID[r.ID] = r
+	}
+	for _, rec := range recommendations {
+		rec.Reading = byID[ids[rec]]
+	}
+
+	return recommendations, nil
+}
+
+// LevelForVocabularySize maps the number of words a learner knows to the
+// CEFR level whose texts they can usually read.
+func LevelForVocabularySize(known int) cefr.Level {
+	level := cefr.A1
+	for _, v := range vocabularySizes {
+		if known < v.words {
+			break
+		}
+		level = v.level
+	}
+	return level
+}
+
+// countKnown counts the known words among word statuses.
+func countKnown(statuses map[string]model.WordStatus) int {
+	known := 0
+	for _, status := range statuses {
+		if status == model.WordStatusKnown {
+			known++
+		}
+	}
+	return known
+}
+
+// scoreCoverage computes a reader's coverage of a text from its token counts.
+func scoreCoverage(counts map[string]int, statuses map[string]model.WordStatus) *model.ReadingRecommendation {
+	rec := &model.ReadingRecommendation{UniqueWordCount: len(counts)}
+
+	var total, known int
+	for word, count := range counts {
+		switch statuses[word] {
+		case model.WordStatusIgnored:
+			continue
+		case model.WordStatusKnown:
+			known += count
+		case model.WordStatusLearning:
+			rec.LearningWordCount++
+		default:
+			rec.NewWordCount++
+		}
+		total += count
+	}
+	if total > 0 {
+		rec.Coverage = float64(known) / float64(total) * 100
+	} else {
+		rec.Coverage = 100
+	}
+
+	return rec
+}
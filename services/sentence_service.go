@@ -0,0 +1,553 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/readingsentence"
+	"LinganoGO/ent/sentencetranslation"
+	"LinganoGO/graph/model"
+	"LinganoGO/importer"
+	"LinganoGO/tokenizer"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+// maxAlignmentSize is the largest aligned translation file accepted.
+const maxAlignmentSize = 10 << 20
+
+// SentenceService provides methods for reading sentences and their translations using Ent
+type SentenceService struct {
+	client     *ent.Client
+	translator Translator
+	limiter    *RateLimiter
+}
+
+// NewSentenceService creates a new SentenceService
+func NewSentenceService() *SentenceService {
+	client := config.GetEntClient()
+	return &SentenceService{
+		client:     client,
+		translator: NewTranslator(client),
+		limiter:    translateLimiter(),
+	}
+}
+
+// GetSentences returns the sentences of a reading the user can view, in order.
+// Readings saved before sentences were tracked are segmented on first use.
+func (s *SentenceService) GetSentences(ctx context.Context, readingID uuid.UUID, userID *uuid.UUID) ([]*ent.ReadingSentence, error) {
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	if !canViewReading(reading, userID) {
+		return nil, fmt.Errorf("failed to get sentences: %w", ErrForbidden)
+	}
+
+	sentences, err := s.client.ReadingSentence.
+		Query().
+		Where(readingsentence.ReadingID(readingID)).
+		Order(ent.Asc(readingsentence.FieldPosition)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sentences: %w", err)
+	}
+	if len(sentences) > 0 || strings.TrimSpace(reading.Body) == "" {
+		return sentences, nil
+	}
+
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		// Concurrent first views wait for each other here, and only the first
+		// one segments the reading.
+		if err := lockReading(ctx, tx, readingID); err != nil {
+			return err
+		}
+		var err error
+		sentences, err = tx.ReadingSentence.
+			Query().
+			Where(readingsentence.ReadingID(readingID)).
+			Order(ent.Asc(readingsentence.FieldPosition)).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get sentences: %w", err)
+		}
+		if len(sentences) > 0 {
+			return nil
+		}
+		sentences, err = segmentSentences(ctx, tx, reading)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sentences, nil
+}
+
+// GetAlignedSentences returns every sentence of a reading paired with its
+// translation into language. The viewer's own translation is preferred, then
+// the reading owner's, then a machine translation.
+func (s *SentenceService) GetAlignedSentences(ctx context.Context, readingID, userID uuid.UUID, language string) ([]*model.SentencePair, error) {
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	sentences, err := s.GetSentences(ctx, readingID, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.client.SentenceTranslation.
+		Query().
+		Where(
+			sentencetranslation.HasSentenceWith(readingsentence.ReadingID(readingID)),
+			sentencetranslation.Language(normalizeLanguage(language)),
+			sentencetranslation.Or(
+				sentencetranslation.UserIDIn(userID, reading.UserID),
+				sentencetranslation.SourceEQ(sentencetranslation.SourcePROVIDER),
+			),
+		).
+		Order(ent.Desc(sentencetranslation.FieldUpdatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translations: %w", err)
+	}
+
+	rank := func(t *ent.SentenceTranslation) int {
+		switch {
+		case t.UserID != nil && *t.UserID == userID:
+			return 0
+		case t.UserID != nil && *t.UserID == reading.UserID:
+			return 1
+		default:
+			return 2
+		}
+	}
+	best := make(map[uuid.UUID]*ent.SentenceTranslation)
+	for _, t := range translations {
+		if current, ok := best[t.SentenceID]; !ok || rank(t) < rank(current) {
+			best[t.SentenceID] = t
+		}
+	}
+
+	pairs := make([]*model.SentencePair, len(sentences))
+	for i, sentence := range sentences {
+		pairs[i] = &model.SentencePair{
+			Sentence:    sentence,
+			Translation: best[sentence.ID],
+		}
+	}
+
+	return pairs, nil
+}
+
+// GetTranslations returns the translations of a sentence, optionally only
+// those into one language
+func (s *SentenceService) GetTranslations(ctx context.Context, sentenceID uuid.UUID, language *string) ([]*ent.SentenceTranslation, error) {
+	query := s.client.SentenceTranslation.
+		Query().
+		Where(sentencetranslation.SentenceID(sentenceID))
+	if language != nil {
+		query.Where(sentencetranslation.Language(normalizeLanguage(*language)))
+	}
+
+	translations, err := query.
+		Order(ent.Asc(sentencetranslation.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translations: %w", err)
+	}
+
+	return translations, nil
+}
+
+// TranslateSentence stores the user's own translation of a sentence, replacing
+// the one they entered before for the same language.
+func (s *SentenceService) TranslateSentence(ctx context.Context, sentenceID, userID uuid.UUID, language, text string) (*ent.SentenceTranslation, error) {
+	language = normalizeLanguage(language)
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("translation must not be empty")
+	}
+
+	sentence, err := s.client.ReadingSentence.
+		Query().
+		Where(readingsentence.ID(sentenceID)).
+		WithReading().
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sentence: %w", err)
+	}
+	if !canViewReading(sentence.Edges.Reading, &userID) {
+		return nil, fmt.Errorf("failed to translate sentence: %w", ErrForbidden)
+	}
+
+	existing, err := s.client.SentenceTranslation.
+		Query().
+		Where(
+			sentencetranslation.SentenceID(sentenceID),
+			sentencetranslation.UserID(userID),
+			sentencetranslation.Language(language),
+			sentencetranslation.SourceEQ(sentencetranslation.SourceUSER),
+		).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get translation: %w", err)
+	}
+
+	var translation *ent.SentenceTranslation
+	if existing != nil {
+		translation, err = existing.Update().SetText(text).Save(ctx)
+	} else {
+		translation, err = s.client.SentenceTranslation.
+			Create().
+			SetSentenceID(sentenceID).
+			SetUserID(userID).
+			SetLanguage(language).
+			SetText(text).
+			SetSource(sentencetranslation.SourceUSER).
+			Save(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save translation: %w", err)
+	}
+
+	return translation, nil
+}
+
+// ImportTranslations reads an aligned TMX or two-column TSV file and attaches
+// its translations into language to the matching sentences of a reading. A
+// segment may span several consecutive sentences, in which case the
+// translation goes to the first one. Importing again replaces the user's
+// previously imported translations for that language. It returns the number
+// of sentences that received a translation.
+func (s *SentenceService) ImportTranslations(ctx context.Context, readingID, userID uuid.UUID, language string, file graphql.Upload, format *model.AlignmentFormat) (int, error) {
+	language = normalizeLanguage(language)
+
+	data, err := io.ReadAll(io.LimitReader(file.File, maxAlignmentSize+1))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	if len(data) > maxAlignmentSize {
+		return 0, fmt.Errorf("failed to import translations: %w", ErrFileTooLarge)
+	}
+
+	sentences, err := s.GetSentences(ctx, readingID, &userID)
+	if err != nil {
+		return 0, err
+	}
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reading: %w", err)
+	}
+
+	var pairs []importer.SegmentPair
+	if alignmentFormat(file.Filename, data, format) == model.AlignmentFormatTmx {
+		pairs, err = importer.ParseTMX(data, reading.Language, language)
+	} else {
+		pairs, err = importer.ParseTSV(data)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to import translations: %w", err)
+	}
+
+	matches := alignSegments(sentences, pairs)
+
+	err = withTx(ctx, s.client, func(tx *ent.Tx) error {
+		_, err := tx.SentenceTranslation.
+			Delete().
+			Where(
+				sentencetranslation.HasSentenceWith(readingsentence.ReadingID(readingID)),
+				sentencetranslation.UserID(userID),
+				sentencetranslation.Language(language),
+				sentencetranslation.SourceEQ(sentencetranslation.SourceIMPORT),
+			).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to replace imported translations: %w", err)
+		}
+
+		creates := make([]*ent.SentenceTranslationCreate, 0, len(matches))
+		for sentenceID, text := range matches {
+			creates = append(creates, tx.SentenceTranslation.
+				Create().
+				SetSentenceID(sentenceID).
+				SetUserID(userID).
+				SetLanguage(language).
+				SetText(text).
+				SetSource(sentencetranslation.SourceIMPORT))
+		}
+		if err := tx.SentenceTranslation.CreateBulk(creates...).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to import translations: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(matches), nil
+}
+
+// MachineTranslateReading fills in machine translations into language for the
+// sentences of a reading that have none from the provider yet, and returns the
+// aligned sentences. Sentences go to the provider in chunks of at most
+// MaxTranslateLength characters, each counting as one request against the
+// user's translation rate limit. Chunks translated before the limit is reached
+// are kept, so translating again later picks up where it stopped. Providers
+// whose translations depend on the user can't be used, since the results are
+// shared with every viewer of the reading.
+func (s *SentenceService) MachineTranslateReading(ctx context.Context, readingID, userID uuid.UUID, language string) ([]*model.SentencePair, error) {
+	if s.translator == nil {
+		return nil, ErrNoTranslator
+	}
+	if _, ok := s.translator.(personalTranslator); ok {
+		return nil, ErrPersonalTranslator
+	}
+	language = normalizeLanguage(language)
+
+	if err := checkUserExists(ctx, s.client, userID); err != nil {
+		return nil, err
+	}
+	reading, err := s.client.Reading.Get(ctx, readingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading: %w", err)
+	}
+	sentences, err := s.GetSentences(ctx, readingID, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	translated, err := s.client.SentenceTranslation.
+		Query().
+		Where(
+			sentencetranslation.HasSentenceWith(readingsentence.ReadingID(readingID)),
+			sentencetranslation.Language(language),
+			sentencetranslation.SourceEQ(sentencetranslation.SourcePROVIDER),
+		).
+		Select(sentencetranslation.FieldSentenceID).
+		Strings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get translations: %w", err)
+	}
+	done := make(map[string]bool, len(translated))
+	for _, id := range translated {
+		done[id] = true
+	}
+
+	var missing []*ent.ReadingSentence
+	for _, sentence := range sentences {
+		if !done[sentence.ID.String()] {
+			missing = append(missing, sentence)
+		}
+	}
+
+	for _, chunk := range translationChunks(missing) {
+		if !s.limiter.Allow(userID.String()) {
+			return nil, ErrRateLimited
+		}
+		if err := s.translateChunk(ctx, chunk, reading.Language, language); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetAlignedSentences(ctx, readingID, userID, language)
+}
+
+// translateChunk machine translates sentences and stores the results.
+func (s *SentenceService) translateChunk(ctx context.Context, sentences []*ent.ReadingSentence, from, to string) error {
+	texts := make([]string, len(sentences))
+	for i, sentence := range sentences {
+		texts[i] = sentence.Text
+	}
+
+	results, err := s.translator.Translate(ctx, texts, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to translate reading: %w", err)
+	}
+	if len(results) != len(sentences) {
+		return fmt.Errorf("translation provider returned %d translations for %d sentences", len(results), len(sentences))
+	}
+
+	creates := make([]*ent.SentenceTranslationCreate, len(sentences))
+	for i, sentence := range sentences {
+		creates[i] = s.client.SentenceTranslation.
+			Create().
+			SetSentenceID(sentence.ID).
+			SetLanguage(to).
+			SetText(results[i]).
+			SetSource(sentencetranslation.SourcePROVIDER).
+			SetProvider(s.translator.Name())
+	}
+	if err := s.client.SentenceTranslation.CreateBulk(creates...).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save translations: %w", err)
+	}
+	return nil
+}
+
+// translationChunks groups consecutive sentences into chunks of at most
+// MaxTranslateLength characters. A longer sentence makes a chunk of its own.
+func translationChunks(sentences []*ent.ReadingSentence) [][]*ent.ReadingSentence {
+	var (
+		chunks [][]*ent.ReadingSentence
+		chunk  []*ent.ReadingSentence
+		length int
+	)
+	for _, sentence := range sentences {
+		n := utf8.RuneCountInString(sentence.Text)
+		if len(chunk) > 0 && length+n > MaxTranslateLength {
+			chunks = append(chunks, chunk)
+			chunk, length = nil, 0
+		}
+		chunk = append(chunk, sentence)
+		length += n
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// segmentSentences splits a reading's body into sentences and stores them,
+// replacing the previous ones. Sentences whose text didn't change keep their
+// identity, and so their translations, even if they moved.
+func segmentSentences(ctx context.Context, tx *ent.Tx, reading *ent.Reading) ([]*ent.ReadingSentence, error) {
+	existing, err := tx.ReadingSentence.
+		Query().
+		Where(readingsentence.ReadingID(reading.ID)).
+		Order(ent.Asc(readingsentence.FieldPosition)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sentences: %w", err)
+	}
+	byText := make(map[string][]*ent.ReadingSentence)
+	for _, sentence := range existing {
+		byText[sentence.Text] = append(byText[sentence.Text], sentence)
+	}
+
+	spans := tokenizer.Sentences(reading.Body, reading.Language)
+	sentences := make([]*ent.ReadingSentence, len(spans))
+	kept := make(map[uuid.UUID]bool)
+	var creates []*ent.ReadingSentenceCreate
+	for i, span := range spans {
+		if candidates := byText[span.Text]; len(candidates) > 0 {
+			sentence := candidates[0]
+			byText[span.Text] = candidates[1:]
+			kept[sentence.ID] = true
+			if sentence.Position != i || sentence.Start != span.Start || sentence.End != span.End {
+				sentence, err = sentence.Update().
+					SetPosition(i).
+					SetStart(span.Start).
+					SetEnd(span.End).
+					Save(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to update sentence: %w", err)
+				}
+			}
+			sentences[i] = sentence
+			continue
+		}
+		creates = append(creates, tx.ReadingSentence.
+			Create().
+			SetReadingID(reading.ID).
+			SetPosition(i).
+			SetStart(span.Start).
+			SetEnd(span.End).
+			SetText(span.Text))
+	}
+
+	var removed []uuid.UUID
+	for _, sentence := range existing {
+		if !kept[sentence.ID] {
+			removed = append(removed, sentence.ID)
+		}
+	}
+	if len(removed) > 0 {
+		if _, err := tx.ReadingSentence.Delete().Where(readingsentence.IDIn(removed...)).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("failed to delete sentences: %w", err)
+		}
+	}
+
+	created, err := tx.ReadingSentence.CreateBulk(creates...).Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sentences: %w", err)
+	}
+	for i, j := 0, 0; i < len(sentences); i++ {
+		if sentences[i] == nil {
+			sentences[i] = created[j]
+			j++
+		}
+	}
+
+	return sentences, nil
+}
+
+// alignSegments matches aligned segments to sentences by their text, in
+// order, and returns the translation for each matched sentence.
+func alignSegments(sentences []*ent.ReadingSentence, pairs []importer.SegmentPair) map[uuid.UUID]string {
+	const maxSpan = 3
+
+	keys := make([]string, len(sentences))
+	for i, sentence := range sentences {
+		keys[i] = alignmentKey(sentence.Text)
+	}
+
+	matches := make(map[uuid.UUID]string)
+	next := 0
+	for _, pair := range pairs {
+		key := alignmentKey(pair.Source)
+		// Search forward from the last match first, then from the start for
+		// files whose segments are out of order.
+		for _, from := range []int{next, 0} {
+			found := -1
+			for i := from; i < len(sentences) && found < 0; i++ {
+				if _, taken := matches[sentences[i].ID]; taken {
+					continue
+				}
+				joined := keys[i]
+				for span := 1; span <= maxSpan && i+span <= len(sentences); span++ {
+					if span > 1 {
+						joined += " " + keys[i+span-1]
+					}
+					if joined == key {
+						found = i
+						next = i + span
+						break
+					}
+				}
+			}
+			if found >= 0 {
+				matches[sentences[found].ID] = pair.Target
+				break
+			}
+		}
+	}
+
+	return matches
+}
+
+// alignmentKey is the form of a segment compared when aligning: lowercased
+// with whitespace collapsed.
+func alignmentKey(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// alignmentFormat returns the format of an aligned file: the requested one, or
+// TMX for .tmx files and XML content and TSV otherwise.
+func alignmentFormat(filename string, data []byte, format *model.AlignmentFormat) model.AlignmentFormat {
+	if format != nil {
+		return *format
+	}
+	trimmed := strings.TrimSpace(string(data[:min(len(data), 512)]))
+	if strings.EqualFold(filepath.Ext(filename), ".tmx") || strings.HasPrefix(trimmed, "<") {
+		return model.AlignmentFormatTmx
+	}
+	return model.AlignmentFormatTsv
+}
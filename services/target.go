@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"LinganoGO/ent"
+	"LinganoGO/ent/bookmark"
+	"LinganoGO/ent/predicate"
+	"LinganoGO/ent/reaction"
+	"LinganoGO/graph/model"
+
+	"github.com/google/uuid"
+)
+
+// Target is the post, reading or comment a reaction or bookmark belongs to.
+// Exactly one of its IDs is set.
+type Target struct {
+	PostID    *uuid.UUID
+	ReadingID *uuid.UUID
+	CommentID *uuid.UUID
+}
+
+// PostTarget returns the target for a post
+func PostTarget(id uuid.UUID) Target {
+	return Target{PostID: &id}
+}
+
+// ReadingTarget returns the target for a reading
+func ReadingTarget(id uuid.UUID) Target {
+	return Target{ReadingID: &id}
+}
+
+// CommentTarget returns the target for a comment
+func CommentTarget(id uuid.UUID) Target {
+	return Target{CommentID: &id}
+}
+
+// ParseTarget parses a ContentTarget input, which must name exactly one post,
+// reading or comment
+func ParseTarget(input model.ContentTarget) (Target, error) {
+	var (
+		target Target
+		err    error
+	)
+	if target.PostID, err = parseOptionalID(input.PostID); err != nil {
+		return Target{}, fmt.Errorf("invalid post ID: %w", err)
+	}
+	if target.ReadingID, err = parseOptionalID(input.ReadingID); err != nil {
+		return Target{}, fmt.Errorf("invalid reading ID: %w", err)
+	}
+	if target.CommentID, err = parseOptionalID(input.CommentID); err != nil {
+		return Target{}, fmt.Errorf("invalid comment ID: %w", err)
+	}
+
+	set := 0
+	for _, id := range []*uuid.UUID{target.PostID, target.ReadingID, target.CommentID} {
+		if id != nil {
+			set++
+		}
+	}
+	if set != 1 {
+		return Target{}, errors.New("exactly one of postID, readingID and commentID must be set")
+	}
+	return target, nil
+}
+
+func parseOptionalID(id *string) (*uuid.UUID, error) {
+	if id == nil {
+		return nil, nil
+	}
+	parsed, err := uuid.Parse(*id)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func (t Target) reactionPredicate() predicate.Reaction {
+	switch {
+	case t.PostID != nil:
+		return reaction.PostID(*t.PostID)
+	case t.ReadingID != nil:
+		return reaction.ReadingID(*t.ReadingID)
+	default:
+		return reaction.CommentID(*t.CommentID)
+	}
+}
+
+func (t Target) bookmarkPredicate() predicate.Bookmark {
+	switch {
+	case t.PostID != nil:
+		return bookmark.PostID(*t.PostID)
+	case t.ReadingID != nil:
+		return bookmark.ReadingID(*t.ReadingID)
+	default:
+		return bookmark.CommentID(*t.CommentID)
+	}
+}
+
+// checkTargetVisible makes sure the user can see the target: posts must be
+// published unless they are the user's own, readings viewable by the user
+// and comments not deleted.
+func checkTargetVisible(ctx context.Context, client *ent.Client, t Target, userID uuid.UUID) error {
+	switch {
+	case t.PostID != nil:
+		p, err := client.Post.Get(ctx, *t.PostID)
+		if err != nil {
+			return fmt.Errorf("failed to get post: %w", err)
+		}
+		if p.Draft && p.UserID != userID {
+			return ErrForbidden
+		}
+	case t.ReadingID != nil:
+		r, err := client.Reading.Get(ctx, *t.ReadingID)
+		if err != nil {
+			return fmt.Errorf("failed to get reading: %w", err)
+		}
+		if !canViewReading(r, &userID) {
+			return ErrForbidden
+		}
+	default:
+		c, err := client.Comment.Get(ctx, *t.CommentID)
+		if err != nil {
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+		if c.DeletedAt != nil {
+			return errors.New("comment has been deleted")
+		}
+	}
+	return nil
+}
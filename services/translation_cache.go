@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"LinganoGO/ent"
+	"LinganoGO/ent/cachedtranslation"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// CachedTranslator caches another provider's translations in the database,
+// keyed by language pair and normalized text, so that a text is sent to the
+// provider only once.
+type CachedTranslator struct {
+	provider Translator
+	client   *ent.Client
+}
+
+// NewCachedTranslator wraps provider with a cache stored through client.
+// Only providers whose results don't depend on the user may be cached.
+func NewCachedTranslator(client *ent.Client, provider Translator) *CachedTranslator {
+	return &CachedTranslator{provider: provider, client: client}
+}
+
+// Name returns the name of the cached provider.
+func (t *CachedTranslator) Name() string {
+	return t.provider.Name()
+}
+
+// Translate answers from the cache and asks the provider for the rest, each
+// distinct text once.
+func (t *CachedTranslator) Translate(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	normalized := make([]string, len(texts))
+	hashes := make([]string, len(texts))
+	for i, text := range texts {
+		normalized[i] = normalizeTranslationText(text)
+		hashes[i] = translationHash(normalized[i])
+	}
+
+	cached, err := t.client.CachedTranslation.
+		Query().
+		Where(
+			cachedtranslation.SourceLanguage(from),
+			cachedtranslation.TargetLanguage(to),
+			cachedtranslation.TextHashIn(hashes...),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translation cache: %w", err)
+	}
+	found := make(map[string]string, len(cached))
+	for _, c := range cached {
+		found[c.TextHash] = c.Translation
+	}
+
+	var missing []string
+	queued := make(map[string]bool)
+	for i, hash := range hashes {
+		if _, ok := found[hash]; !ok && !queued[hash] {
+			queued[hash] = true
+			missing = append(missing, normalized[i])
+		}
+	}
+
+	if len(missing) > 0 {
+		translations, err := t.provider.Translate(ctx, missing, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for i, text := range missing {
+			hash := translationHash(text)
+			found[hash] = translations[i]
+			t.store(ctx, from, to, hash, text, translations[i])
+		}
+	}
+
+	translations := make([]string, len(texts))
+	for i, hash := range hashes {
+		translations[i] = found[hash]
+	}
+	return translations, nil
+}
+
+// store adds a translation to the cache. A failure, such as another request
+// having cached the same text first, only costs a later provider call.
+func (t *CachedTranslator) store(ctx context.Context, from, to, hash, text, translation string) {
+	err := t.client.CachedTranslation.
+		Create().
+		SetSourceLanguage(from).
+		SetTargetLanguage(to).
+		SetTextHash(hash).
+		SetText(text).
+		SetTranslation(translation).
+		SetProvider(t.provider.Name()).
+		Exec(ctx)
+	if err != nil && !ent.IsConstraintError(err) {
+		log.Printf("failed to cache translation: %v", err)
+	}
+}
+
+// normalizeTranslationText returns the form of a text that is translated and
+// cached: NFC normalized with whitespace collapsed. Case is kept, as it can
+// change the translation.
+func normalizeTranslationText(text string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(text)), " ")
+}
+
+func translationHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/user"
+	"LinganoGO/graph/model"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxTranslateLength is the longest text, in characters, translate accepts.
+	MaxTranslateLength = 5000
+	// defaultTranslateRateLimit is the number of translate requests a user can
+	// make per minute unless TRANSLATE_RATE_LIMIT says otherwise.
+	defaultTranslateRateLimit = 60
+)
+
+// TranslationService provides on-demand translation of words and sentences
+type TranslationService struct {
+	client     *ent.Client
+	translator Translator
+	limiter    *RateLimiter
+}
+
+// NewTranslationService creates a new TranslationService using the configured
+// translation provider
+func NewTranslationService() *TranslationService {
+	client := config.GetEntClient()
+	return &TranslationService{
+		client:     client,
+		translator: NewTranslator(client),
+		limiter:    translateLimiter(),
+	}
+}
+
+// translateLimiter is shared by every service that sends text to the
+// translation provider on a user's behalf, so that they draw on one limit.
+var translateLimiter = sync.OnceValue(func() *RateLimiter {
+	return NewRateLimiter(translateRateLimit(), time.Minute)
+})
+
+// Translate translates a word or sentence for a user. Each user can make a
+// limited number of requests per minute; the user must exist, so that made
+// up IDs can't be used to get around the limit.
+func (s *TranslationService) Translate(ctx context.Context, userID uuid.UUID, text, from, to string) (*model.Translation, error) {
+	text = strings.TrimSpace(text)
+	from, to = normalizeLanguage(from), normalizeLanguage(to)
+	switch {
+	case text == "":
+		return nil, fmt.Errorf("text must not be empty")
+	case utf8.RuneCountInString(text) > MaxTranslateLength:
+		return nil, fmt.Errorf("text is longer than %d characters", MaxTranslateLength)
+	case from == "" || to == "":
+		return nil, fmt.Errorf("source and target languages are required")
+	}
+	if s.translator == nil {
+		return nil, ErrNoTranslator
+	}
+	if err := checkUserExists(ctx, s.client, userID); err != nil {
+		return nil, err
+	}
+	if !s.limiter.Allow(userID.String()) {
+		return nil, ErrRateLimited
+	}
+
+	translation := text
+	if from != to {
+		translations, err := s.translator.Translate(withTranslationUser(ctx, userID), []string{text}, from, to)
+		if err != nil {
+			return nil, err
+		}
+		translation = translations[0]
+	}
+
+	return &model.Translation{
+		Text:        text,
+		Translation: translation,
+		From:        from,
+		To:          to,
+		Provider:    s.translator.Name(),
+	}, nil
+}
+
+// checkUserExists fails unless the user exists, so that made up IDs can't be
+// used to get around per-user rate limits.
+func checkUserExists(ctx context.Context, client *ent.Client, userID uuid.UUID) error {
+	exists, err := client.User.Query().Where(user.ID(userID)).Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("user not found")
+	}
+	return nil
+}
+
+// translateRateLimit reads TRANSLATE_RATE_LIMIT, the number of translate
+// requests a user can make per minute.
+func translateRateLimit() int {
+	value := os.Getenv("TRANSLATE_RATE_LIMIT")
+	if value == "" {
+		return defaultTranslateRateLimit
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		log.Printf("invalid TRANSLATE_RATE_LIMIT %q; using %d", value, defaultTranslateRateLimit)
+		return defaultTranslateRateLimit
+	}
+	return limit
+}
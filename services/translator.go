@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+
+	"LinganoGO/ent"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNoTranslator is returned when machine translation is requested but
+	// no translation provider is configured.
+	ErrNoTranslator = errors.New("no translation provider is configured")
+	// ErrNoTranslation is returned by providers that can't translate a text,
+	// such as the glossary provider for words the user hasn't saved.
+	ErrNoTranslation = errors.New("no translation found")
+	// ErrPersonalTranslator is returned when translations that depend on the
+	// user, such as the glossary's, are requested for sharing with others.
+	ErrPersonalTranslator = errors.New("the translation provider translates per user and can't translate readings")
+)
+
+// Translator translates text between languages. Languages are lowercase
+// codes such as "en" or "pt-br". Implementations return one translation per
+// input text, in the same order.
+type Translator interface {
+	// Name identifies the provider, e.g. in stored translations.
+	Name() string
+	Translate(ctx context.Context, texts []string, from, to string) ([]string, error)
+}
+
+// NewTranslator returns the translation provider set by the TRANSLATOR
+// environment variable: "http" for the translation API at TRANSLATE_URL, with
+// its results cached in the database, "glossary" for the user's own glossary
+// or "fake" for development. Without TRANSLATOR, the HTTP provider is used if
+// TRANSLATE_URL is set. It returns nil when no provider is configured.
+func NewTranslator(client *ent.Client) Translator {
+	name := os.Getenv("TRANSLATOR")
+	if name == "" && os.Getenv("TRANSLATE_URL") != "" {
+		name = "http"
+	}
+
+	switch name {
+	case "":
+		return nil
+	case "http":
+		endpoint := os.Getenv("TRANSLATE_URL")
+		if endpoint == "" {
+			log.Printf("TRANSLATOR is http but TRANSLATE_URL is not set; translation is disabled")
+			return nil
+		}
+		return NewCachedTranslator(client, NewHTTPTranslator(endpoint, os.Getenv("TRANSLATE_API_KEY")))
+	case "glossary":
+		return NewGlossaryTranslator(client)
+	case "fake":
+		return &FakeTranslator{}
+	default:
+		log.Printf("unknown TRANSLATOR %q; translation is disabled", name)
+		return nil
+	}
+}
+
+// personalTranslator is implemented by providers whose translations depend on
+// the user they are made for, so they must not be stored for everyone.
+type personalTranslator interface {
+	personal()
+}
+
+type translationUserKey struct{}
+
+// withTranslationUser records the user a translation is made for, so that
+// providers drawing on the user's own data, like the glossary, can find it.
+func withTranslationUser(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, translationUserKey{}, userID)
+}
+
+func translationUser(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(translationUserKey{}).(uuid.UUID)
+	return userID, ok
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeTranslator is a Translator for tests and development. It returns the
+// translations it was given and otherwise tags the text with the target
+// language, as in "[es] hello". If Err is set, every call fails with it.
+type FakeTranslator struct {
+	Translations map[string]string
+	Err          error
+
+	mu    sync.Mutex
+	calls [][]string
+}
+
+// Name returns "fake".
+func (t *FakeTranslator) Name() string {
+	return "fake"
+}
+
+// Translate records the texts and translates them.
+func (t *FakeTranslator) Translate(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	t.mu.Lock()
+	t.calls = append(t.calls, append([]string(nil), texts...))
+	t.mu.Unlock()
+
+	if t.Err != nil {
+		return nil, t.Err
+	}
+
+	translations := make([]string, len(texts))
+	for i, text := range texts {
+		if translation, ok := t.Translations[text]; ok {
+			translations[i] = translation
+		} else {
+			translations[i] = "[" + to + "] " + text
+		}
+	}
+	return translations, nil
+}
+
+// Calls returns the texts of every Translate call so far.
+func (t *FakeTranslator) Calls() [][]string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([][]string(nil), t.calls...)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"LinganoGO/ent"
+	"LinganoGO/ent/vocabularyitem"
+	"LinganoGO/tokenizer"
+)
+
+// GlossaryTranslator translates only the words and phrases the user saved to
+// their vocabulary with a translation, so it never sends text to a vendor.
+// Saved translations are in the user's own language, whatever the target.
+type GlossaryTranslator struct {
+	client *ent.Client
+}
+
+// NewGlossaryTranslator creates a translator drawing on users' vocabulary
+func NewGlossaryTranslator(client *ent.Client) *GlossaryTranslator {
+	return &GlossaryTranslator{client: client}
+}
+
+// Name returns "glossary".
+func (t *GlossaryTranslator) Name() string {
+	return "glossary"
+}
+
+func (t *GlossaryTranslator) personal() {}
+
+// Translate looks every text up in the glossary of the user the translation
+// is made for. It fails with ErrNoTranslation if any text isn't there.
+func (t *GlossaryTranslator) Translate(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	userID, ok := translationUser(ctx)
+	if !ok {
+		return nil, errors.New("glossary translation needs a user")
+	}
+
+	terms := make([]string, len(texts))
+	for i, text := range texts {
+		terms[i] = tokenizer.Normalize(text, from)
+	}
+
+	items, err := t.client.VocabularyItem.
+		Query().
+		Where(
+			vocabularyitem.UserID(userID),
+			vocabularyitem.LanguageIn(from, ""),
+			vocabularyitem.NormalizedTermIn(terms...),
+			vocabularyitem.TranslationNEQ(""),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get glossary: %w", err)
+	}
+	// Words saved without a language apply to every language, but those
+	// saved for this one win.
+	glossary := make(map[string]*ent.VocabularyItem, len(items))
+	for _, item := range items {
+		if prev, ok := glossary[item.NormalizedTerm]; ok && prev.Language != "" {
+			continue
+		}
+		glossary[item.NormalizedTerm] = item
+	}
+
+	translations := make([]string, len(texts))
+	for i, term := range terms {
+		item, ok := glossary[term]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not in the glossary", ErrNoTranslation, texts[i])
+		}
+		translations[i] = item.Translation
+	}
+
+	return translations, nil
+}
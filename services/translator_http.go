@@ -0,0 +1,109 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// maxTranslationResponse is the largest response read from a translation API.
+const maxTranslationResponse = 10 << 20
+
+// HTTPTranslator calls a translation API speaking the LibreTranslate
+// protocol: a JSON POST of {"q": [texts], "source", "target", "format",
+// "api_key"} answered with {"translatedText": [translations]}. It is offered
+// by LibreTranslate itself and by proxies in front of other vendors.
+type HTTPTranslator struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// NewHTTPTranslator creates a translator for the API at endpoint. apiKey may
+// be empty.
+func NewHTTPTranslator(endpoint, apiKey string) *HTTPTranslator {
+	return &HTTPTranslator{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns "http:" followed by the API's host.
+func (t *HTTPTranslator) Name() string {
+	if u, err := url.Parse(t.endpoint); err == nil && u.Host != "" {
+		return "http:" + u.Host
+	}
+	return "http"
+}
+
+type httpTranslateRequest struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+	APIKey string   `json:"api_key,omitempty"`
+}
+
+type httpTranslateResponse struct {
+	TranslatedText []string `json:"translatedText"`
+	Error          string   `json:"error"`
+}
+
+// Translate sends all texts in one request.
+func (t *HTTPTranslator) Translate(ctx context.Context, texts []string, from, to string) ([]string, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(httpTranslateRequest{
+		Q:      texts,
+		Source: from,
+		Target: to,
+		Format: "text",
+		APIKey: t.apiKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode translation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("translation request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxTranslationResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translation response: %w", err)
+	}
+
+	var result httpTranslateResponse
+	decodeErr := json.Unmarshal(body, &result)
+	if res.StatusCode != http.StatusOK {
+		if decodeErr == nil && result.Error != "" {
+			return nil, fmt.Errorf("translation API returned %s: %s", res.Status, result.Error)
+		}
+		return nil, fmt.Errorf("translation API returned %s", res.Status)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode translation response: %w", decodeErr)
+	}
+	if len(result.TranslatedText) != len(texts) {
+		return nil, fmt.Errorf("translation API returned %d translations for %d texts", len(result.TranslatedText), len(texts))
+	}
+
+	return result.TranslatedText, nil
+}
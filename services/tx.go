@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"LinganoGO/ent"
+	"LinganoGO/ent/reading"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// withTx runs fn inside a transaction, committing on success and rolling back
+// if fn returns an error or panics.
+func withTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			tx.Rollback()
+			panic(v)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// lockReading locks the row of a reading until tx ends, so that changes made
+// from its current state aren't made twice by concurrent requests.
+func lockReading(ctx context.Context, tx *ent.Tx, id uuid.UUID) error {
+	_, err := tx.Reading.
+		Query().
+		Where(reading.ID(id), func(s *sql.Selector) {
+			s.ForUpdate()
+		}).
+		OnlyID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to lock reading: %w", err)
+	}
+	return nil
+}
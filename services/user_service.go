@@ -7,6 +7,7 @@ import (
 	"LinganoGO/config"
 	"LinganoGO/ent"
 	"LinganoGO/ent/user"
+	"LinganoGO/graph/model"
 
 	"github.com/google/uuid"
 )
@@ -162,3 +163,102 @@ func (s *UserService) CountUsers(ctx context.Context) (int, error) {
 	
 	return count, nil
 }
+
+// readingAidPreference is the key of User.preferences holding the reading aid
+// shown over non-Latin scripts.
+const readingAidPreference = "readingAid"
+
+// SetReadingAid stores the reading aid a user wants in their preferences,
+// keeping the other preferences as they are
+func (s *UserService) SetReadingAid(ctx context.Context, id uuid.UUID, aid model.ReadingAid) (*ent.User, error) {
+	if !aid.IsValid() {
+		return nil, fmt.Errorf("invalid reading aid: %s", aid)
+	}
+
+	u, err := s.client.User.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	preferences := make(map[string]interface{}, len(u.Preferences)+1)
+	for key, value := range u.Preferences {
+		preferences[key] = value
+	}
+	preferences[readingAidPreference] = aid.String()
+
+	u, err = s.client.User.
+		UpdateOneID(id).
+		SetPreferences(preferences).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set reading aid: %w", err)
+	}
+
+	return u, nil
+}
+
+// ReadingAidOf returns the reading aid stored in a user's preferences, NONE
+// when they never chose one.
+func ReadingAidOf(u *ent.User) model.ReadingAid {
+	value, _ := u.Preferences[readingAidPreference].(string)
+	aid := model.ReadingAid(value)
+	if !aid.IsValid() {
+		return model.ReadingAidNone
+	}
+	return aid
+}
+
+// maxNativeLanguages is the largest number of native languages a user can list.
+const maxNativeLanguages = 5
+
+// SetNativeLanguages replaces the languages a user speaks natively
+func (s *UserService) SetNativeLanguages(ctx context.Context, id uuid.UUID, languages []string) (*ent.User, error) {
+	languages = NormalizeLanguages(languages)
+	if len(languages) > maxNativeLanguages {
+		return nil, fmt.Errorf("at most %d native languages can be set", maxNativeLanguages)
+	}
+
+	u, err := s.client.User.
+		UpdateOneID(id).
+		SetNativeLanguages(languages).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set native languages: %w", err)
+	}
+
+	return u, nil
+}
+
+// NormalizeLanguages normalizes language codes, dropping empty ones and
+// duplicates while keeping the order they were given in.
+func NormalizeLanguages(languages []string) []string {
+	result := make([]string, 0, len(languages))
+	seen := make(map[string]bool, len(languages))
+	for _, language := range languages {
+		language = normalizeLanguage(language)
+		if language == "" || seen[language] {
+			continue
+		}
+		seen[language] = true
+		result = append(result, language)
+	}
+	return result
+}
+
+// SetTargetLanguage sets the language a user is learning. A nil or empty
+// language removes it.
+func (s *UserService) SetTargetLanguage(ctx context.Context, id uuid.UUID, language *string) (*ent.User, error) {
+	update := s.client.User.UpdateOneID(id)
+	if language == nil || normalizeLanguage(*language) == "" {
+		update.ClearTargetLanguage()
+	} else {
+		update.SetTargetLanguage(normalizeLanguage(*language))
+	}
+
+	u, err := update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set target language: %w", err)
+	}
+
+	return u, nil
+}
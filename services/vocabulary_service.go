@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/vocabularyitem"
+	"LinganoGO/graph/model"
+	"LinganoGO/tokenizer"
+
+	"github.com/google/uuid"
+)
+
+// KnownStatus is the vocabulary status of a word the user knows.
+const KnownStatus = 5
+
+// VocabularyService provides methods for the words users save while reading
+type VocabularyService struct {
+	client *ent.Client
+}
+
+// NewVocabularyService creates a new VocabularyService
+func NewVocabularyService() *VocabularyService {
+	return &VocabularyService{
+		client: config.GetEntClient(),
+	}
+}
+
+// SaveWord adds a word to the user's vocabulary. Saving a word that is already in the
+// vocabulary for the same language updates it instead.
+func (s *VocabularyService) SaveWord(ctx context.Context, input model.SaveWordInput) (*ent.VocabularyItem, error) {
+	userUUID, err := uuid.Parse(input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	language := normalizeLanguage(input.Language)
+	normalized := tokenizer.Normalize(input.Term, language)
+	if normalized == "" {
+		return nil, fmt.Errorf("term must not be empty")
+	}
+
+	var readingUUID *uuid.UUID
+	if input.ReadingID != nil {
+		id, err := uuid.Parse(*input.ReadingID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reading ID: %w", err)
+		}
+		reading, err := s.client.Reading.Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reading: %w", err)
+		}
+		if !canViewReading(reading, &userUUID) {
+			return nil, fmt.Errorf("failed to save word: %w", ErrForbidden)
+		}
+		readingUUID = &id
+	}
+
+	existing, err := s.client.VocabularyItem.
+		Query().
+		Where(
+			vocabularyitem.UserID(userUUID),
+			vocabularyitem.Language(language),
+			vocabularyitem.NormalizedTerm(normalized),
+		).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get vocabulary item: %w", err)
+	}
+
+	if existing != nil {
+		update := existing.Update().SetTerm(strings.TrimSpace(input.Term))
+		if input.Lemma != nil {
+			update.SetLemma(*input.Lemma)
+		}
+		if input.Translation != nil {
+			update.SetTranslation(*input.Translation)
+		}
+		if input.Notes != nil {
+			update.SetNotes(*input.Notes)
+		}
+		if input.Status != nil && *input.Status != existing.Status {
+			update.SetStatus(*input.Status).SetStatusUpdatedAt(time.Now())
+		}
+		if input.Sentence != nil {
+			update.SetSentence(*input.Sentence)
+		}
+		if readingUUID != nil {
+			update.SetReadingID(*readingUUID)
+		}
+
+		item, err := update.Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update vocabulary item: %w", err)
+		}
+		return item, nil
+	}
+
+	create := s.client.VocabularyItem.
+		Create().
+		SetUserID(userUUID).
+		SetTerm(strings.TrimSpace(input.Term)).
+		SetNormalizedTerm(normalized).
+		SetLanguage(language).
+		SetNillableReadingID(readingUUID)
+	if input.Lemma != nil {
+		create.SetLemma(*input.Lemma)
+	}
+	if input.Translation != nil {
+		create.SetTranslation(*input.Translation)
+	}
+	if input.Notes != nil {
+		create.SetNotes(*input.Notes)
+	}
+	if input.Status != nil {
+		create.SetStatus(*input.Status)
+	}
+	if input.Sentence != nil {
+		create.SetSentence(*input.Sentence)
+	}
+
+	item, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save word: %w", err)
+	}
+
+	return item, nil
+}
+
+// UpdateWordStatus changes the familiarity status or ignored flag of a vocabulary item owned by the user
+func (s *VocabularyService) UpdateWordStatus(ctx context.Context, id, userID uuid.UUID, status *int, ignored *bool) (*ent.VocabularyItem, error) {
+	item, err := s.client.VocabularyItem.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vocabulary item: %w", err)
+	}
+	if item.UserID != userID {
+		return nil, fmt.Errorf("failed to update vocabulary item: %w", ErrForbidden)
+	}
+
+	update := item.Update()
+	if status != nil && *status != item.Status {
+		update.SetStatus(*status).SetStatusUpdatedAt(time.Now())
+	}
+	if ignored != nil {
+		update.SetIgnored(*ignored)
+	}
+
+	item, err = update.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update word status: %w", err)
+	}
+
+	return item, nil
+}
+
+// GetVocabulary returns the user's vocabulary items matching the filter, most recently updated first
+func (s *VocabularyService) GetVocabulary(ctx context.Context, userID uuid.UUID, filter *model.VocabularyFilter) ([]*ent.VocabularyItem, error) {
+	query := s.client.VocabularyItem.
+		Query().
+		Where(vocabularyitem.UserID(userID)).
+		Order(ent.Desc(vocabularyitem.FieldUpdatedAt))
+
+	if filter != nil {
+		if filter.Language != nil {
+			query.Where(vocabularyitem.Language(normalizeLanguage(*filter.Language)))
+		}
+		if len(filter.Statuses) > 0 {
+			query.Where(vocabularyitem.StatusIn(filter.Statuses...))
+		}
+		if filter.Ignored != nil {
+			query.Where(vocabularyitem.Ignored(*filter.Ignored))
+		}
+		if filter.ReadingID != nil {
+			readingUUID, err := uuid.Parse(*filter.ReadingID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reading ID: %w", err)
+			}
+			query.Where(vocabularyitem.ReadingID(readingUUID))
+		}
+		if filter.Search != nil && *filter.Search != "" {
+			query.Where(vocabularyitem.Or(
+				vocabularyitem.TermContainsFold(*filter.Search),
+				vocabularyitem.LemmaContainsFold(*filter.Search),
+				vocabularyitem.TranslationContainsFold(*filter.Search),
+			))
+		}
+		if filter.Offset != nil {
+			query.Offset(*filter.Offset)
+		}
+		if filter.Limit != nil {
+			query.Limit(*filter.Limit)
+		}
+	}
+
+	items, err := query.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vocabulary: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetWordStatuses returns the user's status for every word saved in the given language,
+// keyed by normalized form. Words saved without a language apply to every language.
+func (s *VocabularyService) GetWordStatuses(ctx context.Context, userID uuid.UUID, language string) (map[string]model.WordStatus, error) {
+	query := s.client.VocabularyItem.
+		Query().
+		Where(vocabularyitem.UserID(userID))
+	if language != "" {
+		query.Where(vocabularyitem.LanguageIn(language, ""))
+	}
+
+	items, err := query.
+		Select(
+			vocabularyitem.FieldNormalizedTerm,
+			vocabularyitem.FieldLanguage,
+			vocabularyitem.FieldStatus,
+			vocabularyitem.FieldIgnored,
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word statuses: %w", err)
+	}
+
+	statuses := make(map[string]model.WordStatus, len(items))
+	for _, item := range items {
+		// Entries saved for the language win over language-less ones.
+		if _, ok := statuses[item.NormalizedTerm]; ok && item.Language == "" {
+			continue
+		}
+		statuses[item.NormalizedTerm] = WordStatusOf(item)
+	}
+
+	return statuses, nil
+}
+
+// WordStatusOf maps a vocabulary item's numeric status to a WordStatus.
+func WordStatusOf(item *ent.VocabularyItem) model.WordStatus {
+	switch {
+	case item.Ignored:
+		return model.WordStatusIgnored
+	case item.Status >= KnownStatus:
+		return model.WordStatusKnown
+	case item.Status > 0:
+		return model.WordStatusLearning
+	default:
+		return model.WordStatusNew
+	}
+}
+
+// NormalizeTerms recomputes the normalized terms of saved words with
+// tokenizer.Normalize. Words migrated from users.saved_words were normalized
+// in SQL, which neither applies NFC nor Turkish casing, so they may not match
+// the tokens of readings. When a word normalizes to one the user already saved
+// for the language, it is merged into that row and removed; words that
+// normalize to nothing are removed. Both are logged. It returns the number of
+// rows changed.
+func (s *VocabularyService) NormalizeTerms(ctx context.Context) (int, error) {
+	changed := 0
+	var after uuid.UUID
+	for {
+		items, err := s.client.VocabularyItem.
+			Query().
+			Where(vocabularyitem.IDGT(after)).
+			Order(ent.Asc(vocabularyitem.FieldID)).
+			Limit(backfillBatchSize).
+			All(ctx)
+		if err != nil {
+			return changed, fmt.Errorf("failed to get vocabulary: %w", err)
+		}
+		if len(items) == 0 {
+			return changed, nil
+		}
+		after = items[len(items)-1].ID
+
+		for _, item := range items {
+			normalized := tokenizer.Normalize(item.Term, item.Language)
+			if normalized == item.NormalizedTerm {
+				continue
+			}
+			if err := s.normalizeTerm(ctx, item, normalized); err != nil {
+				return changed, fmt.Errorf("failed to normalize %q: %w", item.Term, err)
+			}
+			changed++
+		}
+	}
+}
+
+// normalizeTerm stores a saved word's new normalized term, merging the word
+// into the one the user saved with that term if there is one.
+func (s *VocabularyService) normalizeTerm(ctx context.Context, item *ent.VocabularyItem, normalized string) error {
+	return withTx(ctx, s.client, func(tx *ent.Tx) error {
+		if normalized == "" {
+			log.Printf("removing saved word %q (%s) of user %s: it normalizes to nothing", item.Term, item.ID, item.UserID)
+			return tx.VocabularyItem.DeleteOneID(item.ID).Exec(ctx)
+		}
+
+		kept, err := tx.VocabularyItem.
+			Query().
+			Where(
+				vocabularyitem.UserID(item.UserID),
+				vocabularyitem.Language(item.Language),
+				vocabularyitem.NormalizedTerm(normalized),
+			).
+			Only(ctx)
+		if ent.IsNotFound(err) {
+			return tx.VocabularyItem.
+				UpdateOneID(item.ID).
+				SetNormalizedTerm(normalized).
+				SetUpdatedAt(item.UpdatedAt).
+				Exec(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		log.Printf("merging saved word %q (%s) into %q (%s) of user %s", item.Term, item.ID, kept.Term, kept.ID, item.UserID)
+		if err := tx.VocabularyItem.DeleteOneID(item.ID).Exec(ctx); err != nil {
+			return err
+		}
+		update := kept.Update()
+		mergeVocabularyItem(update, kept, item)
+		return update.Exec(ctx)
+	})
+}
+
+// mergeVocabularyItem keeps what a duplicate saved word adds to the one that
+// stays: the more advanced status and the fields the kept word lacks. A word
+// stays ignored only if both were.
+func mergeVocabularyItem(update *ent.VocabularyItemUpdateOne, kept, dropped *ent.VocabularyItem) {
+	if dropped.Status > kept.Status {
+		update.SetStatus(dropped.Status).SetStatusUpdatedAt(dropped.StatusUpdatedAt)
+	}
+	if kept.Ignored && !dropped.Ignored {
+		update.SetIgnored(false)
+	}
+	if kept.Lemma == "" && dropped.Lemma != "" {
+		update.SetLemma(dropped.Lemma)
+	}
+	if kept.Translation == "" && dropped.Translation != "" {
+		update.SetTranslation(dropped.Translation)
+	}
+	if kept.Notes == "" && dropped.Notes != "" {
+		update.SetNotes(dropped.Notes)
+	}
+	if kept.Sentence == "" && dropped.Sentence != "" {
+		update.SetSentence(dropped.Sentence)
+	}
+	if kept.ReadingID == nil && dropped.ReadingID != nil {
+		update.SetReadingID(*dropped.ReadingID)
+	}
+}
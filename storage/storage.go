@@ -0,0 +1,126 @@
+// Package storage keeps uploaded media files, such as reading audio, and
+// gives them the URLs they are served from.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	defaultDir = "media"
+	defaultURL = "/media"
+)
+
+// ErrInvalidKey is returned for keys that would escape the storage directory.
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Store saves files under keys such as "audio/<id>.mp3".
+type Store interface {
+	// Save stores the content read from r under key, replacing any file
+	// already there, and returns the URL it is served from.
+	Save(ctx context.Context, key string, r io.Reader) (string, error)
+	// Delete removes the file stored under key. Deleting a missing file is
+	// not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Dir returns the directory media files are kept in, set by MEDIA_DIR.
+func Dir() string {
+	if dir := os.Getenv("MEDIA_DIR"); dir != "" {
+		return dir
+	}
+	return defaultDir
+}
+
+// BaseURL returns the URL media files are served under, set by MEDIA_URL.
+// It defaults to the server's own /media path.
+func BaseURL() string {
+	if url := os.Getenv("MEDIA_URL"); url != "" {
+		return strings.TrimSuffix(url, "/")
+	}
+	return defaultURL
+}
+
+// LocalStore keeps files in a directory on the local disk.
+type LocalStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStore creates a store keeping files in dir and serving them under
+// baseURL.
+func NewLocalStore(dir, baseURL string) *LocalStore {
+	return &LocalStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Save writes the file to a temporary name first so that a failed upload
+// never replaces a complete file.
+func (s *LocalStore) Save(ctx context.Context, key string, r io.Reader) (string, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create media file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return "", fmt.Errorf("failed to store media file: %w", err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// Delete removes a stored file.
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete media file: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean != "/"+key {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+// Handler serves the files kept in dir. Directory listings are not served,
+// so files can only be fetched by their full URL.
+func Handler(dir string) http.Handler {
+	files := http.FileServer(http.Dir(dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"LinganoGO/anchor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// selectorFor selects the given occurrence (counting from 0) of quote in text.
+func selectorFor(t *testing.T, text, quote string, occurrence int) anchor.Selector {
+	t.Helper()
+
+	offset := -1
+	for i := 0; i <= occurrence; i++ {
+		next := strings.Index(text[offset+1:], quote)
+		require.GreaterOrEqual(t, next, 0)
+		offset += 1 + next
+	}
+	start := utf8.RuneCountInString(text[:offset])
+
+	s, err := anchor.New(text, start, start+utf8.RuneCountInString(quote))
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewSelectorValidatesRange(t *testing.T) {
+	_, err := anchor.New("short", 2, 9)
+	assert.Error(t, err)
+
+	_, err = anchor.New("short", 3, 3)
+	assert.Error(t, err)
+
+	s, err := anchor.New("Ça va très bien", 3, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "va", s.Quote)
+	assert.Equal(t, "Ça ", s.Prefix)
+	assert.Equal(t, " très bien", s.Suffix)
+}
+
+func TestReanchorFollowsInsertedText(t *testing.T) {
+	text := "The cat sat on the mat. It was happy."
+	s := selectorFor(t, text, "sat on the mat", 0)
+
+	edited := "Yesterday evening, the old cat sat on the mat. It was happy."
+	moved, ok := anchor.Reanchor(edited, s)
+
+	require.True(t, ok)
+	assert.Equal(t, "sat on the mat", string([]rune(edited)[moved.Start:moved.End]))
+	assert.Equal(t, "Yesterday evening, the old cat ", moved.Prefix)
+}
+
+func TestReanchorPicksOccurrenceByContext(t *testing.T) {
+	text := "First, the dog barked. Later, the dog slept."
+	s := selectorFor(t, text, "the dog", 1)
+
+	edited := "Then the dog ran. First, the dog barked. Later, the dog slept."
+	moved, ok := anchor.Reanchor(edited, s)
+
+	require.True(t, ok)
+	assert.Equal(t, strings.Index(edited, "Later, the dog")+len("Later, "), moved.Start)
+}
+
+func TestReanchorFindsEditedQuoteBetweenContext(t *testing.T) {
+	text := "Chapter one begins here. The wizard opened the ancient door slowly. Nobody followed him inside."
+	s := selectorFor(t, text, "opened the ancient door", 0)
+
+	edited := "Chapter one begins here. The wizard opened the old door slowly. Nobody followed him inside."
+	moved, ok := anchor.Reanchor(edited, s)
+
+	require.True(t, ok)
+	assert.Equal(t, "opened the old door", moved.Quote)
+}
+
+func TestReanchorFailsWhenPassageIsRemoved(t *testing.T) {
+	text := "Keep this sentence. Remove this one. Keep this too."
+	s := selectorFor(t, text, "Remove this one.", 0)
+
+	_, ok := anchor.Reanchor("Something entirely different now.", s)
+
+	assert.False(t, ok)
+}
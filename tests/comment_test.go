@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCommentBody(t *testing.T) {
+	body, err := services.NormalizeCommentBody("  Great story!\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "Great story!", body)
+
+	_, err = services.NormalizeCommentBody(" \n\t ")
+	assert.Error(t, err)
+
+	// The limit counts characters, not bytes.
+	_, err = services.NormalizeCommentBody(strings.Repeat("語", 10000))
+	assert.NoError(t, err)
+	_, err = services.NormalizeCommentBody(strings.Repeat("a", 10001))
+	assert.Error(t, err)
+}
+
+func TestCommentThreads(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	userService := services.NewUserService()
+	postService := services.NewPostService()
+	readingService := services.NewReadingService()
+	commentService := services.NewCommentService()
+
+	suffix := uuid.NewString()
+	author, err := userService.CreateUser(ctx, "Comment Author", "comment-author-"+suffix+"@test.com", "password123")
+	require.NoError(t, err)
+	replier, err := userService.CreateUser(ctx, "Comment Replier", "comment-replier-"+suffix+"@test.com", "password123")
+	require.NoError(t, err)
+	post, err := postService.CreatePost(ctx, model.NewPost{Body: "Hoy fui al mercado.", UserID: author.ID.String()})
+	require.NoError(t, err)
+	postID := post.ID.String()
+
+	comment := func(t *testing.T, userID uuid.UUID, input model.NewComment) *ent.Comment {
+		t.Helper()
+		input.UserID = userID.String()
+		input.Body = "Comment " + uuid.NewString()
+		c, err := commentService.CreateComment(ctx, input)
+		require.NoError(t, err)
+		return c
+	}
+	reply := func(t *testing.T, userID uuid.UUID, parent *ent.Comment) *ent.Comment {
+		t.Helper()
+		parentID := parent.ID.String()
+		return comment(t, userID, model.NewComment{ParentID: &parentID})
+	}
+	assertGone := func(t *testing.T, c *ent.Comment) {
+		t.Helper()
+		_, err := commentService.GetComment(ctx, c.ID)
+		assert.True(t, ent.IsNotFound(err), "comment %s should be removed", c.ID)
+	}
+
+	t.Run("RepliesInheritTheirParentsTarget", func(t *testing.T) {
+		root := comment(t, author.ID, model.NewComment{PostID: &postID})
+		child := reply(t, replier.ID, root)
+		grandchild := reply(t, author.ID, child)
+
+		for _, c := range []*ent.Comment{child, grandchild} {
+			require.NotNil(t, c.PostID)
+			assert.Equal(t, post.ID, *c.PostID)
+			assert.Nil(t, c.ReadingID)
+		}
+		assert.Equal(t, root.ID, *child.ParentID)
+		assert.Equal(t, child.ID, *grandchild.ParentID)
+
+		r, err := readingService.CreateReading(ctx, "Commented Reading", author.ID, true)
+		require.NoError(t, err)
+		readingID := r.ID.String()
+		readingReply := reply(t, replier.ID, comment(t, author.ID, model.NewComment{ReadingID: &readingID}))
+		require.NotNil(t, readingReply.ReadingID)
+		assert.Equal(t, r.ID, *readingReply.ReadingID)
+		assert.Nil(t, readingReply.PostID)
+
+		parentID := root.ID.String()
+		_, err = commentService.CreateComment(ctx, model.NewComment{
+			UserID:   replier.ID.String(),
+			PostID:   &postID,
+			ParentID: &parentID,
+			Body:     "Both targets",
+		})
+		assert.Error(t, err, "a reply must not name its own target")
+	})
+
+	t.Run("DeletingACommentWithRepliesLeavesATombstone", func(t *testing.T) {
+		root := comment(t, author.ID, model.NewComment{PostID: &postID})
+		child := reply(t, replier.ID, root)
+
+		require.NoError(t, commentService.DeleteComment(ctx, root.ID, author.ID))
+
+		tombstone, err := commentService.GetComment(ctx, root.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, tombstone.DeletedAt)
+		assert.Nil(t, tombstone.UserID)
+		assert.Empty(t, tombstone.Body)
+
+		replies, err := commentService.GetReplies(ctx, root.ID, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, replies, 1)
+		assert.Equal(t, child.ID, replies[0].ID)
+
+		count, err := commentService.CountReplies(ctx, root.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		parentID := root.ID.String()
+		_, err = commentService.CreateComment(ctx, model.NewComment{
+			UserID:   replier.ID.String(),
+			ParentID: &parentID,
+			Body:     "Too late",
+		})
+		assert.Error(t, err, "deleted comments take no new replies")
+		assert.Error(t, commentService.DeleteComment(ctx, root.ID, author.ID), "tombstones can't be deleted again")
+	})
+
+	t.Run("DeletingTheLastReplyPrunesTombstones", func(t *testing.T) {
+		root := comment(t, author.ID, model.NewComment{PostID: &postID})
+		child := reply(t, replier.ID, root)
+		first := reply(t, author.ID, child)
+		second := reply(t, replier.ID, child)
+
+		require.NoError(t, commentService.DeleteComment(ctx, root.ID, author.ID))
+		require.NoError(t, commentService.DeleteComment(ctx, child.ID, replier.ID))
+
+		// The tombstones stay while a reply is left under them.
+		require.NoError(t, commentService.DeleteComment(ctx, first.ID, author.ID))
+		assertGone(t, first)
+		for _, c := range []*ent.Comment{root, child} {
+			tombstone, err := commentService.GetComment(ctx, c.ID)
+			require.NoError(t, err)
+			assert.NotNil(t, tombstone.DeletedAt)
+		}
+
+		// Removing the last reply removes every tombstone above it.
+		require.NoError(t, commentService.DeleteComment(ctx, second.ID, replier.ID))
+		for _, c := range []*ent.Comment{second, child, root} {
+			assertGone(t, c)
+		}
+	})
+
+	t.Run("PruningStopsAtALiveComment", func(t *testing.T) {
+		root := comment(t, author.ID, model.NewComment{PostID: &postID})
+		child := reply(t, replier.ID, root)
+		grandchild := reply(t, author.ID, child)
+
+		require.NoError(t, commentService.DeleteComment(ctx, child.ID, replier.ID))
+		require.NoError(t, commentService.DeleteComment(ctx, grandchild.ID, author.ID))
+		assertGone(t, child)
+
+		kept, err := commentService.GetComment(ctx, root.ID)
+		require.NoError(t, err)
+		assert.Nil(t, kept.DeletedAt)
+	})
+}
@@ -0,0 +1,49 @@
+package tests
+
+import (
+	"testing"
+
+	"LinganoGO/ent"
+	"LinganoGO/ent/comprehensionquestion"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreAnswer(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	boolPtr := func(b bool) *bool { return &b }
+	strPtr := func(s string) *string { return &s }
+
+	multipleChoice := &ent.ComprehensionQuestion{
+		Kind:          comprehensionquestion.KindMULTIPLE_CHOICE,
+		Choices:       []string{"Paris", "Lyon", "Nice"},
+		CorrectChoice: intPtr(1),
+	}
+	assert.True(t, services.ScoreAnswer(multipleChoice, model.ComprehensionAnswerInput{Choice: intPtr(1)}))
+	assert.False(t, services.ScoreAnswer(multipleChoice, model.ComprehensionAnswerInput{Choice: intPtr(0)}))
+	assert.False(t, services.ScoreAnswer(multipleChoice, model.ComprehensionAnswerInput{Text: strPtr("Lyon")}))
+
+	trueFalse := &ent.ComprehensionQuestion{
+		Kind:         comprehensionquestion.KindTRUE_FALSE,
+		CorrectValue: boolPtr(false),
+	}
+	assert.True(t, services.ScoreAnswer(trueFalse, model.ComprehensionAnswerInput{Value: boolPtr(false)}))
+	assert.False(t, services.ScoreAnswer(trueFalse, model.ComprehensionAnswerInput{Value: boolPtr(true)}))
+	assert.False(t, services.ScoreAnswer(trueFalse, model.ComprehensionAnswerInput{}))
+
+	shortAnswer := &ent.ComprehensionQuestion{
+		Kind:            comprehensionquestion.KindSHORT_ANSWER,
+		AcceptedAnswers: []string{"the moon", "Moon"},
+	}
+	assert.True(t, services.ScoreAnswer(shortAnswer, model.ComprehensionAnswerInput{Text: strPtr("  The   Moon. ")}))
+	assert.True(t, services.ScoreAnswer(shortAnswer, model.ComprehensionAnswerInput{Text: strPtr("moon!")}))
+	assert.False(t, services.ScoreAnswer(shortAnswer, model.ComprehensionAnswerInput{Text: strPtr("the sun")}))
+	assert.False(t, services.ScoreAnswer(shortAnswer, model.ComprehensionAnswerInput{Text: strPtr("...")}))
+}
+
+func TestAttemptPercentage(t *testing.T) {
+	assert.Equal(t, 75.0, services.AttemptPercentage(&ent.ComprehensionAttempt{Score: 3, MaxScore: 4}))
+	assert.Equal(t, 0.0, services.AttemptPercentage(&ent.ComprehensionAttempt{}))
+}
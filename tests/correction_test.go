@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"testing"
+
+	"LinganoGO/ent"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLanguages(t *testing.T) {
+	assert.Equal(t, []string{"en", "pt-br"}, services.NormalizeLanguages([]string{" EN", "pt-BR", "en", ""}))
+	assert.Empty(t, services.NormalizeLanguages(nil))
+}
+
+func TestBaseLanguages(t *testing.T) {
+	assert.Equal(t, []string{"pt", "en"}, services.BaseLanguages([]string{"pt-BR", " en_US", "pt", "PT_pt", ""}))
+	assert.Empty(t, services.BaseLanguages(nil))
+}
+
+func TestPostSentences(t *testing.T) {
+	language := "en"
+	post := &ent.Post{Body: "Yesterday I go to the park. It was fun!", Language: &language}
+
+	sentences := services.PostSentences(post)
+	assert.Len(t, sentences, 2)
+	assert.Equal(t, &model.PostSentence{Index: 1, Text: "It was fun!", Start: 28, End: 39}, sentences[1])
+
+	sentence, err := services.PostSentence(post, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Yesterday I go to the park.", sentence.Text)
+
+	_, err = services.PostSentence(post, 2)
+	assert.Error(t, err)
+	_, err = services.PostSentence(post, -1)
+	assert.Error(t, err)
+}
+
+func TestCorrectionDiff(t *testing.T) {
+	post := &ent.Post{}
+	correction := &ent.Correction{
+		Original:  "Yesterday I go to the park.",
+		Corrected: "Yesterday I went to the park.",
+	}
+
+	var deleted, inserted string
+	for _, chunk := range services.CorrectionDiff(correction, post) {
+		switch chunk.Kind {
+		case model.DiffKindDelete:
+			deleted += chunk.Text
+		case model.DiffKindInsert:
+			inserted += chunk.Text
+		}
+	}
+	assert.Equal(t, "go", deleted)
+	assert.Equal(t, "went", inserted)
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/course"
+	"LinganoGO/ent/courseenrollment"
+	"LinganoGO/ent/courseitem"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCourseCompletion(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	client := config.GetEntClient()
+	userService := services.NewUserService()
+	readingService := services.NewReadingService()
+	courseService := services.NewCourseService()
+	progressService := services.NewReadingProgressService()
+
+	suffix := uuid.NewString()
+	teacher, err := userService.CreateUser(ctx, "Teacher", "course-teacher-"+suffix+"@test.com", "password123")
+	require.NoError(t, err)
+
+	// newCourse publishes a course of the given readings.
+	newCourse := func(t *testing.T, readings ...*ent.Reading) *ent.Course {
+		t.Helper()
+		c, err := courseService.CreateCourse(ctx, model.NewCourse{UserID: teacher.ID.String(), Title: "Course " + suffix})
+		require.NoError(t, err)
+		for _, r := range readings {
+			readingID := r.ID.String()
+			_, err := courseService.AddItem(ctx, model.NewCourseItem{CourseID: c.ID.String(), UserID: teacher.ID.String(), Kind: courseitem.KindREADING, ReadingID: &readingID})
+			require.NoError(t, err)
+		}
+		published := course.StatePUBLISHED
+		c, err = courseService.UpdateCourse(ctx, c.ID, teacher.ID, model.UpdateCourse{State: &published})
+		require.NoError(t, err)
+		return c
+	}
+	newReading := func(t *testing.T) *ent.Reading {
+		t.Helper()
+		r, err := readingService.CreateReading(ctx, "Reading "+uuid.NewString(), teacher.ID, true)
+		require.NoError(t, err)
+		return r
+	}
+	newLearner := func(t *testing.T) *ent.User {
+		t.Helper()
+		u, err := userService.CreateUser(ctx, "Learner", "course-learner-"+uuid.NewString()+"@test.com", "password123")
+		require.NoError(t, err)
+		return u
+	}
+	finish := func(t *testing.T, learner *ent.User, r *ent.Reading) {
+		t.Helper()
+		finished := true
+		_, err := progressService.RecordProgress(ctx, model.ReadingProgressInput{
+			ReadingID: r.ID.String(),
+			UserID:    learner.ID.String(),
+			Finished:  &finished,
+		})
+		require.NoError(t, err)
+	}
+	enrollment := func(t *testing.T, c *ent.Course, learner *ent.User) *ent.CourseEnrollment {
+		t.Helper()
+		e, err := client.CourseEnrollment.
+			Query().
+			Where(courseenrollment.CourseID(c.ID), courseenrollment.UserID(learner.ID)).
+			Only(ctx)
+		require.NoError(t, err)
+		return e
+	}
+
+	t.Run("FinishingTheLastReadingCompletesTheCourse", func(t *testing.T) {
+		first, last := newReading(t), newReading(t)
+		c := newCourse(t, first, last)
+		learner := newLearner(t)
+		_, err := courseService.Enroll(ctx, c.ID, learner.ID)
+		require.NoError(t, err)
+
+		finish(t, learner, first)
+		assert.Nil(t, enrollment(t, c, learner).CompletedAt)
+
+		finish(t, learner, last)
+		assert.NotNil(t, enrollment(t, c, learner).CompletedAt)
+	})
+
+	t.Run("EnrollingAfterFinishingEveryReadingCompletesTheCourse", func(t *testing.T) {
+		r := newReading(t)
+		c := newCourse(t, r)
+		learner := newLearner(t)
+		finish(t, learner, r)
+
+		e, err := courseService.Enroll(ctx, c.ID, learner.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, e.CompletedAt)
+	})
+
+	t.Run("PublishedCoursesOnlyTakePublicReadings", func(t *testing.T) {
+		c := newCourse(t, newReading(t))
+		private, err := readingService.CreateReading(ctx, "Private "+suffix, teacher.ID, false)
+		require.NoError(t, err)
+
+		readingID := private.ID.String()
+		_, err = courseService.AddItem(ctx, model.NewCourseItem{CourseID: c.ID.String(), UserID: teacher.ID.String(), Kind: courseitem.KindREADING, ReadingID: &readingID})
+		assert.EqualError(t, err, "reading \"Private "+suffix+"\" must be public before it can be added to a published course")
+	})
+}
@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"LinganoGO/dictionary"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func tarGzArchive(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// starDict builds the .idx, .dict and .syn files for articles given in order.
+func starDict(articles [][2]string, synonyms map[string]uint32) (idx, dict, syn []byte) {
+	var idxBuf, dictBuf, synBuf bytes.Buffer
+	for _, article := range articles {
+		idxBuf.WriteString(article[0])
+		idxBuf.WriteByte(0)
+		binary.Write(&idxBuf, binary.BigEndian, uint32(dictBuf.Len()))
+		binary.Write(&idxBuf, binary.BigEndian, uint32(len(article[1])))
+		dictBuf.WriteString(article[1])
+	}
+	for form, i := range synonyms {
+		synBuf.WriteString(form)
+		synBuf.WriteByte(0)
+		binary.Write(&synBuf, binary.BigEndian, i)
+	}
+	return idxBuf.Bytes(), dictBuf.Bytes(), synBuf.Bytes()
+}
+
+func TestParseStarDict(t *testing.T) {
+	idx, dict, syn := starDict([][2]string{
+		{"casa", "house\nhome"},
+		{"perro", "dog"},
+	}, map[string]uint32{"casas": 0})
+
+	var dz bytes.Buffer
+	gz := gzip.NewWriter(&dz)
+	gz.Write(dict)
+	gz.Close()
+
+	data := tarGzArchive(t, map[string][]byte{
+		"es-en/es-en.ifo":     []byte("StarDict's dict ifo file\nversion=2.4.2\nbookname=Spanish-English\nwordcount=2\nsametypesequence=m\n"),
+		"es-en/es-en.idx":     idx,
+		"es-en/es-en.dict.dz": dz.Bytes(),
+		"es-en/es-en.syn":     syn,
+	})
+
+	format, err := dictionary.DetectFormat(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, dictionary.FormatStarDict, format)
+
+	parsed, err := dictionary.Parse(bytes.NewReader(data), int64(len(data)), format)
+	require.NoError(t, err)
+	assert.Equal(t, "Spanish-English", parsed.Title)
+	assert.Equal(t, []dictionary.Entry{
+		{Headword: "casa", Definitions: []string{"house", "home"}},
+		{Headword: "perro", Definitions: []string{"dog"}},
+	}, parsed.Entries)
+	assert.Equal(t, []dictionary.Inflection{{Form: "casas", Lemma: "casa"}}, parsed.Inflections)
+}
+
+func TestParseStarDictTypedFields(t *testing.T) {
+	// Without a sametypesequence each field starts with its type: a phonetic
+	// transcription and an XDXF article here.
+	idx, dict, _ := starDict([][2]string{
+		{"run", "t/rʌn/\x00x<k>run</k><pos>verb</pos> to move fast<br/>to operate<ex>The engine runs well.</ex>\x00"},
+	}, nil)
+
+	data := zipArchive(t, map[string]string{
+		"en.ifo":  "StarDict's dict ifo file\nbookname=English\n",
+		"en.idx":  string(idx),
+		"en.dict": string(dict),
+	})
+
+	parsed, err := dictionary.Parse(bytes.NewReader(data), int64(len(data)), dictionary.FormatStarDict)
+	require.NoError(t, err)
+	require.Len(t, parsed.Entries, 1)
+	assert.Equal(t, dictionary.Entry{
+		Headword:     "run",
+		Reading:      "/rʌn/",
+		PartOfSpeech: "verb",
+		Definitions:  []string{"to move fast", "to operate"},
+		Examples:     []string{"The engine runs well."},
+	}, parsed.Entries[0])
+}
+
+func TestParseYomichan(t *testing.T) {
+	data := zipArchive(t, map[string]string{
+		"index.json": `{"title": "JMdict (English)", "format": 3, "revision": "1"}`,
+		"term_bank_1.json": `[
+			["食べる", "たべる", "v1 vt", "v1", 100, [
+				"to eat",
+				{"type": "structured-content", "content": [
+					{"tag": "ul", "content": [{"tag": "li", "content": "to live on"}]},
+					{"tag": "div", "data": {"content": "example-sentence"}, "content": [
+						{"tag": "div", "content": "ご飯を食べる。"},
+						{"tag": "div", "content": "to eat rice"}
+					]}
+				]}
+			], 1358280, "P"],
+			["食べた", "たべた", "", "", 0, [["食べる", ["past"]]], 0, ""]
+		]`,
+	})
+
+	format, err := dictionary.DetectFormat(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, dictionary.FormatYomichan, format)
+
+	parsed, err := dictionary.Parse(bytes.NewReader(data), int64(len(data)), format)
+	require.NoError(t, err)
+	assert.Equal(t, "JMdict (English)", parsed.Title)
+	assert.Equal(t, []dictionary.Entry{{
+		Headword:     "食べる",
+		Reading:      "たべる",
+		PartOfSpeech: "v1 vt",
+		Definitions:  []string{"to eat", "to live on"},
+		Examples:     []string{"ご飯を食べる。 to eat rice"},
+	}}, parsed.Entries)
+	assert.Equal(t, []dictionary.Inflection{{Form: "食べた", Lemma: "食べる"}}, parsed.Inflections)
+}
+
+func TestParseTSVDictionary(t *testing.T) {
+	data := []byte("# Spanish-English\n" +
+		"banco\tbank | bench\tnoun\tFui al banco.\n" +
+		"banco\tshoal\tnoun\n" +
+		"correr\tto run\tverb\n" +
+		"vacío\t\n")
+
+	format, err := dictionary.DetectFormat(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, dictionary.FormatTSV, format)
+
+	parsed, err := dictionary.Parse(bytes.NewReader(data), int64(len(data)), format)
+	require.NoError(t, err)
+	assert.Equal(t, []dictionary.Entry{
+		{Headword: "banco", PartOfSpeech: "noun", Definitions: []string{"bank", "bench", "shoal"}, Examples: []string{"Fui al banco."}},
+		{Headword: "correr", PartOfSpeech: "verb", Definitions: []string{"to run"}},
+	}, parsed.Entries)
+
+	_, err = dictionary.DetectFormat(bytes.NewReader([]byte{0x00, 0x01, 0xff}), 3)
+	assert.ErrorIs(t, err, dictionary.ErrUnsupportedFormat)
+}
+
+func TestParseInflections(t *testing.T) {
+	inflections, err := dictionary.ParseInflections(strings.NewReader("# form\tlemma\tTags\nfue\tir\tV;PST\nfue\tser\nser\tser\nbroken line\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []dictionary.Inflection{
+		{Form: "fue", Lemma: "ir"},
+		{Form: "fue", Lemma: "ser"},
+	}, inflections)
+}
+
+func TestLemmas(t *testing.T) {
+	tests := []struct {
+		word, language, lemma string
+	}{
+		{"running", "en", "run"},
+		{"studies", "en", "study"},
+		{"knives", "en", "knife"},
+		{"baked", "en", "bake"},
+		{"went", "en", "go"},
+		{"hablando", "es", "hablar"},
+		{"comieron", "es", "comer"},
+		{"luces", "es", "luz"},
+		{"chevaux", "fr", "cheval"},
+		{"finissons", "fr", "finir"},
+		{"gemacht", "de", "machen"},
+		{"parlato", "it", "parlare"},
+		{"canções", "pt-br", "canção"},
+	}
+	for _, tt := range tests {
+		assert.Contains(t, dictionary.Lemmas(tt.word, tt.language), tt.lemma, tt.word)
+	}
+
+	assert.NotContains(t, dictionary.Lemmas("run", "en"), "run")
+	assert.Empty(t, dictionary.Lemmas("猫", "ja"))
+}
+
+func TestLemmasTryPluralsFirst(t *testing.T) {
+	tests := []struct {
+		word, language string
+		lemmas         []string
+	}{
+		{"casas", "es", []string{"casa", "caso", "casar"}},
+		{"chicas", "es", []string{"chica", "chico", "chicar"}},
+		{"flores", "es", []string{"flore", "flor"}},
+		{"livres", "fr", []string{"livre", "livrer"}},
+		{"casas", "pt-br", []string{"casa", "caso", "casar"}},
+	}
+	for _, tt := range tests {
+		lemmas := dictionary.Lemmas(tt.word, tt.language)
+		require.GreaterOrEqual(t, len(lemmas), len(tt.lemmas), tt.word)
+		assert.Equal(t, tt.lemmas, lemmas[:len(tt.lemmas)], tt.word)
+	}
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"testing"
+
+	"LinganoGO/cefr"
+	"LinganoGO/estimator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateTextOrdersTextsByDifficulty(t *testing.T) {
+	simple, ok := estimator.EstimateText("My name is Tom. I have a dog. The dog is big. I like my dog. We go to the park every day.", "en")
+	require.True(t, ok)
+
+	academic, ok := estimator.EstimateText("Notwithstanding the considerable methodological heterogeneity among the aforementioned longitudinal investigations, the meta-analytic synthesis unequivocally corroborates the hypothesised association between socioeconomic deprivation and cardiovascular morbidity.", "en")
+	require.True(t, ok)
+
+	assert.Less(t, simple.Score, academic.Score)
+	assert.LessOrEqual(t, simple.Level.Rank(), cefr.A2.Rank())
+	assert.GreaterOrEqual(t, academic.Level.Rank(), cefr.C1.Rank())
+}
+
+func TestEstimateTextWithoutWords(t *testing.T) {
+	_, ok := estimator.EstimateText(" 42 — !", "en")
+
+	assert.False(t, ok)
+}
+
+func TestFrequencyRanks(t *testing.T) {
+	assert.True(t, estimator.HasFrequencyList("en-GB"))
+	assert.Equal(t, 1, estimator.Rank("en", "the"))
+	assert.Equal(t, 0, estimator.Rank("en", "heterogeneity"))
+	assert.Greater(t, estimator.Rank("de", "haus"), 0)
+	assert.False(t, estimator.HasFrequencyList("xx"))
+}
+
+func TestScoreForLevel(t *testing.T) {
+	for _, level := range cefr.All {
+		assert.Equal(t, level, estimator.LevelForScore(estimator.ScoreForLevel(level)), level)
+	}
+}
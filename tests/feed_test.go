@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/course"
+	"LinganoGO/ent/courseitem"
+	"LinganoGO/ent/reaction"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedCursor(t *testing.T) {
+	following := services.FeedCursor{
+		Time: time.Date(2026, 10, 19, 8, 30, 0, 123456000, time.UTC),
+		ID:   uuid.New(),
+	}
+	decoded, err := services.DecodeFeedCursor(services.EncodeFeedCursor(following))
+	assert.NoError(t, err)
+	assert.False(t, decoded.Popular)
+	assert.True(t, following.Time.Equal(decoded.Time))
+	assert.Equal(t, following.ID, decoded.ID)
+
+	popular := services.FeedCursor{Popular: true, Offset: 40}
+	decoded, err = services.DecodeFeedCursor(services.EncodeFeedCursor(popular))
+	assert.NoError(t, err)
+	assert.Equal(t, popular, decoded)
+}
+
+func TestDecodeFeedCursorRejectsGarbage(t *testing.T) {
+	for _, raw := range []string{"", "popular", "popular:-1", "popular:x", "following:1", "following:x:" + uuid.NewString(), "following:1:not-a-uuid"} {
+		_, err := services.DecodeFeedCursor(base64.RawURLEncoding.EncodeToString([]byte(raw)))
+		assert.Error(t, err, raw)
+	}
+
+	_, err := services.DecodeFeedCursor("not base64!")
+	assert.Error(t, err)
+}
+
+func TestFeedPaging(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	client := config.GetEntClient()
+	userService := services.NewUserService()
+	postService := services.NewPostService()
+	readingService := services.NewReadingService()
+	courseService := services.NewCourseService()
+	commentService := services.NewCommentService()
+	followService := services.NewFollowService()
+	reactionService := services.NewReactionService()
+	feedService := services.NewFeedService()
+
+	suffix := uuid.NewString()
+	newUser := func(t *testing.T, name string) *ent.User {
+		t.Helper()
+		u, err := userService.CreateUser(ctx, name, strings.ToLower(name)+"-"+suffix+"@test.com", "password123")
+		require.NoError(t, err)
+		return u
+	}
+	newPost := func(t *testing.T, author *ent.User, language *string) *ent.Post {
+		t.Helper()
+		p, err := postService.CreatePost(ctx, model.NewPost{Body: "Post " + uuid.NewString(), UserID: author.ID.String(), Language: language})
+		require.NoError(t, err)
+		return p
+	}
+	newReading := func(t *testing.T, author *ent.User) *ent.Reading {
+		t.Helper()
+		r, err := readingService.CreateReading(ctx, "Reading "+uuid.NewString(), author.ID, true)
+		require.NoError(t, err)
+		return r
+	}
+
+	// pageThrough reads a user's whole feed two items at a time and returns
+	// the IDs of the items in feed order, failing on any repeat.
+	pageThrough := func(t *testing.T, userID uuid.UUID, popular bool) []uuid.UUID {
+		t.Helper()
+		var (
+			ids   []uuid.UUID
+			after *string
+		)
+		seen := make(map[uuid.UUID]bool)
+		for range 50 {
+			page, err := feedService.GetFeed(ctx, userID, after, 2)
+			require.NoError(t, err)
+			assert.Equal(t, popular, page.Popular)
+			assert.LessOrEqual(t, len(page.Items), 2)
+			for _, item := range page.Items {
+				id := feedItemID(item)
+				require.False(t, seen[id], "feed item %s repeated", id)
+				seen[id] = true
+				ids = append(ids, id)
+			}
+			if !page.HasNextPage {
+				return ids
+			}
+			require.NotNil(t, page.EndCursor)
+			after = page.EndCursor
+		}
+		t.Fatal("feed did not end")
+		return nil
+	}
+
+	t.Run("FollowingFeedMergesEverySource", func(t *testing.T) {
+		viewer, writer, teacher := newUser(t, "Viewer"), newUser(t, "Writer"), newUser(t, "Teacher")
+		for _, followee := range []*ent.User{writer, teacher} {
+			_, err := followService.Follow(ctx, viewer.ID, followee.ID)
+			require.NoError(t, err)
+		}
+
+		var want []uuid.UUID
+		var readings []*ent.Reading
+		for range 3 {
+			want = append(want, newPost(t, writer, nil).ID)
+			r := newReading(t, teacher)
+			readings = append(readings, r)
+			want = append(want, r.ID)
+		}
+
+		c, err := courseService.CreateCourse(ctx, model.NewCourse{UserID: teacher.ID.String(), Title: "Course " + suffix})
+		require.NoError(t, err)
+		readingID := readings[0].ID.String()
+		_, err = courseService.AddItem(ctx, model.NewCourseItem{CourseID: c.ID.String(), UserID: teacher.ID.String(), Kind: courseitem.KindREADING, ReadingID: &readingID})
+		require.NoError(t, err)
+		published := course.StatePUBLISHED
+		_, err = courseService.UpdateCourse(ctx, c.ID, teacher.ID, model.UpdateCourse{State: &published})
+		require.NoError(t, err)
+		want = append(want, c.ID)
+
+		for _, r := range readings[1:] {
+			target := r.ID.String()
+			cm, err := commentService.CreateComment(ctx, model.NewComment{UserID: writer.ID.String(), ReadingID: &target, Body: "Nice reading"})
+			require.NoError(t, err)
+			want = append(want, cm.ID)
+		}
+
+		got := pageThrough(t, viewer.ID, false)
+		assert.ElementsMatch(t, want, got)
+	})
+
+	t.Run("PopularFeedPagesByOffset", func(t *testing.T) {
+		// A language of its own keeps other content out of the ranking.
+		language := "x-" + suffix[:8]
+		viewer, author := newUser(t, "Reader"), newUser(t, "Author")
+		_, err := userService.SetTargetLanguage(ctx, viewer.ID, &language)
+		require.NoError(t, err)
+
+		var targets []services.Target
+		var want []uuid.UUID
+		for range 4 {
+			p := newPost(t, author, &language)
+			targets = append(targets, services.PostTarget(p.ID))
+			want = append(want, p.ID)
+		}
+		for range 3 {
+			r := newReading(t, author)
+			require.NoError(t, client.Reading.UpdateOneID(r.ID).SetLanguage(language).Exec(ctx))
+			targets = append(targets, services.ReadingTarget(r.ID))
+			want = append(want, r.ID)
+		}
+
+		// Scores of 3, 2, 1 and 0 reactions, with ties between posts and
+		// readings that the ranking has to break the same way on every page.
+		scores := make(map[uuid.UUID]int)
+		fans := []*ent.User{newUser(t, "Fan1"), newUser(t, "Fan2"), newUser(t, "Fan3")}
+		for i, target := range targets {
+			score := 3 - i%4
+			for _, fan := range fans[:score] {
+				_, err := reactionService.ToggleReaction(ctx, fan.ID, target, reaction.KindLIKE)
+				require.NoError(t, err)
+			}
+			scores[want[i]] = score
+		}
+
+		got := pageThrough(t, viewer.ID, true)
+		assert.ElementsMatch(t, want, got)
+		for i := 1; i < len(got); i++ {
+			assert.GreaterOrEqual(t, scores[got[i-1]], scores[got[i]], "popular feed out of order")
+		}
+	})
+}
+
+// feedItemID returns the ID of the post, reading, course or comment a feed
+// item is about.
+func feedItemID(item *model.FeedItem) uuid.UUID {
+	switch {
+	case item.Comment != nil:
+		return item.Comment.ID
+	case item.Post != nil:
+		return item.Post.ID
+	case item.Reading != nil:
+		return item.Reading.ID
+	case item.Course != nil:
+		return item.Course.ID
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"LinganoGO/importer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/article.html")
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/article", http.StatusFound)
+	})
+	mux.HandleFunc("/latin1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=iso-8859-1")
+		w.Write([]byte("Caf\xe9 con leche."))
+	})
+	mux.HandleFunc("/huge", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<p>" + strings.Repeat("a", importer.DefaultMaxPageSize) + "</p>"))
+	})
+	mux.HandleFunc("/latin1-large", func(w http.ResponseWriter, r *http.Request) {
+		// Under the limit as sent, but twice as long in UTF-8.
+		w.Header().Set("Content-Type", "text/plain; charset=iso-8859-1")
+		w.Write([]byte(strings.Repeat("Caf\xe9 ", importer.DefaultMaxPageSize/5-1)))
+	})
+	mux.HandleFunc("/utf16-huge", func(w http.ResponseWriter, r *http.Request) {
+		// Over the limit as sent, but half as long in UTF-8.
+		w.Header().Set("Content-Type", "text/plain; charset=utf-16le")
+		w.Write([]byte(strings.Repeat("a\x00", importer.DefaultMaxPageSize/2+1)))
+	})
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchExtractsMainArticle(t *testing.T) {
+	server := newFixtureServer(t)
+	fetcher, err := importer.NewFetcher([]string{"127.0.0.1", "::1"})
+	require.NoError(t, err)
+
+	doc, err := fetcher.Fetch(context.Background(), server.URL+"/moved")
+	require.NoError(t, err)
+
+	assert.Equal(t, "El regreso de las golondrinas", doc.Title)
+	assert.Equal(t, "Lucía Martín", doc.Author)
+	assert.Equal(t, "es", doc.Language)
+	assert.Equal(t, server.URL+"/article", doc.SourceURL)
+
+	require.Len(t, doc.Chapters, 1)
+	body := doc.Chapters[0].Body
+	assert.True(t, strings.HasPrefix(body, "El regreso de las golondrinas\n\nCada primavera"), body)
+	assert.Contains(t, body, "tormentas de arena en el norte de África.")
+	for _, furniture := range []string{"Política", "Lo más leído", "Pepe", "derechos reservados", "analytics"} {
+		assert.NotContains(t, body, furniture)
+	}
+}
+
+func TestFetchDecodesCharset(t *testing.T) {
+	server := newFixtureServer(t)
+	fetcher, err := importer.NewFetcher([]string{"127.0.0.0/8"})
+	require.NoError(t, err)
+
+	doc, err := fetcher.Fetch(context.Background(), server.URL+"/latin1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Café con leche.", doc.Chapters[0].Body)
+}
+
+func TestFetchBlocksPrivateAddresses(t *testing.T) {
+	server := newFixtureServer(t)
+	fetcher, err := importer.NewFetcher(nil)
+	require.NoError(t, err)
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/article")
+	assert.ErrorIs(t, err, importer.ErrBlockedAddress)
+
+	_, err = fetcher.Fetch(context.Background(), "http://169.254.169.254/latest/meta-data/")
+	assert.ErrorIs(t, err, importer.ErrBlockedAddress)
+
+	_, err = fetcher.Fetch(context.Background(), "file:///etc/passwd")
+	assert.Error(t, err)
+}
+
+func TestFetchRejectsLargeAndNonTextPages(t *testing.T) {
+	server := newFixtureServer(t)
+	fetcher, err := importer.NewFetcher([]string{"127.0.0.1", "::1"})
+	require.NoError(t, err)
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/huge")
+	assert.ErrorIs(t, err, importer.ErrPageTooLarge)
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/utf16-huge")
+	assert.ErrorIs(t, err, importer.ErrPageTooLarge, "the limit applies to the bytes sent")
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/image")
+	assert.ErrorIs(t, err, importer.ErrUnsupportedFormat)
+}
+
+func TestFetchLimitsBytesSentNotDecoded(t *testing.T) {
+	server := newFixtureServer(t)
+	fetcher, err := importer.NewFetcher([]string{"127.0.0.1", "::1"})
+	require.NoError(t, err)
+
+	doc, err := fetcher.Fetch(context.Background(), server.URL+"/latin1-large")
+	require.NoError(t, err)
+	require.Len(t, doc.Chapters, 1)
+	assert.True(t, strings.HasPrefix(doc.Chapters[0].Body, "Café Café"))
+}
+
+func TestNewFetcherRejectsInvalidAllowlist(t *testing.T) {
+	_, err := importer.NewFetcher([]string{"not-an-ip"})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,213 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+	"time"
+
+	"LinganoGO/importer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		filename    string
+		contentType string
+		data        string
+		want        importer.Format
+	}{
+		{"book.epub", "", "PK\x03\x04", importer.FormatEPUB},
+		{"page.HTM", "", "<p>hi</p>", importer.FormatHTML},
+		{"notes.md", "", "# Notes", importer.FormatMarkdown},
+		{"upload", "application/pdf", "", importer.FormatPDF},
+		{"upload", "", "%PDF-1.4", importer.FormatPDF},
+		{"upload", "", "WEBVTT\n\n", importer.FormatVTT},
+		{"film.srt", "", "1\n", importer.FormatSRT},
+		{"upload", "", "plain words", importer.FormatText},
+	}
+	for _, tt := range tests {
+		got, err := importer.DetectFormat(tt.filename, tt.contentType, []byte(tt.data))
+		require.NoError(t, err, tt.filename)
+		assert.Equal(t, tt.want, got, tt.filename)
+	}
+
+	_, err := importer.DetectFormat("image", "", []byte{0xff, 0xd8, 0xff, 0xe0})
+	assert.ErrorIs(t, err, importer.ErrUnsupportedFormat)
+}
+
+func TestExtractHTMLDropsScriptsAndKeepsParagraphs(t *testing.T) {
+	page := `<html lang="es"><head><title>Un cuento</title><script>alert("x")</script></head>
+<body><nav>Menu</nav><p>Había una vez
+  un gato.</p><p>Fin.</p></body></html>`
+
+	doc, err := importer.Extract([]byte(page), importer.FormatHTML, "cuento.html")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Un cuento", doc.Title)
+	assert.Equal(t, "es", doc.Language)
+	require.Len(t, doc.Chapters, 1)
+	assert.Equal(t, "Había una vez un gato.\n\nFin.", doc.Chapters[0].Body)
+	assert.NotContains(t, doc.Chapters[0].Body, "alert")
+}
+
+func TestExtractMarkdownUsesFirstHeadingAsTitle(t *testing.T) {
+	doc, err := importer.Extract([]byte("# Der Hund\r\n\r\nDer Hund ist **groß**.\r\n"), importer.FormatMarkdown, "hund.md")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Der Hund", doc.Title)
+	require.Len(t, doc.Chapters, 1)
+	assert.True(t, doc.Chapters[0].Markdown)
+	assert.Equal(t, "# Der Hund\n\nDer Hund ist **groß**.", doc.Chapters[0].Body)
+}
+
+func TestExtractTextFallsBackToFilenameTitle(t *testing.T) {
+	doc, err := importer.Extract([]byte("Hello there."), importer.FormatText, "uploads/greeting.txt")
+	require.NoError(t, err)
+
+	assert.Equal(t, "greeting", doc.Title)
+	assert.Equal(t, "greeting", doc.Chapters[0].Title)
+}
+
+func TestExtractEmptyDocument(t *testing.T) {
+	_, err := importer.Extract([]byte("<html><body> </body></html>"), importer.FormatHTML, "empty.html")
+
+	assert.ErrorIs(t, err, importer.ErrNoText)
+}
+
+func TestExtractEPUBKeepsSpineOrder(t *testing.T) {
+	doc, err := importer.Extract(buildEPUB(t), importer.FormatEPUB, "book.epub")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Le Petit Livre", doc.Title)
+	assert.Equal(t, "Anne Auteur", doc.Author)
+	assert.Equal(t, "fr", doc.Language)
+	require.Len(t, doc.Chapters, 2)
+	assert.Equal(t, "Chapitre un", doc.Chapters[0].Title)
+	assert.Equal(t, "Chapitre un\n\nLe début.", doc.Chapters[0].Body)
+	assert.Equal(t, "Chapitre deux", doc.Chapters[1].Title)
+	assert.Equal(t, "Chapitre deux\n\nLa fin.", doc.Chapters[1].Body)
+}
+
+func TestParseSubtitles(t *testing.T) {
+	srt := "1\r\n00:00:01,000 --> 00:00:02,500\r\n<i>Hello</i> there\r\n\r\n2\r\n00:00:03,000 --> 00:00:04,000\r\nGeneral Kenobi\r\n"
+
+	cues, err := importer.ParseSubtitles([]byte(srt), importer.FormatSRT)
+	require.NoError(t, err)
+
+	require.Len(t, cues, 2)
+	assert.Equal(t, importer.Cue{Start: time.Second, End: 2500 * time.Millisecond, Text: "Hello there"}, cues[0])
+	assert.Equal(t, "General Kenobi", cues[1].Text)
+
+	vtt := "WEBVTT\n\nNOTE a comment\n\n00:01.000 --> 00:02.000 align:start\nBonjour\n"
+	cues, err = importer.ParseSubtitles([]byte(vtt), importer.FormatVTT)
+	require.NoError(t, err)
+
+	require.Len(t, cues, 1)
+	assert.Equal(t, time.Second, cues[0].Start)
+	assert.Equal(t, "Bonjour", cues[0].Text)
+}
+
+func TestExtractPDFTextLayer(t *testing.T) {
+	content := "BT /F1 12 Tf 72 720 Td (Hello ) Tj [(wor) -20 (ld) -300 (again)] TJ 0 -14 Td (Second \\(line\\)) Tj ET"
+
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	_, err := w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n1 0 obj << /Title (A \\(tiny\\) PDF) >> endobj\n")
+	fmt.Fprintf(&pdf, "2 0 obj << /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+	pdf.Write(compressed.Bytes())
+	pdf.WriteString("\nendstream\nendobj\n%%EOF\n")
+
+	doc, err := importer.Extract(pdf.Bytes(), importer.FormatPDF, "tiny.pdf")
+	require.NoError(t, err)
+
+	assert.Equal(t, "A (tiny) PDF", doc.Title)
+	require.Len(t, doc.Chapters, 1)
+	assert.Equal(t, "Hello world again\nSecond (line)", doc.Chapters[0].Body)
+}
+
+func TestExtractPDFWithToUnicodeCMap(t *testing.T) {
+	cmap := "/CIDInit /ProcSet findresource begin 12 dict begin begincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"1 begincodespacerange <0000> <FFFF> endcodespacerange\n" +
+		"2 beginbfchar <0003> <0020> <0011> <00E9> endbfchar\n" +
+		"1 beginbfrange <0024> <0026> <0061> endbfrange\n" +
+		"1 beginbfrange <0030> <0031> [<0074> <00FC>] endbfrange\n" +
+		"endcmap CMapName currentdict /CMap defineresource pop end end"
+	content := "BT /F1 12 Tf 72 720 Td <002400250011> Tj [<0003>] TJ <00300031> Tj ET"
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.7\n")
+	pdf.WriteString("1 0 obj << /Type /Page /Resources << /Font << /F1 2 0 R >> >> /Contents 4 0 R >> endobj\n")
+	pdf.WriteString("2 0 obj << /Type /Font /Subtype /Type0 /Encoding /Identity-H /ToUnicode 3 0 R >> endobj\n")
+	fmt.Fprintf(&pdf, "3 0 obj << /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(cmap), cmap)
+	fmt.Fprintf(&pdf, "4 0 obj << /Length %d >>\nstream\n%s\nendstream\nendobj\n%%%%EOF\n", len(content), content)
+
+	doc, err := importer.Extract(pdf.Bytes(), importer.FormatPDF, "cmap.pdf")
+	require.NoError(t, err)
+
+	require.Len(t, doc.Chapters, 1)
+	assert.Equal(t, "abé tü", doc.Chapters[0].Body)
+}
+
+func TestExtractPDFWithUndecodableFont(t *testing.T) {
+	content := "BT /F1 12 Tf 72 720 Td <00240025001100120013> Tj ET"
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.7\n")
+	pdf.WriteString("1 0 obj << /Type /Page /Resources << /Font << /F1 2 0 R >> >> /Contents 3 0 R >> endobj\n")
+	pdf.WriteString("2 0 obj << /Type /Font /Subtype /Type0 /Encoding /Identity-H >> endobj\n")
+	fmt.Fprintf(&pdf, "3 0 obj << /Length %d >>\nstream\n%s\nendstream\nendobj\n%%%%EOF\n", len(content), content)
+
+	_, err := importer.Extract(pdf.Bytes(), importer.FormatPDF, "identity.pdf")
+	assert.ErrorIs(t, err, importer.ErrUndecodableText)
+}
+
+func buildEPUB(t *testing.T) []byte {
+	t.Helper()
+
+	files := []struct{ name, body string }{
+		{"mimetype", "application/epub+zip"},
+		{"META-INF/container.xml", `<?xml version="1.0"?>
+<container xmlns="urn:oasis:names:tc:opendocument:xmlns:container" version="1.0">
+  <rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles>
+</container>`},
+		{"OEBPS/content.opf", `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Le Petit Livre</dc:title>
+    <dc:creator>Anne Auteur</dc:creator>
+    <dc:language>fr</dc:language>
+  </metadata>
+  <manifest>
+    <item id="c2" href="text/two.xhtml" media-type="application/xhtml+xml"/>
+    <item id="c1" href="text/one.xhtml" media-type="application/xhtml+xml"/>
+    <item id="css" href="style.css" media-type="text/css"/>
+  </manifest>
+  <spine><itemref idref="c1"/><itemref idref="c2"/></spine>
+</package>`},
+		{"OEBPS/text/one.xhtml", `<html><body><h1>Chapitre un</h1><p>Le début.</p></body></html>`},
+		{"OEBPS/text/two.xhtml", `<html><body><h1>Chapitre deux</h1><p>La fin.</p></body></html>`},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(f.body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
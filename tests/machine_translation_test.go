@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"LinganoGO/config"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMachineTranslateReading(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	suffix := uuid.NewString()
+
+	owner, err := services.NewUserService().CreateUser(ctx, "Translator", "machine-"+suffix+"@test.com", "password123")
+	require.NoError(t, err)
+
+	// Longer than one provider request, so it is sent in chunks.
+	var body strings.Builder
+	for i := range 40 {
+		fmt.Fprintf(&body, "Sentence %d is about %s. ", i, strings.Repeat("words ", 40))
+	}
+	text, language := body.String(), "en"
+	reading, err := services.NewReadingService().CreateReadingWithContent(ctx, model.NewReading{
+		Title:    "Machine " + suffix,
+		UserID:   owner.ID.String(),
+		Body:     &text,
+		Language: &language,
+	})
+	require.NoError(t, err)
+
+	t.Run("TranslatesEverySentence", func(t *testing.T) {
+		t.Setenv("TRANSLATOR", "fake")
+		pairs, err := services.NewSentenceService().MachineTranslateReading(ctx, reading.ID, owner.ID, "es")
+		require.NoError(t, err)
+		require.Len(t, pairs, 40)
+		for _, pair := range pairs {
+			require.NotNil(t, pair.Translation, pair.Sentence.Text)
+			assert.Equal(t, "[es] "+pair.Sentence.Text, pair.Translation.Text)
+		}
+	})
+
+	t.Run("RequiresAnExistingUser", func(t *testing.T) {
+		t.Setenv("TRANSLATOR", "fake")
+		_, err := services.NewSentenceService().MachineTranslateReading(ctx, reading.ID, uuid.New(), "fr")
+		assert.EqualError(t, err, "user not found")
+	})
+
+	t.Run("RefusesPersonalProviders", func(t *testing.T) {
+		t.Setenv("TRANSLATOR", "glossary")
+		_, err := services.NewSentenceService().MachineTranslateReading(ctx, reading.ID, owner.ID, "de")
+		assert.ErrorIs(t, err, services.ErrPersonalTranslator)
+	})
+}
@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePublishAt(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	assert.NoError(t, services.ValidatePublishAt(true, nil, now))
+	assert.NoError(t, services.ValidatePublishAt(false, nil, now))
+	assert.NoError(t, services.ValidatePublishAt(true, &later, now))
+
+	// Only drafts wait for a publish time, and it has to be ahead of now.
+	assert.Error(t, services.ValidatePublishAt(false, &later, now))
+	assert.Error(t, services.ValidatePublishAt(true, &earlier, now))
+	assert.Error(t, services.ValidatePublishAt(true, &now, now))
+}
+
+func TestPosts(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	client := config.GetEntClient()
+	userService := services.NewUserService()
+	postService := services.NewPostService()
+
+	suffix := uuid.NewString()
+	newUser := func(t *testing.T, name string) *ent.User {
+		t.Helper()
+		u, err := userService.CreateUser(ctx, name, strings.ToLower(name)+"-posts-"+suffix+"@test.com", "password123")
+		require.NoError(t, err)
+		return u
+	}
+	author, reader := newUser(t, "Author"), newUser(t, "Reader")
+
+	newPost := func(t *testing.T, draft bool, publishAt *time.Time) *ent.Post {
+		t.Helper()
+		input := model.NewPost{Body: "Post " + uuid.NewString(), UserID: author.ID.String(), Draft: draft}
+		if publishAt != nil {
+			at := publishAt.Format(time.RFC3339)
+			input.PublishAt = &at
+		}
+		p, err := postService.CreatePost(ctx, input)
+		require.NoError(t, err)
+		return p
+	}
+	reload := func(t *testing.T, p *ent.Post) *ent.Post {
+		t.Helper()
+		p, err := client.Post.Get(ctx, p.ID)
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("PublishDuePosts", func(t *testing.T) {
+		soon, later := time.Now().Add(time.Hour), time.Now().Add(3*time.Hour)
+		due, notDue := newPost(t, true, &soon), newPost(t, true, &later)
+
+		now := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+		n, err := postService.PublishDuePosts(ctx, now)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, n, 1)
+
+		due = reload(t, due)
+		assert.False(t, due.Draft)
+		require.NotNil(t, due.PublishedAt)
+		assert.True(t, now.Equal(*due.PublishedAt))
+		assert.Nil(t, due.PublishAt, "the schedule is cleared once published")
+
+		notDue = reload(t, notDue)
+		assert.True(t, notDue.Draft)
+		assert.NotNil(t, notDue.PublishAt)
+	})
+
+	t.Run("SchedulerPublishesDuePosts", func(t *testing.T) {
+		// Publish times are sent with second precision.
+		at := time.Now().Add(2 * time.Second)
+		scheduled := newPost(t, true, &at)
+		time.Sleep(time.Until(at.Truncate(time.Second).Add(time.Second)))
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go postService.RunScheduler(ctx)
+
+		assert.Eventually(t, func() bool {
+			return !reload(t, scheduled).Draft
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	t.Run("UpdatePostMovesBetweenDraftAndPublished", func(t *testing.T) {
+		p := newPost(t, false, nil)
+		require.NotNil(t, p.PublishedAt)
+
+		p, err := postService.UpdatePost(ctx, p.ID, author.ID, p.Body, true, nil, nil)
+		require.NoError(t, err)
+		assert.True(t, p.Draft)
+		assert.Nil(t, p.PublishedAt, "turning a post into a draft unpublishes it")
+
+		at := time.Now().Add(time.Hour)
+		p, err = postService.UpdatePost(ctx, p.ID, author.ID, p.Body, true, &at, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, p.PublishAt)
+
+		p, err = postService.UpdatePost(ctx, p.ID, author.ID, "Edited", false, nil, nil)
+		require.NoError(t, err)
+		assert.False(t, p.Draft)
+		assert.Equal(t, "Edited", p.Body)
+		assert.NotNil(t, p.PublishedAt)
+		assert.Nil(t, p.PublishAt, "publishing a draft clears its schedule")
+
+		_, err = postService.UpdatePost(ctx, p.ID, author.ID, p.Body, false, &at, nil)
+		assert.EqualError(t, err, "failed to update post: only drafts can be scheduled for publishing")
+	})
+
+	t.Run("OnlyTheAuthorChangesPosts", func(t *testing.T) {
+		p := newPost(t, false, nil)
+
+		_, err := postService.UpdatePost(ctx, p.ID, reader.ID, "Hijacked", false, nil, nil)
+		assert.ErrorIs(t, err, services.ErrForbidden)
+		assert.ErrorIs(t, postService.DeletePost(ctx, p.ID, reader.ID), services.ErrForbidden)
+		assert.Equal(t, p.Body, reload(t, p).Body)
+
+		require.NoError(t, postService.DeletePost(ctx, p.ID, author.ID))
+		_, err = client.Post.Get(ctx, p.ID)
+		assert.True(t, ent.IsNotFound(err))
+	})
+
+	t.Run("GetPostsByUserHidesDraftsFromOthers", func(t *testing.T) {
+		writer := newUser(t, "Writer")
+		published, err := postService.CreatePost(ctx, model.NewPost{Body: "Published", UserID: writer.ID.String()})
+		require.NoError(t, err)
+		draft, err := postService.CreatePost(ctx, model.NewPost{Body: "Draft", UserID: writer.ID.String(), Draft: true})
+		require.NoError(t, err)
+
+		ids := func(posts []*ent.Post) []uuid.UUID {
+			result := make([]uuid.UUID, len(posts))
+			for i, p := range posts {
+				result[i] = p.ID
+			}
+			return result
+		}
+
+		own, err := postService.GetPostsByUser(ctx, writer.ID, &writer.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{published.ID, draft.ID}, ids(own))
+
+		others, err := postService.GetPostsByUser(ctx, writer.ID, &reader.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{published.ID}, ids(others))
+
+		anonymous, err := postService.GetPostsByUser(ctx, writer.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{published.ID}, ids(anonymous))
+	})
+}
@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/reading"
+	"LinganoGO/ent/readingreview"
+	"LinganoGO/ent/user"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanChangePublicationStatus(t *testing.T) {
+	assert.True(t, services.CanChangePublicationStatus(reading.PublicationStatusPRIVATE, reading.PublicationStatusSUBMITTED))
+	assert.True(t, services.CanChangePublicationStatus(reading.PublicationStatusSUBMITTED, reading.PublicationStatusREJECTED))
+	assert.True(t, services.CanChangePublicationStatus(reading.PublicationStatusAPPROVED, reading.PublicationStatusPUBLISHED))
+	assert.True(t, services.CanChangePublicationStatus(reading.PublicationStatusPUBLISHED, reading.PublicationStatusPRIVATE))
+
+	// Nothing is published without an approval.
+	assert.False(t, services.CanChangePublicationStatus(reading.PublicationStatusPRIVATE, reading.PublicationStatusPUBLISHED))
+	assert.False(t, services.CanChangePublicationStatus(reading.PublicationStatusSUBMITTED, reading.PublicationStatusPUBLISHED))
+	assert.False(t, services.CanChangePublicationStatus(reading.PublicationStatusREJECTED, reading.PublicationStatusPUBLISHED))
+	assert.False(t, services.CanChangePublicationStatus(reading.PublicationStatusPRIVATE, reading.PublicationStatusAPPROVED))
+}
+
+func TestPublicationStatusForPublic(t *testing.T) {
+	assert.Equal(t, reading.PublicationStatusSUBMITTED, services.PublicationStatusForPublic(reading.PublicationStatusPRIVATE, true))
+	assert.Equal(t, reading.PublicationStatusSUBMITTED, services.PublicationStatusForPublic(reading.PublicationStatusREJECTED, true))
+	assert.Equal(t, reading.PublicationStatusPUBLISHED, services.PublicationStatusForPublic(reading.PublicationStatusAPPROVED, true))
+	assert.Equal(t, reading.PublicationStatusPUBLISHED, services.PublicationStatusForPublic(reading.PublicationStatusPUBLISHED, true))
+	assert.Equal(t, reading.PublicationStatusPRIVATE, services.PublicationStatusForPublic(reading.PublicationStatusPUBLISHED, false))
+}
+
+func TestPublicationStatusAfterUpdate(t *testing.T) {
+	public := true
+
+	// Changing published content takes the reading down until it is reviewed
+	// again, whether or not the update also asks for it to stay public.
+	assert.Equal(t, reading.PublicationStatusSUBMITTED, services.PublicationStatusAfterUpdate(reading.PublicationStatusPUBLISHED, nil, true))
+	assert.Equal(t, reading.PublicationStatusSUBMITTED, services.PublicationStatusAfterUpdate(reading.PublicationStatusPUBLISHED, &public, true))
+	assert.True(t, services.CanChangePublicationStatus(reading.PublicationStatusPUBLISHED, reading.PublicationStatusSUBMITTED))
+
+	assert.Equal(t, reading.PublicationStatusSUBMITTED, services.PublicationStatusAfterUpdate(reading.PublicationStatusAPPROVED, nil, true))
+	assert.Equal(t, reading.PublicationStatusPUBLISHED, services.PublicationStatusAfterUpdate(reading.PublicationStatusPUBLISHED, nil, false))
+	assert.Equal(t, reading.PublicationStatusPRIVATE, services.PublicationStatusAfterUpdate(reading.PublicationStatusPRIVATE, nil, true))
+}
+
+func TestBookPublication(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	client := config.GetEntClient()
+	userService := services.NewUserService()
+	publicationService := services.NewPublicationService()
+
+	suffix := uuid.NewString()
+	author, err := userService.CreateUser(ctx, "Author", "book-author-"+suffix+"@test.com", "password123")
+	require.NoError(t, err)
+	admin, err := userService.CreateUser(ctx, "Admin", "book-admin-"+suffix+"@test.com", "password123")
+	require.NoError(t, err)
+	require.NoError(t, client.User.UpdateOneID(admin.ID).SetRole(user.RoleADMIN).Exec(ctx))
+
+	t.Run("CreatingAnEmptyPublicReadingFails", func(t *testing.T) {
+		public := true
+		_, err := services.NewReadingService().CreateReadingWithContent(ctx, model.NewReading{
+			Title:  "Empty " + suffix,
+			UserID: author.ID.String(),
+			Public: &public,
+		})
+		assert.EqualError(t, err, "a reading needs content before it can be submitted")
+	})
+
+	t.Run("ChaptersFollowTheBook", func(t *testing.T) {
+		book, err := client.Reading.Create().SetTitle("Book " + suffix).SetUserID(author.ID).Save(ctx)
+		require.NoError(t, err)
+		for i, body := range []string{"The first chapter.", "The second chapter."} {
+			err := client.Reading.Create().
+				SetTitle(body).
+				SetBody(body).
+				SetUserID(author.ID).
+				SetParentID(book.ID).
+				SetChapterIndex(i).
+				Exec(ctx)
+			require.NoError(t, err)
+		}
+		chapters := func(t *testing.T) []*ent.Reading {
+			t.Helper()
+			chapters, err := client.Reading.Query().Where(reading.ParentID(book.ID)).All(ctx)
+			require.NoError(t, err)
+			require.Len(t, chapters, 2)
+			return chapters
+		}
+
+		_, err = publicationService.Submit(ctx, book.ID, author.ID)
+		require.NoError(t, err, "a book's chapters are its content")
+		for _, chapter := range chapters(t) {
+			assert.Equal(t, reading.PublicationStatusSUBMITTED, chapter.PublicationStatus)
+		}
+
+		_, err = publicationService.Review(ctx, book.ID, admin.ID, readingreview.DecisionAPPROVED, nil)
+		require.NoError(t, err)
+		_, err = publicationService.Publish(ctx, book.ID, author.ID)
+		require.NoError(t, err)
+		for _, chapter := range chapters(t) {
+			assert.Equal(t, reading.PublicationStatusPUBLISHED, chapter.PublicationStatus)
+			assert.True(t, chapter.Public)
+		}
+
+		_, err = publicationService.Unpublish(ctx, book.ID, author.ID, nil)
+		require.NoError(t, err)
+		for _, chapter := range chapters(t) {
+			assert.Equal(t, reading.PublicationStatusPRIVATE, chapter.PublicationStatus)
+			assert.False(t, chapter.Public)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"testing"
+
+	"LinganoGO/ent"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickClozeBlanks(t *testing.T) {
+	sentences := []string{
+		"The cat sat on the mat.",
+		"Yesterday Maria bought a beautiful umbrella.",
+		"I know the cat.",
+	}
+	statuses := map[string]model.WordStatus{
+		"cat":       model.WordStatusLearning,
+		"yesterday": model.WordStatusKnown,
+		"bought":    model.WordStatusIgnored,
+	}
+
+	blanks := services.PickClozeBlanks(sentences, "en", statuses, 5)
+	// The learning word is blanked once only, known, ignored and capitalized
+	// words are skipped and "know" is too common to beat "beautiful".
+	assert.Equal(t, []services.ClozeBlank{
+		{Sentence: 0, Start: 4, End: 7, Word: "cat"},
+		{Sentence: 1, Start: 25, End: 34, Word: "beautiful"},
+		{Sentence: 2, Start: 2, End: 6, Word: "know"},
+	}, blanks)
+
+	blanks = services.PickClozeBlanks(sentences, "en", statuses, 1)
+	assert.Equal(t, []services.ClozeBlank{{Sentence: 0, Start: 4, End: 7, Word: "cat"}}, blanks)
+}
+
+func TestDistractors(t *testing.T) {
+	answer := services.QuizWord{Text: "House", Normalized: "house", Rank: 184, PartOfSpeech: "noun"}
+	pool := []services.QuizWord{
+		{Text: "house", Normalized: "house", Rank: 184, PartOfSpeech: "noun"},
+		{Text: "houses", Normalized: "houses", PartOfSpeech: "noun"},
+		{Text: "run", Normalized: "run", Rank: 249, PartOfSpeech: "verb"},
+		{Text: "the", Normalized: "the", Rank: 1},
+		{Text: "day", Normalized: "day", Rank: 98, PartOfSpeech: "noun"},
+		{Text: "man", Normalized: "man", Rank: 144, PartOfSpeech: "noun"},
+		{Text: "car", Normalized: "car", Rank: 194, PartOfSpeech: "noun"},
+		{Text: "1984", Normalized: "1984"},
+	}
+
+	assert.Equal(t, []string{"Car", "Man", "Day"}, services.Distractors(answer, pool, 3))
+	assert.Empty(t, services.Distractors(answer, pool[:2], 3))
+}
+
+func TestScoreClozeAnswer(t *testing.T) {
+	assert.True(t, services.ScoreClozeAnswer("Beautiful", " beautiful. ", "en"))
+	assert.True(t, services.ScoreClozeAnswer("Straße", "straße", "de"))
+	assert.False(t, services.ScoreClozeAnswer("café", "cafe", "fr"))
+	assert.False(t, services.ScoreClozeAnswer("cat", "", "en"))
+}
+
+func TestQuizStatus(t *testing.T) {
+	assert.Equal(t, 3, services.QuizStatus(2, true))
+	assert.Equal(t, services.KnownStatus, services.QuizStatus(services.KnownStatus, true))
+	assert.Equal(t, 1, services.QuizStatus(2, false))
+	assert.Equal(t, 1, services.QuizStatus(0, false))
+
+	assert.Equal(t, 50.0, services.QuizPercentage(&ent.QuizAttempt{Score: 2, Total: 4}))
+	assert.Equal(t, 0.0, services.QuizPercentage(&ent.QuizAttempt{}))
+}
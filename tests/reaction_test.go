@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"testing"
+
+	"LinganoGO/ent/reaction"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeReactions(t *testing.T) {
+	love := reaction.KindLOVE
+	counts := map[reaction.Kind]int{
+		reaction.KindLIKE:   2,
+		reaction.KindLOVE:   5,
+		reaction.KindTHANKS: 2,
+	}
+
+	assert.Equal(t, []*model.ReactionCount{
+		{Kind: reaction.KindLOVE, Count: 5, ViewerHasReacted: true},
+		{Kind: reaction.KindLIKE, Count: 2},
+		{Kind: reaction.KindTHANKS, Count: 2},
+	}, services.SummarizeReactions(counts, &love))
+
+	assert.Empty(t, services.SummarizeReactions(nil, nil))
+}
+
+func TestParseTarget(t *testing.T) {
+	id := uuid.New()
+	idString := id.String()
+
+	target, err := services.ParseTarget(model.ContentTarget{ReadingID: &idString})
+	assert.NoError(t, err)
+	assert.Equal(t, services.ReadingTarget(id), target)
+
+	_, err = services.ParseTarget(model.ContentTarget{})
+	assert.Error(t, err)
+	_, err = services.ParseTarget(model.ContentTarget{PostID: &idString, CommentID: &idString})
+	assert.Error(t, err)
+
+	invalid := "not-a-uuid"
+	_, err = services.ParseTarget(model.ContentTarget{CommentID: &invalid})
+	assert.Error(t, err)
+}
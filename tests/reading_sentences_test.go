@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"LinganoGO/config"
+	"LinganoGO/ent/readingsentence"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSentencesSegmentsOnce(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	suffix := uuid.NewString()
+	owner, err := services.NewUserService().CreateUser(ctx, "Reader", "sentences-"+suffix+"@test.com", "password123")
+	require.NoError(t, err)
+
+	body, language := "One sentence. Another one. And a third.", "en"
+	reading, err := services.NewReadingService().CreateReadingWithContent(ctx, model.NewReading{
+		Title:    "Sentences " + suffix,
+		UserID:   owner.ID.String(),
+		Body:     &body,
+		Language: &language,
+	})
+	require.NoError(t, err)
+
+	// Every first view races to segment the reading.
+	sentenceService := services.NewSentenceService()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sentences, err := sentenceService.GetSentences(ctx, reading.ID, &owner.ID)
+			assert.NoError(t, err)
+			assert.Len(t, sentences, 3)
+		}()
+	}
+	wg.Wait()
+
+	count, err := config.GetEntClient().ReadingSentence.
+		Query().
+		Where(readingsentence.ReadingID(reading.ID)).
+		Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
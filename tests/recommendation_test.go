@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"LinganoGO/cefr"
+	"LinganoGO/config"
+	"LinganoGO/ent"
+	"LinganoGO/ent/reading"
+	"LinganoGO/graph/model"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelForVocabularySize(t *testing.T) {
+	assert.Equal(t, cefr.A1, services.LevelForVocabularySize(0))
+	assert.Equal(t, cefr.A1, services.LevelForVocabularySize(499))
+	assert.Equal(t, cefr.A2, services.LevelForVocabularySize(500))
+	assert.Equal(t, cefr.B2, services.LevelForVocabularySize(3999))
+	assert.Equal(t, cefr.C2, services.LevelForVocabularySize(20000))
+}
+
+func TestRecommendReadings(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	userService := services.NewUserService()
+	readingService := services.NewReadingService()
+	recommendationService := services.NewRecommendationService()
+
+	// A language of its own keeps other tests' readings out of the ranking.
+	suffix := uuid.NewString()
+	language := "x-" + suffix[:8]
+	learner, err := userService.CreateUser(ctx, "Learner", "learner-"+suffix+"@test.com", "password123")
+	require.NoError(t, err)
+	author, err := userService.CreateUser(ctx, "Author", "recommended-"+suffix+"@test.com", "password123")
+	require.NoError(t, err)
+
+	for _, term := range []string{"hola", "mundo"} {
+		status := services.KnownStatus
+		_, err := services.NewVocabularyService().SaveWord(ctx, model.SaveWordInput{
+			UserID:   learner.ID.String(),
+			Term:     term,
+			Language: language,
+			Status:   &status,
+		})
+		require.NoError(t, err)
+	}
+
+	newReading := func(t *testing.T, owner *ent.User, body string, public bool) *ent.Reading {
+		t.Helper()
+		r, err := readingService.CreateReadingWithContent(ctx, model.NewReading{
+			Title:    body,
+			UserID:   owner.ID.String(),
+			Body:     &body,
+			Language: &language,
+		})
+		require.NoError(t, err)
+		if public {
+			r, err = r.Update().
+				SetPublic(true).
+				SetPublicationStatus(reading.PublicationStatusPUBLISHED).
+				Save(ctx)
+			require.NoError(t, err)
+		}
+		return r
+	}
+
+	known := newReading(t, author, "hola mundo hola", true)
+	mostly := newReading(t, author, "hola mundo gato", true)
+	unknown := newReading(t, author, "gato perro", true)
+	newReading(t, author, "hola mundo", false)
+	newReading(t, learner, "hola hola", true)
+	finished := newReading(t, author, "mundo mundo", true)
+	done := true
+	_, err = services.NewReadingProgressService().RecordProgress(ctx, model.ReadingProgressInput{
+		ReadingID: finished.ID.String(),
+		UserID:    learner.ID.String(),
+		Finished:  &done,
+	})
+	require.NoError(t, err)
+
+	// The parent of an imported book has no body, but counts for the whole book.
+	book := newReading(t, author, "", true)
+	require.NoError(t, book.Update().
+		SetTokenCounts(map[string]int{"hola": 2}).
+		SetWordCount(2).
+		Exec(ctx))
+	chapter := newReading(t, author, "hola hola", true)
+	require.NoError(t, chapter.Update().SetParentID(book.ID).Exec(ctx))
+
+	target := 100.0
+	recommendations, err := recommendationService.RecommendReadings(ctx, learner.ID, language, &target, nil)
+	require.NoError(t, err)
+
+	var ids []uuid.UUID
+	for _, rec := range recommendations {
+		ids = append(ids, rec.Reading.ID)
+	}
+	require.Len(t, ids, 4, "private, own, finished and book readings are left out")
+	assert.ElementsMatch(t, []uuid.UUID{known.ID, chapter.ID}, ids[:2], "fully known readings come first")
+	assert.Equal(t, []uuid.UUID{mostly.ID, unknown.ID}, ids[2:])
+	assert.InDelta(t, 100*2.0/3, recommendations[2].Coverage, 0.01)
+	assert.Equal(t, 2, recommendations[3].NewWordCount)
+}
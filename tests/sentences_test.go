@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"testing"
+
+	"LinganoGO/importer"
+	"LinganoGO/tokenizer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sentenceTexts(sentences []tokenizer.Sentence) []string {
+	texts := make([]string, len(sentences))
+	for i, s := range sentences {
+		texts[i] = s.Text
+	}
+	return texts
+}
+
+func TestSentences(t *testing.T) {
+	text := "Mr. Smith arrived at 3.30 p.m. today. Did he bring J. R. R. Tolkien's book?  \"Yes!\" she said.\n\nA new paragraph without a period\nStill the same one…"
+	sentences := tokenizer.Sentences(text, "en")
+
+	assert.Equal(t, []string{
+		"Mr. Smith arrived at 3.30 p.m. today.",
+		"Did he bring J. R. R. Tolkien's book?",
+		"\"Yes!\" she said.",
+		"A new paragraph without a period\nStill the same one…",
+	}, sentenceTexts(sentences))
+
+	runes := []rune(text)
+	for _, s := range sentences {
+		assert.Equal(t, s.Text, string(runes[s.Start:s.End]))
+	}
+}
+
+func TestSentencesLanguageAbbreviations(t *testing.T) {
+	assert.Equal(t,
+		[]string{"Der Bus kommt z.B. um acht Uhr.", "Dann fahren wir."},
+		sentenceTexts(tokenizer.Sentences("Der Bus kommt z.B. um acht Uhr. Dann fahren wir.", "de-AT")))
+	assert.Equal(t,
+		[]string{"今日は晴れです。", "散歩しましょう！"},
+		sentenceTexts(tokenizer.Sentences("今日は晴れです。散歩しましょう！", "ja")))
+	assert.Empty(t, tokenizer.Sentences("  \n\n ", "en"))
+}
+
+func TestParseTMX(t *testing.T) {
+	tmx := `<?xml version="1.0" encoding="UTF-8"?>
+<tmx version="1.4">
+  <header srclang="en" datatype="plaintext"/>
+  <body>
+    <tu>
+      <tuv xml:lang="en-GB"><seg>Good  <hi>morning</hi>.</seg></tuv>
+      <tuv xml:lang="es"><seg>Buenos <bpt i="1">&lt;b&gt;</bpt>días<ept i="1">&lt;/b&gt;</ept>.</seg></tuv>
+    </tu>
+    <tu>
+      <tuv xml:lang="en"><seg>Only English.</seg></tuv>
+    </tu>
+    <tu>
+      <tuv lang="es"><seg>Hasta luego.</seg></tuv>
+      <tuv lang="en"><seg>See you later.</seg></tuv>
+    </tu>
+  </body>
+</tmx>`
+
+	pairs, err := importer.ParseTMX([]byte(tmx), "", "es")
+	require.NoError(t, err)
+	assert.Equal(t, []importer.SegmentPair{
+		{Source: "Good morning.", Target: "Buenos días."},
+		{Source: "See you later.", Target: "Hasta luego."},
+	}, pairs)
+
+	_, err = importer.ParseTMX([]byte("<tmx><body>"), "en", "es")
+	assert.Error(t, err)
+}
+
+func TestParseTSV(t *testing.T) {
+	tsv := "\xef\xbb\xbfHello there.\tHola.\n" +
+		"no tab on this line\n" +
+		"\tmissing source\n" +
+		"How are you?\t¿Cómo estás?\t0.98\n"
+
+	pairs, err := importer.ParseTSV([]byte(tsv))
+	require.NoError(t, err)
+	assert.Equal(t, []importer.SegmentPair{
+		{Source: "Hello there.", Target: "Hola."},
+		{Source: "How are you?", Target: "¿Cómo estás?"},
+	}, pairs)
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"LinganoGO/textdiff"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinesDiff(t *testing.T) {
+	old := "one\ntwo\nthree\nfour\n"
+	new := "one\n2\nthree\nfour\nfive\n"
+	assert.Equal(t, []textdiff.Chunk{
+		{Kind: textdiff.Equal, Text: "one\n"},
+		{Kind: textdiff.Delete, Text: "two\n"},
+		{Kind: textdiff.Insert, Text: "2\n"},
+		{Kind: textdiff.Equal, Text: "three\nfour\n"},
+		{Kind: textdiff.Insert, Text: "five\n"},
+	}, textdiff.Lines(old, new))
+
+	assert.Equal(t, []textdiff.Chunk{{Kind: textdiff.Equal, Text: old}}, textdiff.Lines(old, old))
+	assert.Empty(t, textdiff.Lines("", ""))
+}
+
+func TestWordsDiff(t *testing.T) {
+	chunks := textdiff.Words("The cat sat on the mat.", "The black cat sat on a mat.", "en")
+	assert.Equal(t, []textdiff.Chunk{
+		{Kind: textdiff.Equal, Text: "The "},
+		{Kind: textdiff.Insert, Text: "black "},
+		{Kind: textdiff.Equal, Text: "cat sat on "},
+		{Kind: textdiff.Delete, Text: "the"},
+		{Kind: textdiff.Insert, Text: "a"},
+		{Kind: textdiff.Equal, Text: " mat."},
+	}, chunks)
+}
+
+func TestDiffRebuildsBothTexts(t *testing.T) {
+	old := strings.Repeat("alpha beta gamma\n", 50) + "delta\n"
+	new := "zeta\n" + strings.Repeat("alpha gamma beta\n", 40) + "delta\nepsilon\n"
+
+	for _, chunks := range [][]textdiff.Chunk{textdiff.Lines(old, new), textdiff.Words(old, new, "en")} {
+		var before, after strings.Builder
+		for _, chunk := range chunks {
+			if chunk.Kind != textdiff.Insert {
+				before.WriteString(chunk.Text)
+			}
+			if chunk.Kind != textdiff.Delete {
+				after.WriteString(chunk.Text)
+			}
+		}
+		assert.Equal(t, old, before.String())
+		assert.Equal(t, new, after.String())
+	}
+
+	// Texts with nothing in common are replaced as a whole.
+	var a, b strings.Builder
+	for i := 0; i < 1500; i++ {
+		a.WriteString("a\n")
+		b.WriteString("b\n")
+	}
+	assert.Equal(t, []textdiff.Chunk{
+		{Kind: textdiff.Delete, Text: a.String()},
+		{Kind: textdiff.Insert, Text: b.String()},
+	}, textdiff.Lines(a.String(), b.String()))
+}
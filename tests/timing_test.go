@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"LinganoGO/importer"
+	"LinganoGO/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlignCues(t *testing.T) {
+	text := "“Don’t go,” she said. The rain had stopped.\n\nIt was late; 今日は晴れ。"
+	cues := []importer.Cue{
+		{Start: 0, End: 1500 * time.Millisecond, Text: "don't go, she said"},
+		{Start: 1500 * time.Millisecond, End: 3 * time.Second, Text: "<i>The rain</i> has stopped."},
+		{Start: 3 * time.Second, End: 4 * time.Second, Text: "It was LATE"},
+		{Start: 4 * time.Second, End: 5 * time.Second, Text: "晴れ"},
+	}
+
+	spans := importer.AlignCues(text, cues)
+	require.Len(t, spans, 3)
+
+	runes := []rune(text)
+	quote := func(s importer.TimedSpan) string { return string(runes[s.Start:s.End]) }
+	assert.Equal(t, "Don’t go,” she said.", quote(spans[0]))
+	assert.Equal(t, "It was late;", quote(spans[1]))
+	assert.Equal(t, 3*time.Second, spans[1].StartTime)
+	assert.Equal(t, "晴れ。", quote(spans[2]))
+}
+
+func TestParseTimestampJSON(t *testing.T) {
+	spans, err := importer.ParseTimestampJSON([]byte(`[
+		{"startTime": 0, "endTime": 2.45, "start": 0, "end": 12},
+		{"startTime": 2.45, "endTime": 4.001, "start": 13, "end": 20}
+	]`))
+	require.NoError(t, err)
+	assert.Equal(t, []importer.TimedSpan{
+		{StartTime: 0, EndTime: 2450 * time.Millisecond, Start: 0, End: 12},
+		{StartTime: 2450 * time.Millisecond, EndTime: 4001 * time.Millisecond, Start: 13, End: 20},
+	}, spans)
+
+	for _, bad := range []string{
+		`{"startTime": 0}`,
+		`[{"startTime": 0, "endTime": 1, "start": 0}]`,
+		`[{"startTime": 2, "endTime": 1, "start": 0, "end": 4}]`,
+		`[{"startTime": 0, "endTime": 1, "start": 4, "end": 4}]`,
+	} {
+		_, err := importer.ParseTimestampJSON([]byte(bad))
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	dir := t.TempDir()
+	store := storage.NewLocalStore(dir, "/media/")
+	ctx := context.Background()
+
+	url, err := store.Save(ctx, "audio/1/track.mp3", strings.NewReader("ID3 data"))
+	require.NoError(t, err)
+	assert.Equal(t, "/media/audio/1/track.mp3", url)
+
+	data, err := os.ReadFile(filepath.Join(dir, "audio", "1", "track.mp3"))
+	require.NoError(t, err)
+	assert.Equal(t, "ID3 data", string(data))
+
+	server := httptest.NewServer(http.StripPrefix("/media", storage.Handler(dir)))
+	defer server.Close()
+	res, err := http.Get(server.URL + url)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res, err = http.Get(server.URL + "/media/audio/1/")
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	for _, key := range []string{"", "../escape.mp3", "audio/../../escape.mp3", "/abs.mp3"} {
+		_, err := store.Save(ctx, key, strings.NewReader("x"))
+		assert.ErrorIs(t, err, storage.ErrInvalidKey, key)
+	}
+
+	require.NoError(t, store.Delete(ctx, "audio/1/track.mp3"))
+	require.NoError(t, store.Delete(ctx, "audio/1/track.mp3"))
+	_, err = os.Stat(filepath.Join(dir, "audio", "1", "track.mp3"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"LinganoGO/tokenizer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func wordTexts(tokens []tokenizer.Token) []string {
+	var words []string
+	for _, t := range tokens {
+		if t.IsWord {
+			words = append(words, t.Text)
+		}
+	}
+	return words
+}
+
+func TestTokenizeRoundTrip(t *testing.T) {
+	text := "Hello, world! It's a well-known fact — 42 times."
+	tokens := tokenizer.Tokenize(text, "en")
+
+	var rebuilt strings.Builder
+	for _, tok := range tokens {
+		rebuilt.WriteString(tok.Text)
+	}
+	assert.Equal(t, text, rebuilt.String())
+	assert.Equal(t, []string{"Hello", "world", "It's", "a", "well-known", "fact", "times"}, wordTexts(tokens))
+}
+
+func TestTokenizeOffsetsAreCharacters(t *testing.T) {
+	tokens := tokenizer.Words("¿Qué tal, señor?", "es")
+
+	assert.Len(t, tokens, 3)
+	assert.Equal(t, "señor", tokens[2].Text)
+	assert.Equal(t, 10, tokens[2].Start)
+	assert.Equal(t, 15, tokens[2].End)
+}
+
+func TestTokenizeFrenchElision(t *testing.T) {
+	words := wordTexts(tokenizer.Tokenize("L'homme qu'il voit aujourd'hui", "fr"))
+
+	assert.Equal(t, []string{"L'", "homme", "qu'", "il", "voit", "aujourd'hui"}, words)
+}
+
+func TestTokenizeJapanese(t *testing.T) {
+	words := wordTexts(tokenizer.Tokenize("日本語をベンキョウする", "ja"))
+
+	assert.Equal(t, []string{"日", "本", "語", "を", "ベンキョウ", "する"}, words)
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "don't", tokenizer.Normalize("Don’t", "en"))
+	assert.Equal(t, "ılık", tokenizer.Normalize("ILIK", "tr"))
+	assert.Equal(t, "straße", tokenizer.Normalize("Straße", "de"))
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"LinganoGO/config"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedTranslator(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	// Cached translations outlive the test, so every run uses new texts.
+	suffix := uuid.NewString()
+	hola, adios := "hola "+suffix, "adiós "+suffix
+
+	t.Run("TranslatesEachTextOnce", func(t *testing.T) {
+		fake := &services.FakeTranslator{}
+		cached := services.NewCachedTranslator(config.GetEntClient(), fake)
+
+		out, err := cached.Translate(ctx, []string{" hola  " + suffix, adios, hola}, "es", "en")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"[en] " + hola, "[en] " + adios, "[en] " + hola}, out)
+		assert.Equal(t, [][]string{{hola, adios}}, fake.Calls())
+
+		out, err = cached.Translate(ctx, []string{adios, hola}, "es", "en")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"[en] " + adios, "[en] " + hola}, out)
+		assert.Len(t, fake.Calls(), 1, "cached texts should not reach the provider")
+
+		_, err = cached.Translate(ctx, []string{hola}, "es", "fr")
+		require.NoError(t, err)
+		assert.Equal(t, []string{hola}, fake.Calls()[1], "the cache is per language pair")
+	})
+
+	t.Run("DoesNotCacheFailures", func(t *testing.T) {
+		text := "perro " + suffix
+		fake := &services.FakeTranslator{Err: errors.New("provider down")}
+		cached := services.NewCachedTranslator(config.GetEntClient(), fake)
+
+		_, err := cached.Translate(ctx, []string{text}, "es", "en")
+		assert.EqualError(t, err, "provider down")
+
+		fake.Err = nil
+		out, err := cached.Translate(ctx, []string{text}, "es", "en")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"[en] " + text}, out)
+		assert.Len(t, fake.Calls(), 2)
+	})
+
+	t.Run("TranslateRequiresAnExistingUser", func(t *testing.T) {
+		t.Setenv("TRANSLATOR", "fake")
+		translationService := services.NewTranslationService()
+
+		_, err := translationService.Translate(ctx, uuid.New(), "hola", "es", "en")
+		assert.EqualError(t, err, "user not found")
+
+		user, err := services.NewUserService().CreateUser(ctx, "Translator", "translator-"+suffix+"@test.com", "password123")
+		require.NoError(t, err)
+		translation, err := translationService.Translate(ctx, user.ID, "hola", "es", "en")
+		require.NoError(t, err)
+		assert.Equal(t, "[en] hola", translation.Translation)
+	})
+
+	t.Run("GlossaryUsesWordsSavedWithoutALanguage", func(t *testing.T) {
+		t.Setenv("TRANSLATOR", "glossary")
+		translationService := services.NewTranslationService()
+		client := config.GetEntClient()
+
+		user, err := services.NewUserService().CreateUser(ctx, "Glossary", "glossary-"+suffix+"@test.com", "password123")
+		require.NoError(t, err)
+		// Words migrated from saved_words have no language.
+		for _, word := range []struct{ term, language, translation string }{
+			{"perro", "", "dog"},
+			{"gato", "", "any cat"},
+			{"gato", "es", "cat"},
+		} {
+			err := client.VocabularyItem.
+				Create().
+				SetUserID(user.ID).
+				SetTerm(word.term).
+				SetNormalizedTerm(word.term).
+				SetLanguage(word.language).
+				SetTranslation(word.translation).
+				Exec(ctx)
+			require.NoError(t, err)
+		}
+
+		translation, err := translationService.Translate(ctx, user.ID, "Perro", "es", "en")
+		require.NoError(t, err)
+		assert.Equal(t, "dog", translation.Translation)
+
+		translation, err = translationService.Translate(ctx, user.ID, "gato", "es", "en")
+		require.NoError(t, err)
+		assert.Equal(t, "cat", translation.Translation, "words saved for the language win")
+	})
+}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"LinganoGO/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// translationStub is a local stand-in for a LibreTranslate-style API that
+// upper-cases its input.
+func translationStub(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Q      []string `json:"q"`
+			Source string   `json:"source"`
+			Target string   `json:"target"`
+			Format string   `json:"format"`
+			APIKey string   `json:"api_key"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "text", req.Format)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.APIKey != "secret":
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Invalid API key"})
+		case req.Target == "xx":
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "xx is not supported"})
+		case req.Target == "short":
+			json.NewEncoder(w).Encode(map[string][]string{"translatedText": {}})
+		default:
+			out := make([]string, len(req.Q))
+			for i, q := range req.Q {
+				out[i] = req.Source + ">" + req.Target + ":" + strings.ToUpper(q)
+			}
+			json.NewEncoder(w).Encode(map[string][]string{"translatedText": out})
+		}
+	}))
+}
+
+func TestHTTPTranslator(t *testing.T) {
+	server := translationStub(t)
+	defer server.Close()
+	ctx := context.Background()
+
+	translator := services.NewHTTPTranslator(server.URL+"/translate", "secret")
+	assert.Equal(t, "http:"+strings.TrimPrefix(server.URL, "http://"), translator.Name())
+
+	out, err := translator.Translate(ctx, []string{"hola", "buenos días"}, "es", "en")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"es>en:HOLA", "es>en:BUENOS DÍAS"}, out)
+
+	out, err = translator.Translate(ctx, nil, "es", "en")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = translator.Translate(ctx, []string{"hola"}, "es", "xx")
+	assert.ErrorContains(t, err, "xx is not supported")
+
+	_, err = translator.Translate(ctx, []string{"hola"}, "es", "short")
+	assert.ErrorContains(t, err, "0 translations for 1 texts")
+
+	_, err = services.NewHTTPTranslator(server.URL, "wrong").Translate(ctx, []string{"hola"}, "es", "en")
+	assert.ErrorContains(t, err, "Invalid API key")
+}
+
+func TestFakeTranslator(t *testing.T) {
+	ctx := context.Background()
+	fake := &services.FakeTranslator{Translations: map[string]string{"gato": "cat"}}
+
+	out, err := fake.Translate(ctx, []string{"gato", "perro"}, "es", "en")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cat", "[en] perro"}, out)
+	assert.Equal(t, [][]string{{"gato", "perro"}}, fake.Calls())
+
+	fake.Err = errors.New("provider down")
+	_, err = fake.Translate(ctx, []string{"gato"}, "es", "en")
+	assert.EqualError(t, err, "provider down")
+	assert.Len(t, fake.Calls(), 2)
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := services.NewRateLimiter(2, 100*time.Millisecond)
+
+	assert.True(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("a"))
+	assert.False(t, limiter.Allow("a"))
+	assert.True(t, limiter.Allow("b"), "keys are limited separately")
+
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, limiter.Allow("a"), "tokens refill over the window")
+	assert.False(t, limiter.Allow("a"))
+}
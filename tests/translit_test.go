@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"testing"
+
+	"LinganoGO/translit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCyrillicToLatin(t *testing.T) {
+	assert.Equal(t, "Moskva", translit.CyrillicToLatin("Москва", "ru"))
+	assert.Equal(t, "Shchi i kasha", translit.CyrillicToLatin("Щи и каша", "ru"))
+	assert.Equal(t, "ZHUK", translit.CyrillicToLatin("ЖУК", "ru"))
+	assert.Equal(t, "Kyiv", translit.CyrillicToLatin("Київ", "uk"))
+	assert.Equal(t, "Sofiya, Balgariya", translit.CyrillicToLatin("София, България", "bg"))
+	assert.Equal(t, "Đurđevdan u Čačku", translit.CyrillicToLatin("Ђурђевдан у Чачку", "sr"))
+
+	assert.Equal(t, "Москва щука", translit.LatinToCyrillic("Moskva shchuka", "ru"))
+	assert.Equal(t, "Љубљана", translit.LatinToCyrillic("Ljubljana", "sr"))
+}
+
+func TestGreekToLatin(t *testing.T) {
+	assert.Equal(t, "Athina", translit.GreekToLatin("Αθήνα"))
+	assert.Equal(t, "efcharisto", translit.GreekToLatin("ευχαριστώ"))
+	assert.Equal(t, "Evropi", translit.GreekToLatin("Ευρώπη"))
+	assert.Equal(t, "banana", translit.GreekToLatin("μπανάνα"))
+	assert.Equal(t, "Angelos", translit.GreekToLatin("Άγγελος"))
+	assert.Equal(t, "proypothesi", translit.GreekToLatin("προϋπόθεση"))
+}
+
+func TestHangulToLatin(t *testing.T) {
+	assert.Equal(t, "hangugeo", translit.HangulToLatin("한국어"))
+	assert.Equal(t, "gungmul", translit.HangulToLatin("국물"))
+	assert.Equal(t, "silla", translit.HangulToLatin("신라"))
+	assert.Equal(t, "joko", translit.HangulToLatin("좋고"))
+	assert.Equal(t, "annyeonghaseyo, seoul", translit.HangulToLatin("안녕하세요, 서울"))
+}
+
+func TestKanaToRomaji(t *testing.T) {
+	assert.Equal(t, "gakkou", translit.KanaToRomaji("がっこう"))
+	assert.Equal(t, "matcha", translit.KanaToRomaji("まっちゃ"))
+	assert.Equal(t, "kōhī", translit.KanaToRomaji("コーヒー"))
+	assert.Equal(t, "shin'you", translit.KanaToRomaji("しんよう"))
+	assert.Equal(t, "カメラ", translit.ToKatakana("かめら"))
+}
+
+func TestFurigana(t *testing.T) {
+	text := "今日は学生と山に行きたい。"
+	runes := []rune(text)
+
+	var got []string
+	for _, span := range translit.Furigana(text) {
+		got = append(got, string(runes[span.Start:span.End])+"="+span.Reading)
+	}
+	// 今日 can only be read as a whole, 学生 is read character by character,
+	// 山 stands alone and 行 is read from its okurigana.
+	assert.Equal(t, []string{"今日=きょう", "学=がく", "生=せい", "山=やま", "行=い"}, got)
+
+	assert.Equal(t, "nippon", translit.Romanize("日本", "ja"))
+	assert.Equal(t, "taberu", translit.Romanize("食べる", "ja"))
+}
+
+func TestPinyin(t *testing.T) {
+	reading, ok := translit.Pinyin('中')
+	assert.True(t, ok)
+	assert.Equal(t, "zhōng", reading)
+
+	assert.Equal(t, "wǒ ài zhōng guó.", translit.Romanize("我爱中国.", "zh-CN"))
+	assert.Empty(t, translit.Annotate("Hello, world", "zh", translit.Latin))
+}
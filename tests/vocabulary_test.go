@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"LinganoGO/config"
+	"LinganoGO/ent/vocabularyitem"
+	"LinganoGO/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTerms(t *testing.T) {
+	err := config.ConnectEntDB()
+	require.NoError(t, err, "Failed to connect to Ent database")
+	defer config.DisconnectEntDB()
+
+	ctx := context.Background()
+	client := config.GetEntClient()
+	user, err := services.NewUserService().CreateUser(ctx, "Migrated", "migrated-"+uuid.NewString()+"@test.com", "password123")
+	require.NoError(t, err)
+
+	// Rows as the saved_words migration wrote them: lowercased in SQL, but
+	// neither NFC normalized nor with Turkish casing.
+	saved, err := client.VocabularyItem.
+		Create().
+		SetUserID(user.ID).
+		SetTerm("café").
+		SetNormalizedTerm("café").
+		SetLanguage("es").
+		SetStatus(1).
+		Save(ctx)
+	require.NoError(t, err)
+	migrated, err := client.VocabularyItem.
+		Create().
+		SetUserID(user.ID).
+		SetTerm("Cafe\u0301").
+		SetNormalizedTerm("cafe\u0301").
+		SetLanguage("es").
+		SetStatus(services.KnownStatus).
+		SetTranslation("coffee").
+		Save(ctx)
+	require.NoError(t, err)
+	_, err = client.VocabularyItem.
+		Create().
+		SetUserID(user.ID).
+		SetTerm("Işık").
+		SetNormalizedTerm("işık").
+		SetLanguage("tr").
+		Save(ctx)
+	require.NoError(t, err)
+
+	_, err = services.NewVocabularyService().NormalizeTerms(ctx)
+	require.NoError(t, err)
+
+	items, err := client.VocabularyItem.
+		Query().
+		Where(vocabularyitem.UserID(user.ID)).
+		All(ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 2, "the migrated duplicate is merged into the saved word")
+
+	for _, item := range items {
+		switch item.Language {
+		case "es":
+			assert.Contains(t, []uuid.UUID{saved.ID, migrated.ID}, item.ID)
+			assert.Equal(t, "café", item.NormalizedTerm)
+			assert.Equal(t, services.KnownStatus, item.Status, "the more advanced status is kept")
+			assert.Equal(t, "coffee", item.Translation)
+		case "tr":
+			assert.Equal(t, "ışık", item.NormalizedTerm)
+		}
+	}
+}
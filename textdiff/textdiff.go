@@ -0,0 +1,230 @@
+// Package textdiff computes the differences between two versions of a text,
+// either line by line or word by word, with the algorithm of Myers' "An
+// O(ND) Difference Algorithm and Its Variations".
+package textdiff
+
+import (
+	"strings"
+
+	"LinganoGO/tokenizer"
+)
+
+// maxEdits bounds the work spent on texts that have little in common. Past
+// that many edits, whatever is left between the common start and end of the
+// texts is reported as deleted and inserted as a whole.
+const maxEdits = 1000
+
+// Kind tells what happened to a chunk of text.
+type Kind int
+
+const (
+	// Equal text is found in both versions.
+	Equal Kind = iota
+	// Insert text is only found in the new version.
+	Insert
+	// Delete text is only found in the old version.
+	Delete
+)
+
+// Chunk is a run of text that was kept, inserted or deleted. Concatenating
+// the Equal and Delete chunks of a diff gives back the old text, and the
+// Equal and Insert chunks the new one. Within a change, deleted text comes
+// before inserted text.
+type Chunk struct {
+	Kind Kind
+	Text string
+}
+
+// Lines compares two texts line by line. Lines keep their line break.
+func Lines(a, b string) []Chunk {
+	return diff(splitLines(a), splitLines(b))
+}
+
+// Words compares two texts word by word, using the tokenizer's rules for
+// language. Spaces and punctuation between words are compared as tokens of
+// their own.
+func Words(a, b, language string) []Chunk {
+	return diff(splitWords(a, language), splitWords(b, language))
+}
+
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func splitWords(text, language string) []string {
+	tokens := tokenizer.Tokenize(text, language)
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Text
+	}
+	return words
+}
+
+func diff(a, b []string) []Chunk {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var c chunker
+	for _, token := range a[:prefix] {
+		c.add(Equal, token)
+	}
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	edits, ok := shortestEdit(middleA, middleB)
+	if !ok {
+		edits = edits[:0]
+		for _, token := range middleA {
+			edits = append(edits, edit{Delete, token})
+		}
+		for _, token := range middleB {
+			edits = append(edits, edit{Insert, token})
+		}
+	}
+	for _, e := range edits {
+		c.add(e.kind, e.token)
+	}
+	for _, token := range a[len(a)-suffix:] {
+		c.add(Equal, token)
+	}
+	return c.finish()
+}
+
+type edit struct {
+	kind  Kind
+	token string
+}
+
+// shortestEdit returns the edits turning a into b, or false when that takes
+// more than maxEdits edits.
+func shortestEdit(a, b []string) ([]edit, bool) {
+	n, m := len(a), len(b)
+	if n+m == 0 {
+		return nil, true
+	}
+
+	// trace[d][k+d] is the furthest x reached on diagonal k = x-y with d
+	// edits.
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		if d > maxEdits {
+			return nil, false
+		}
+		v := make([]int, 2*d+1)
+		for k := -d; k <= d; k += 2 {
+			x := 0
+			if d > 0 {
+				prev := trace[d-1]
+				if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+					x = prev[k+1+d-1]
+				} else {
+					x = prev[k-1+d-1] + 1
+				}
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+d] = x
+			if x >= n && y >= m {
+				trace = append(trace, v)
+				return backtrack(trace, a, b), true
+			}
+		}
+		trace = append(trace, v)
+	}
+	return nil, false
+}
+
+func backtrack(trace [][]int, a, b []string) []edit {
+	var edits []edit
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			edits = append(edits, edit{Equal, a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			edits = append(edits, edit{Insert, b[y-1]})
+			y--
+		} else {
+			edits = append(edits, edit{Delete, a[x-1]})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		edits = append(edits, edit{Equal, a[x-1]})
+		x--
+		y--
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// chunker joins edits into chunks, putting the deletions of a change before
+// its insertions.
+type chunker struct {
+	chunks  []Chunk
+	equal   strings.Builder
+	deleted strings.Builder
+	added   strings.Builder
+}
+
+func (c *chunker) add(kind Kind, token string) {
+	switch kind {
+	case Equal:
+		c.flushChange()
+		c.equal.WriteString(token)
+	case Delete:
+		c.flushEqual()
+		c.deleted.WriteString(token)
+	case Insert:
+		c.flushEqual()
+		c.added.WriteString(token)
+	}
+}
+
+func (c *chunker) flushEqual() {
+	if c.equal.Len() > 0 {
+		c.chunks = append(c.chunks, Chunk{Equal, c.equal.String()})
+		c.equal.Reset()
+	}
+}
+
+func (c *chunker) flushChange() {
+	if c.deleted.Len() > 0 {
+		c.chunks = append(c.chunks, Chunk{Delete, c.deleted.String()})
+		c.deleted.Reset()
+	}
+	if c.added.Len() > 0 {
+		c.chunks = append(c.chunks, Chunk{Insert, c.added.String()})
+		c.added.Reset()
+	}
+}
+
+func (c *chunker) finish() []Chunk {
+	c.flushEqual()
+	c.flushChange()
+	return c.chunks
+}
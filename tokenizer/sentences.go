@@ -0,0 +1,179 @@
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Sentence is a sentence of a text. Start and End are character (rune)
+// offsets into the original text, End being exclusive; leading and trailing
+// whitespace is not part of the sentence.
+type Sentence struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// abbreviations end with a period that doesn't end a sentence, by language.
+// They are compared lowercased and without the period.
+var abbreviations = map[string]map[string]bool{
+	"en": set("mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "vs", "etc", "e.g", "i.e", "no", "fig", "approx", "dept", "inc", "ltd", "co", "mt", "jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "oct", "nov", "dec"),
+	"es": set("sr", "sra", "srta", "dr", "dra", "ud", "uds", "etc", "p.ej", "pág", "núm", "av", "avda", "dña", "d"),
+	"fr": set("m", "mm", "mme", "mlle", "dr", "etc", "p.ex", "av", "bd", "cf", "env", "n°"),
+	"de": set("hr", "fr", "dr", "prof", "bzw", "ca", "usw", "z.b", "d.h", "u.a", "vgl", "nr", "str", "bspw", "ggf"),
+	"it": set("sig", "sigg", "dott", "prof", "ecc", "pag", "avv", "ing"),
+	"pt": set("sr", "sra", "dr", "dra", "etc", "pág", "av", "prof"),
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
+
+// Sentences splits text into sentences. A sentence ends at a terminal
+// punctuation mark (. ! ? … and their full-width forms) followed by
+// whitespace, closing quotes or brackets, or the end of the text, and at
+// every blank line. Periods after known abbreviations, single initials and
+// inside numbers don't end sentences, nor do terminators followed by a
+// lowercase word. Full-width terminators used by Chinese
+// and Japanese end a sentence without a following space.
+func Sentences(text, language string) []Sentence {
+	language = baseLanguage(language)
+	runes := []rune(text)
+
+	var (
+		sentences []Sentence
+		start     int
+	)
+	emit := func(end int) {
+		s, e := start, end
+		for s < e && unicode.IsSpace(runes[s]) {
+			s++
+		}
+		for e > s && unicode.IsSpace(runes[e-1]) {
+			e--
+		}
+		if s < e {
+			sentences = append(sentences, Sentence{Text: string(runes[s:e]), Start: s, End: e})
+		}
+		start = end
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if r == '\n' && blankLineFollows(runes, i) {
+			emit(i)
+			continue
+		}
+		if !isTerminator(r) {
+			continue
+		}
+
+		// Include repeated terminators ("?!", "...") and closing quotes or
+		// brackets in the sentence.
+		end := i + 1
+		for end < len(runes) && (isTerminator(runes[end]) || isCloser(runes[end])) {
+			end++
+		}
+
+		switch {
+		case isFullWidthTerminator(r):
+		case end < len(runes) && !unicode.IsSpace(runes[end]):
+			i = end - 1
+			continue
+		case r == '.' && end == i+1 && isAbbreviation(runes, start, i, language):
+			continue
+		case continuesLowercase(runes, end):
+			// "p.m. today", "\"Yes!\" she said": a lowercase word
+			// carries the sentence on.
+			i = end - 1
+			continue
+		}
+
+		emit(end)
+		i = end - 1
+	}
+	emit(len(runes))
+
+	return sentences
+}
+
+// blankLineFollows reports whether the newline at i starts a blank line.
+func blankLineFollows(runes []rune, i int) bool {
+	for j := i + 1; j < len(runes); j++ {
+		switch {
+		case runes[j] == '\n':
+			return true
+		case !unicode.IsSpace(runes[j]):
+			return false
+		}
+	}
+	return false
+}
+
+// continuesLowercase reports whether the text after i, skipping spaces and
+// opening quotes, goes on with a lowercase letter on the same paragraph.
+func continuesLowercase(runes []rune, i int) bool {
+	for ; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\n' && blankLineFollows(runes, i):
+			return false
+		case unicode.IsSpace(r) || isOpener(r):
+		default:
+			return unicode.IsLower(r)
+		}
+	}
+	return false
+}
+
+// isAbbreviation reports whether the period at i ends an abbreviation or an
+// initial rather than a sentence.
+func isAbbreviation(runes []rune, sentenceStart, i int, language string) bool {
+	wordStart := i
+	for wordStart > sentenceStart && !unicode.IsSpace(runes[wordStart-1]) && !isOpener(runes[wordStart-1]) {
+		wordStart--
+	}
+	word := string(runes[wordStart:i])
+	if word == "" {
+		return false
+	}
+
+	// A single letter is an initial, as in "J. R. R. Tolkien".
+	if letters := []rune(word); len(letters) == 1 && unicode.IsUpper(letters[0]) {
+		return true
+	}
+	return abbreviations[language][strings.ToLower(word)]
+}
+
+func isTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…', '。', '！', '？', '‼', '⁇', '؟', '।':
+		return true
+	}
+	return false
+}
+
+func isFullWidthTerminator(r rune) bool {
+	return r == '。' || r == '！' || r == '？'
+}
+
+func isCloser(r rune) bool {
+	switch r {
+	case '"', '\'', '’', '”', '»', ')', ']', '」', '』', '）':
+		return true
+	}
+	return false
+}
+
+func isOpener(r rune) bool {
+	switch r {
+	case '"', '\'', '‘', '“', '«', '(', '[', '「', '『', '（':
+		return true
+	}
+	return false
+}
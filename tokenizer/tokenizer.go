@@ -0,0 +1,183 @@
+// Package tokenizer splits reading content into words and the text between
+// them, with a few language specific rules for apostrophes and scripts that
+// are written without spaces.
+package tokenizer
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Token is a span of text. Start and End are character (rune) offsets into
+// the original text, End being exclusive.
+type Token struct {
+	Text       string
+	Normalized string
+	Start      int
+	End        int
+	IsWord     bool
+}
+
+// elidingLanguages write articles and pronouns glued to the next word with an
+// apostrophe ("l'homme", "dell'anno"), which should be split into two words.
+var elidingLanguages = map[string]bool{
+	"fr": true,
+	"it": true,
+	"ca": true,
+}
+
+// Tokenize splits text into tokens. Every character of the text belongs to
+// exactly one token, so concatenating the Text of all tokens gives back the
+// input. Runs of characters that are not part of a word are grouped into a
+// single non-word token.
+func Tokenize(text, language string) []Token {
+	language = baseLanguage(language)
+	runes := []rune(text)
+
+	var tokens []Token
+	for i := 0; i < len(runes); {
+		start := i
+		if !isWordRune(runes[i]) {
+			for i < len(runes) && !isWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, newToken(runes, start, i, false, language))
+			continue
+		}
+
+		i = wordEnd(runes, start, language)
+		tokens = append(tokens, newToken(runes, start, i, hasLetter(runes[start:i]), language))
+	}
+
+	return tokens
+}
+
+// Words returns only the word tokens of text.
+func Words(text, language string) []Token {
+	var words []Token
+	for _, t := range Tokenize(text, language) {
+		if t.IsWord {
+			words = append(words, t)
+		}
+	}
+	return words
+}
+
+// Normalize returns the form of a word used to look it up in a learner's
+// vocabulary: NFC normalized, lowercased with the language's casing rules and
+// with typographic apostrophes replaced by ASCII ones.
+func Normalize(word, language string) string {
+	word = norm.NFC.String(strings.TrimSpace(word))
+	word = strings.NewReplacer("’", "'", "ʼ", "'").Replace(word)
+
+	switch baseLanguage(language) {
+	case "tr", "az":
+		return strings.ToLowerSpecial(unicode.TurkishCase, word)
+	default:
+		return strings.ToLower(word)
+	}
+}
+
+// wordEnd returns the exclusive end of the word starting at start.
+func wordEnd(runes []rune, start int, language string) int {
+	// Scripts without spaces between words. Without a dictionary the best
+	// we can do is one token per Han character and one per kana run.
+	if unicode.Is(unicode.Han, runes[start]) {
+		return start + 1
+	}
+	if isKana(runes[start]) {
+		i := start + 1
+		for i < len(runes) && sameKanaScript(runes[start], runes[i]) {
+			i++
+		}
+		return i
+	}
+
+	i := start
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.Is(unicode.Han, r) || isKana(r):
+			return i
+		case isWordRune(r):
+			i++
+		case isJoiner(r) && i+1 < len(runes) && isWordRune(runes[i+1]) && !unicode.Is(unicode.Han, runes[i+1]) && !isKana(runes[i+1]):
+			if isApostrophe(r) && elidingLanguages[language] && isElision(runes[start:i]) {
+				return i + 1
+			}
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func newToken(runes []rune, start, end int, isWord bool, language string) Token {
+	text := string(runes[start:end])
+	t := Token{
+		Text:   text,
+		Start:  start,
+		End:    end,
+		IsWord: isWord,
+	}
+	if isWord {
+		t.Normalized = Normalize(text, language)
+	}
+	return t
+}
+
+// isElision reports whether prefix is a short article or pronoun that is
+// elided before a vowel, such as "l", "d", "qu" or "dell".
+func isElision(prefix []rune) bool {
+	switch strings.ToLower(string(prefix)) {
+	case "qu", "jusqu", "lorsqu", "puisqu", "quoiqu", "dell", "dall", "nell", "sull", "all", "quell", "un", "c", "d", "j", "l", "m", "n", "s", "t":
+		return true
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
+
+func isJoiner(r rune) bool {
+	return isApostrophe(r) || r == '-' || r == '‐' || r == '·'
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == 'ʼ'
+}
+
+func isKana(r rune) bool {
+	return unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || r == 'ー'
+}
+
+// sameKanaScript reports whether r continues a kana run started by first. The
+// prolonged sound mark belongs to either script.
+func sameKanaScript(first, r rune) bool {
+	if r == 'ー' {
+		return true
+	}
+	return unicode.Is(unicode.Hiragana, first) == unicode.Is(unicode.Hiragana, r) && isKana(r)
+}
+
+func hasLetter(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// baseLanguage reduces a language tag such as "fr-CA" to "fr".
+func baseLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return language
+}
@@ -0,0 +1,124 @@
+package translit
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cyrillicLatin is the romanization used for Russian and for any Cyrillic
+// letter a language table does not override. It follows the simplified
+// BGN/PCGN system learners see in textbooks: "щука" is "shchuka", and the
+// hard and soft signs are dropped.
+var cyrillicLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	// Letters of other Cyrillic alphabets, for languages without a table.
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "w", 'ђ': "đ", 'ј': "j",
+	'љ': "lj", 'њ': "nj", 'ћ': "ć", 'џ': "dž", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+}
+
+// cyrillicOverrides are the letters romanized differently in each language,
+// following the national romanization standards (Ukraine 2010, Bulgaria
+// 2009, the Serbian Latin alphabet and Macedonian's official romanization).
+var cyrillicOverrides = map[string]map[rune]string{
+	"uk": {'г': "h", 'и': "y", 'і': "i", 'й': "i", 'х': "kh", 'щ': "shch", 'ь': "", 'є': "ie", 'ї': "i", 'ю': "iu", 'я': "ia"},
+	"be": {'г': "h", 'і': "i", 'ў': "w", 'ы': "y", 'ь': ""},
+	"bg": {'ъ': "a", 'щ': "sht", 'ь': "y", 'ю': "yu", 'я': "ya", 'х': "h", 'ц': "ts"},
+	"sr": {'ж': "ž", 'х': "h", 'ц': "c", 'ч': "č", 'ш': "š", 'ђ': "đ", 'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "ć", 'џ': "dž"},
+	"mk": {'ж': "zh", 'х': "h", 'ц': "c", 'ч': "ch", 'ш': "sh", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz", 'ј': "j", 'љ': "lj", 'њ': "nj", 'џ': "dž"},
+}
+
+// CyrillicToLatin romanizes Cyrillic text written in language. Characters
+// that are not Cyrillic are kept as they are.
+func CyrillicToLatin(text, language string) string {
+	overrides := cyrillicOverrides[baseLanguage(language)]
+	runes := []rune(text)
+
+	var b strings.Builder
+	for i, r := range runes {
+		lower := unicode.ToLower(r)
+		latin, ok := overrides[lower]
+		if !ok {
+			latin, ok = cyrillicLatin[lower]
+		}
+		if !ok {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString(keepCase(latin, runes, i))
+	}
+	return b.String()
+}
+
+// LatinToCyrillic writes romanized text back in Cyrillic, reading the
+// romanization of language the way CyrillicToLatin writes it. Digraphs are
+// matched greedily ("shch" before "sh" before "s"). Letters that were dropped
+// when romanizing (hard and soft signs) cannot be restored.
+func LatinToCyrillic(text, language string) string {
+	table := latinCyrillicTable(baseLanguage(language))
+	runes := []rune(text)
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		matched := false
+		for n := min(longestLatin, len(runes)-i); n > 0; n-- {
+			chunk := strings.ToLower(string(runes[i : i+n]))
+			cyrillic, ok := table[chunk]
+			if !ok {
+				continue
+			}
+			if unicode.IsUpper(runes[i]) {
+				cyrillic = string(unicode.ToUpper([]rune(cyrillic)[0]))
+			}
+			b.WriteString(cyrillic)
+			i += n
+			matched = true
+			break
+		}
+		if !matched {
+			b.WriteRune(runes[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// longestLatin is the length of the longest romanization of a single letter.
+const longestLatin = 4
+
+// alphabets are the letters of each language in alphabetical order. Unknown
+// languages are read as Russian.
+var alphabets = map[string]string{
+	"ru": "абвгдеёжзийклмнопрстуфхцчшщъыьэюя",
+	"uk": "абвгґдеєжзиіїйклмнопрстуфхцчшщьюя",
+	"be": "абвгдеёжзійклмнопрстуўфхцчшыьэюя",
+	"bg": "абвгдежзийклмнопрстуфхцчшщъьюя",
+	"sr": "абвгдђежзијклљмнњопрстћуфхцчџш",
+	"mk": "абвгдѓежзѕијклљмнњопрстќуфхцчџш",
+}
+
+// latinCyrillicTable inverts the romanization of language. When several
+// letters share a romanization ("e" for е and э) the one that comes first in
+// the alphabet wins.
+func latinCyrillicTable(language string) map[string]string {
+	alphabet, ok := alphabets[language]
+	if !ok {
+		alphabet = alphabets["ru"]
+	}
+	overrides := cyrillicOverrides[language]
+
+	table := make(map[string]string)
+	for _, r := range alphabet {
+		latin, ok := overrides[r]
+		if !ok {
+			latin = cyrillicLatin[r]
+		}
+		if _, taken := table[latin]; latin != "" && !taken {
+			table[latin] = string(r)
+		}
+	}
+	return table
+}
@@ -0,0 +1,84 @@
+package translit
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// greekLatin romanizes single Greek letters following ELOT 743, the
+// transcription used on Greek road signs and passports.
+var greekLatin = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// greekDigraphs are the letter pairs ELOT 743 romanizes as a unit.
+var greekDigraphs = map[string]string{
+	"ου": "ou", "γγ": "ng", "γξ": "nx", "γχ": "nch",
+}
+
+// voicelessGreek are the letters before which αυ, ευ and ηυ are pronounced
+// af, ef and if rather than av, ev and iv.
+const voicelessGreek = "θκξπστφχψ"
+
+// GreekToLatin romanizes Greek text. Like ELOT 743 it drops the stress mark
+// ("καλημέρα" is "kalimera"); a diaeresis only matters in that it keeps two
+// vowels from being read as a digraph ("προϋπόθεση" is "proypothesi").
+func GreekToLatin(text string) string {
+	runes := []rune(norm.NFD.String(text))
+
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		lower := unicode.ToLower(r)
+		if _, ok := greekLatin[lower]; !ok {
+			b.WriteRune(r)
+			i++
+			continue
+		}
+
+		letter, marks := i, i+1
+		for marks < len(runes) && unicode.Is(unicode.Mn, runes[marks]) {
+			marks++
+		}
+		next, nextEnd := rune(0), marks
+		if marks < len(runes) {
+			next = unicode.ToLower(runes[marks])
+			for nextEnd = marks + 1; nextEnd < len(runes) && unicode.Is(unicode.Mn, runes[nextEnd]); nextEnd++ {
+			}
+		}
+		nextMarks := string(runes[min(marks+1, len(runes)):nextEnd])
+		// A diaeresis on the second letter means the pair is not a digraph.
+		splitPair := strings.ContainsRune(nextMarks, '̈')
+
+		latin, consumed := greekLatin[lower], marks
+		switch {
+		case !splitPair && greekDigraphs[string([]rune{lower, next})] != "":
+			latin, consumed = greekDigraphs[string([]rune{lower, next})], nextEnd
+		case !splitPair && next == 'υ' && (lower == 'α' || lower == 'ε' || lower == 'η'):
+			after := rune(0)
+			if nextEnd < len(runes) {
+				after = unicode.ToLower(runes[nextEnd])
+			}
+			v := "v"
+			if after == 0 || strings.ContainsRune(voicelessGreek, after) || !unicode.IsLetter(after) {
+				v = "f"
+			}
+			latin, consumed = greekLatin[lower]+v, nextEnd
+		case (lower == 'μ' && next == 'π') || (lower == 'ν' && next == 'τ'):
+			// μπ and ντ are b and d at the start of a word, mb and nd inside.
+			if letter == 0 || !unicode.IsLetter(runes[letter-1]) {
+				latin = map[rune]string{'μ': "b", 'ν': "d"}[lower]
+				consumed = nextEnd
+			}
+		}
+
+		b.WriteString(keepCase(latin, runes, letter))
+		i = consumed
+	}
+	return norm.NFC.String(b.String())
+}
@@ -0,0 +1,123 @@
+package translit
+
+import "strings"
+
+const (
+	hangulBase  = 0xAC00
+	hangulLast  = 0xD7A3
+	medialCount = 21
+	finalCount  = 28
+)
+
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulMedials  = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	// hangulFinals are the finals as pronounced before a consonant or at
+	// the end of a word, where only seven sounds remain.
+	hangulFinals = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+	// hangulLiaison splits each final into what stays and what moves to the
+	// next syllable when that one starts with a silent ㅇ: 음악 is eumak.
+	hangulLiaison = [][2]string{
+		{"", ""}, {"", "g"}, {"", "kk"}, {"k", "s"}, {"", "n"}, {"n", "j"}, {"", "n"}, {"", "d"},
+		{"", "r"}, {"l", "g"}, {"l", "m"}, {"l", "b"}, {"l", "s"}, {"l", "t"}, {"l", "p"}, {"", "r"},
+		{"", "m"}, {"", "b"}, {"p", "s"}, {"", "s"}, {"", "ss"}, {"ng", ""}, {"", "j"}, {"", "ch"},
+		{"", "k"}, {"", "t"}, {"", "p"}, {"", ""},
+	}
+)
+
+// Initial consonants that trigger sound changes, as indexes in hangulInitials.
+const (
+	initialG    = 0
+	initialN    = 2
+	initialD    = 3
+	initialR    = 5
+	initialM    = 6
+	initialJ    = 12
+	initialNone = 11
+)
+
+// Finals written with ㅎ, as indexes in hangulFinals.
+const (
+	finalH  = 27
+	finalNH = 6
+	finalLH = 15
+)
+
+// HangulToLatin romanizes Korean with the Revised Romanization of Korean. It
+// writes what is pronounced rather than letter by letter, applying the
+// changes the system transcribes: liaison (음악 eumak), nasalization (국물
+// gungmul), the ㄴ/ㄹ assimilations (신라 Silla) and aspiration (좋고 joko).
+// Characters other than Hangul syllables are kept as they are.
+func HangulToLatin(text string) string {
+	runes := []rune(text)
+
+	var b strings.Builder
+	// carried is the initial of the current syllable as changed by the final
+	// of the previous one; hasCarried is false when it was not changed.
+	carried, hasCarried := "", false
+	for i, r := range runes {
+		if r < hangulBase || r > hangulLast {
+			b.WriteRune(r)
+			hasCarried = false
+			continue
+		}
+		s := int(r - hangulBase)
+		initial, medial, final := s/(medialCount*finalCount), (s/finalCount)%medialCount, s%finalCount
+
+		start := hangulInitials[initial]
+		if hasCarried {
+			start = carried
+		}
+		hasCarried = false
+		end := hangulFinals[final]
+
+		if i+1 < len(runes) && runes[i+1] >= hangulBase && runes[i+1] <= hangulLast && final != 0 {
+			next := int(runes[i+1]-hangulBase) / (medialCount * finalCount)
+			end, carried, hasCarried = assimilate(final, next)
+		}
+
+		b.WriteString(start + hangulMedials[medial] + end)
+	}
+	return b.String()
+}
+
+// assimilate returns how a final and the initial of the next syllable are
+// written once the sound changes between them are applied.
+func assimilate(final, next int) (end, initial string, changed bool) {
+	end = hangulFinals[final]
+	switch {
+	case next == initialNone:
+		end, initial = hangulLiaison[final][0], hangulLiaison[final][1]
+		return end, initial, true
+	case (final == finalH || final == finalNH || final == finalLH) && (next == initialG || next == initialD || next == initialJ):
+		// The ㅎ merges into the next consonant, which becomes aspirated.
+		aspirated := map[int]string{initialG: "k", initialD: "t", initialJ: "ch"}[next]
+		return map[int]string{finalH: "", finalNH: "n", finalLH: "l"}[final], aspirated, true
+	case next == initialN || next == initialM:
+		switch end {
+		case "k":
+			end = "ng"
+		case "t":
+			end = "n"
+		case "p":
+			end = "m"
+		case "l":
+			if next == initialN {
+				return "l", "l", true
+			}
+		}
+		return end, hangulInitials[next], true
+	case next == initialR:
+		switch end {
+		case "n", "l":
+			return "l", "l", true
+		case "k":
+			return "ng", "n", true
+		case "p":
+			return "m", "n", true
+		case "m", "ng":
+			return end, "n", true
+		}
+	}
+	return end, "", false
+}
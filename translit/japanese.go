@@ -0,0 +1,108 @@
+package translit
+
+import (
+	"strings"
+	"sync"
+)
+
+// kanjiReadings is the table built by scripts/gen_translit_data.go from
+// IPADIC, the dictionary behind most Japanese morphological analyzers.
+type kanjiReadings struct {
+	// alone is the reading of a kanji standing on its own (usually kun'yomi).
+	alone map[rune]string
+	// inCompound is its most common reading next to other kanji (usually
+	// on'yomi).
+	inCompound map[rune]string
+	// okurigana maps a kanji and the kana following it to the kanji's
+	// reading: "食べ" is た.
+	okurigana map[string]string
+	// compounds are the words that are not read character by character. The
+	// readings are per character, or a single reading for the whole word.
+	compounds map[string][]string
+	// longestCompound is the length in characters of the longest compound.
+	longestCompound int
+}
+
+var (
+	loadKanji sync.Once
+	kanji     *kanjiReadings
+)
+
+func kanjiTable() *kanjiReadings {
+	loadKanji.Do(func() {
+		kanji = &kanjiReadings{
+			alone:      make(map[rune]string),
+			inCompound: make(map[rune]string),
+			okurigana:  make(map[string]string),
+			compounds:  make(map[string][]string),
+		}
+		readData("data/kanji.txt.gz", func(fields []string) {
+			switch {
+			case fields[0] == "K" && len(fields) == 4:
+				r := []rune(fields[1])[0]
+				kanji.alone[r] = fields[2]
+				kanji.inCompound[r] = fields[3]
+			case fields[0] == "O" && len(fields) == 3:
+				kanji.okurigana[fields[1]] = fields[2]
+			case fields[0] == "C" && len(fields) == 3:
+				kanji.compounds[fields[1]] = strings.Split(fields[2], "|")
+				kanji.longestCompound = max(kanji.longestCompound, len([]rune(fields[1])))
+			}
+		})
+	})
+	return kanji
+}
+
+// Furigana returns the hiragana reading of every kanji in text, as spans over
+// the kanji. Known compounds are looked up as a whole so that 今日 reads
+// きょう rather than いまにち; when a compound can only be read as a whole,
+// the span covers all of it. Kanji without a known reading are left out.
+func Furigana(text string) []Span {
+	return Annotate(text, "ja", Kana)
+}
+
+// kanjiSpans reads a run of kanji followed by the text in following.
+func kanjiSpans(run []rune, following []rune) []Span {
+	table := kanjiTable()
+
+	var spans []Span
+	for i := 0; i < len(run); {
+		matched := false
+		for n := min(table.longestCompound, len(run)-i); n > 1; n-- {
+			readings, ok := table.compounds[string(run[i:i+n])]
+			if !ok {
+				continue
+			}
+			if len(readings) == n {
+				for j, reading := range readings {
+					spans = append(spans, Span{Start: i + j, End: i + j + 1, Reading: reading})
+				}
+			} else {
+				spans = append(spans, Span{Start: i, End: i + n, Reading: strings.Join(readings, "")})
+			}
+			i += n
+			matched = true
+			break
+		}
+		if matched {
+			continue
+		}
+
+		r := run[i]
+		reading := ""
+		if i == len(run)-1 && len(following) > 0 && isHiragana(following[0]) {
+			reading = table.okurigana[string([]rune{r, following[0]})]
+		}
+		if reading == "" && len(run) == 1 {
+			reading = table.alone[r]
+		}
+		if reading == "" {
+			reading = table.inCompound[r]
+		}
+		if reading != "" {
+			spans = append(spans, Span{Start: i, End: i + 1, Reading: reading})
+		}
+		i++
+	}
+	return spans
+}
@@ -0,0 +1,145 @@
+package translit
+
+import (
+	"strings"
+	"unicode"
+)
+
+// kanaRomaji is the Hepburn romanization of hiragana syllables, including the
+// small ゃ/ゅ/ょ combinations. Katakana is converted to hiragana first.
+var kanaRomaji = map[string]string{
+	"あ": "a", "い": "i", "う": "u", "え": "e", "お": "o",
+	"か": "ka", "き": "ki", "く": "ku", "け": "ke", "こ": "ko",
+	"さ": "sa", "し": "shi", "す": "su", "せ": "se", "そ": "so",
+	"た": "ta", "ち": "chi", "つ": "tsu", "て": "te", "と": "to",
+	"な": "na", "に": "ni", "ぬ": "nu", "ね": "ne", "の": "no",
+	"は": "ha", "ひ": "hi", "ふ": "fu", "へ": "he", "ほ": "ho",
+	"ま": "ma", "み": "mi", "む": "mu", "め": "me", "も": "mo",
+	"や": "ya", "ゆ": "yu", "よ": "yo",
+	"ら": "ra", "り": "ri", "る": "ru", "れ": "re", "ろ": "ro",
+	"わ": "wa", "ゐ": "i", "ゑ": "e", "を": "o", "ん": "n",
+	"が": "ga", "ぎ": "gi", "ぐ": "gu", "げ": "ge", "ご": "go",
+	"ざ": "za", "じ": "ji", "ず": "zu", "ぜ": "ze", "ぞ": "zo",
+	"だ": "da", "ぢ": "ji", "づ": "zu", "で": "de", "ど": "do",
+	"ば": "ba", "び": "bi", "ぶ": "bu", "べ": "be", "ぼ": "bo",
+	"ぱ": "pa", "ぴ": "pi", "ぷ": "pu", "ぺ": "pe", "ぽ": "po",
+	"ゔ": "vu",
+	"ぁ": "a", "ぃ": "i", "ぅ": "u", "ぇ": "e", "ぉ": "o",
+	"ゃ": "ya", "ゅ": "yu", "ょ": "yo", "ゎ": "wa",
+	"きゃ": "kya", "きゅ": "kyu", "きょ": "kyo",
+	"しゃ": "sha", "しゅ": "shu", "しょ": "sho", "しぇ": "she",
+	"ちゃ": "cha", "ちゅ": "chu", "ちょ": "cho", "ちぇ": "che",
+	"にゃ": "nya", "にゅ": "nyu", "にょ": "nyo",
+	"ひゃ": "hya", "ひゅ": "hyu", "ひょ": "hyo",
+	"みゃ": "mya", "みゅ": "myu", "みょ": "myo",
+	"りゃ": "rya", "りゅ": "ryu", "りょ": "ryo",
+	"ぎゃ": "gya", "ぎゅ": "gyu", "ぎょ": "gyo",
+	"じゃ": "ja", "じゅ": "ju", "じょ": "jo", "じぇ": "je",
+	"ぢゃ": "ja", "ぢゅ": "ju", "ぢょ": "jo",
+	"びゃ": "bya", "びゅ": "byu", "びょ": "byo",
+	"ぴゃ": "pya", "ぴゅ": "pyu", "ぴょ": "pyo",
+	"ふぁ": "fa", "ふぃ": "fi", "ふぇ": "fe", "ふぉ": "fo",
+	"てぃ": "ti", "でぃ": "di", "とぅ": "tu", "どぅ": "du",
+	"うぃ": "wi", "うぇ": "we", "うぉ": "wo",
+	"ゔぁ": "va", "ゔぃ": "vi", "ゔぇ": "ve", "ゔぉ": "vo",
+	"つぁ": "tsa", "つぃ": "tsi", "つぇ": "tse", "つぉ": "tso",
+}
+
+// longVowels are the macron forms used for the katakana long vowel mark.
+var longVowels = map[byte]string{'a': "ā", 'i': "ī", 'u': "ū", 'e': "ē", 'o': "ō"}
+
+// KanaToRomaji romanizes hiragana and katakana with the Hepburn system: っ
+// doubles the next consonant ("がっこう" is "gakkou"), ん is written n' before
+// a vowel or y, and the katakana long vowel mark becomes a macron ("コーヒー"
+// is "kōhī"). Other characters are kept as they are.
+func KanaToRomaji(text string) string {
+	runes := []rune(ToHiragana(text))
+
+	var b strings.Builder
+	geminate := false
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == 'っ':
+			geminate = true
+			i++
+			continue
+		case r == 'ー':
+			out := b.String()
+			if vowel, ok := longVowels[lastByte(out)]; ok {
+				b.Reset()
+				b.WriteString(out[:len(out)-1] + vowel)
+			}
+			i++
+			continue
+		}
+
+		syllable, n := "", 0
+		if i+1 < len(runes) {
+			syllable = kanaRomaji[string(runes[i:i+2])]
+			n = 2
+		}
+		if syllable == "" {
+			syllable, n = kanaRomaji[string(r)], 1
+		}
+		if syllable == "" {
+			if geminate {
+				b.WriteRune('っ')
+			}
+			geminate = false
+			b.WriteRune(r)
+			i++
+			continue
+		}
+
+		if r == 'ん' && i+1 < len(runes) && strings.ContainsRune("あいうえおやゆよ", runes[i+1]) {
+			syllable = "n'"
+		}
+		if geminate {
+			if strings.HasPrefix(syllable, "ch") {
+				b.WriteByte('t')
+			} else if !strings.ContainsRune("aeiou", rune(syllable[0])) {
+				b.WriteByte(syllable[0])
+			}
+			geminate = false
+		}
+		b.WriteString(syllable)
+		i += n
+	}
+	return b.String()
+}
+
+func lastByte(s string) byte {
+	if s == "" {
+		return 0
+	}
+	return s[len(s)-1]
+}
+
+// ToHiragana converts katakana to hiragana, leaving everything else alone.
+func ToHiragana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 0x60
+		}
+		return r
+	}, text)
+}
+
+// ToKatakana converts hiragana to katakana, leaving everything else alone.
+func ToKatakana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + 0x60
+		}
+		return r
+	}, text)
+}
+
+func isHiragana(r rune) bool {
+	return unicode.Is(unicode.Hiragana, r)
+}
+
+func isKatakana(r rune) bool {
+	return unicode.Is(unicode.Katakana, r) || r == 'ー'
+}
@@ -0,0 +1,44 @@
+package translit
+
+import "sync"
+
+var (
+	loadPinyin sync.Once
+	pinyin     map[rune]string
+)
+
+// pinyinTable maps Chinese characters to their most common reading, from
+// the table built by scripts/gen_translit_data.go.
+func pinyinTable() map[rune]string {
+	loadPinyin.Do(func() {
+		pinyin = make(map[rune]string)
+		readData("data/pinyin.txt.gz", func(fields []string) {
+			if len(fields) != 2 {
+				return
+			}
+			for _, r := range fields[1] {
+				pinyin[r] = fields[0]
+			}
+		})
+	})
+	return pinyin
+}
+
+// Pinyin returns the tone-marked pinyin of a Chinese character and whether
+// it is known. Characters with several readings get the most common one:
+// there is no word list to tell 银行 (yínháng) from 行走 (xíngzǒu).
+func Pinyin(r rune) (string, bool) {
+	reading, ok := pinyinTable()[r]
+	return reading, ok
+}
+
+// pinyinSpans gives every character of runes[start:end] its own span.
+func pinyinSpans(runes []rune, start, end int) []Span {
+	var spans []Span
+	for i := start; i < end; i++ {
+		if reading, ok := Pinyin(runes[i]); ok {
+			spans = append(spans, Span{Start: i, End: i + 1, Reading: reading})
+		}
+	}
+	return spans
+}
@@ -0,0 +1,227 @@
+// Package translit writes text in non-Latin scripts in a way a learner can
+// read aloud: Cyrillic and Greek are romanized letter by letter, Hangul
+// follows the Revised Romanization of Korean, Chinese characters get their
+// pinyin and Japanese kanji their kana reading (furigana), both looked up in
+// dictionaries embedded in the binary.
+package translit
+
+import (
+	"bufio"
+	"compress/gzip"
+	"embed"
+	"strings"
+	"unicode"
+)
+
+//go:embed data/*.gz
+var dataFS embed.FS
+
+// Style selects what kind of reading aid Annotate produces.
+type Style int
+
+const (
+	// Kana gives Japanese kanji their reading in hiragana and leaves kana
+	// alone. Every other script is romanized.
+	Kana Style = iota
+	// Latin romanizes everything, Japanese kana included (Hepburn).
+	Latin
+)
+
+// Span is the reading of part of a text. Start and End are character (rune)
+// offsets, End being exclusive, like the offsets of tokenizer.Token.
+type Span struct {
+	Start   int
+	End     int
+	Reading string
+}
+
+// Annotate returns the readings of the parts of text written in a script
+// this package knows, in order. Parts that need no reading aid (Latin
+// letters, digits, punctuation and, with the Kana style, Japanese kana) are
+// left out. Spans never cross a change of script, so they line up with the
+// tokenizer's words.
+func Annotate(text, language string, style Style) []Span {
+	language = baseLanguage(language)
+	runes := []rune(text)
+
+	var spans []Span
+	for i := 0; i < len(runes); {
+		s := scriptOf(runes[i], language)
+		if s == scriptNone {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && scriptOf(runes[i], language) == s {
+			i++
+		}
+		spans = append(spans, annotateRun(runes, start, i, s, language, style)...)
+	}
+	return spans
+}
+
+// Romanize writes text in Latin letters. Characters it has no reading for
+// are kept as they are. Chinese syllables are separated by spaces.
+func Romanize(text, language string) string {
+	spans := Annotate(text, language, Latin)
+	runes := []rune(text)
+	chinese := baseLanguage(language) != "ja"
+
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		if span.Start < last {
+			continue
+		}
+		if chinese && last > 0 && last == span.Start && isHan(runes[last-1]) && isHan(runes[span.Start]) {
+			b.WriteByte(' ')
+		}
+		b.WriteString(string(runes[last:span.Start]))
+		b.WriteString(span.Reading)
+		last = span.End
+	}
+	b.WriteString(string(runes[last:]))
+	return b.String()
+}
+
+type script int
+
+const (
+	scriptNone script = iota
+	scriptCyrillic
+	scriptGreek
+	scriptHangul
+	scriptHan
+	scriptKanji
+	scriptHiragana
+	scriptKatakana
+)
+
+func scriptOf(r rune, language string) script {
+	switch {
+	case unicode.Is(unicode.Cyrillic, r):
+		return scriptCyrillic
+	case unicode.Is(unicode.Greek, r):
+		return scriptGreek
+	case unicode.Is(unicode.Hangul, r):
+		return scriptHangul
+	case isHan(r):
+		if language == "ja" {
+			return scriptKanji
+		}
+		return scriptHan
+	case language == "ja" && isHiragana(r):
+		return scriptHiragana
+	case language == "ja" && isKatakana(r):
+		return scriptKatakana
+	}
+	return scriptNone
+}
+
+func annotateRun(runes []rune, start, end int, s script, language string, style Style) []Span {
+	text := string(runes[start:end])
+	switch s {
+	case scriptCyrillic:
+		return []Span{{start, end, CyrillicToLatin(text, language)}}
+	case scriptGreek:
+		return []Span{{start, end, GreekToLatin(text)}}
+	case scriptHangul:
+		return []Span{{start, end, HangulToLatin(text)}}
+	case scriptHan:
+		return pinyinSpans(runes, start, end)
+	case scriptKanji:
+		var following []rune
+		if end < len(runes) {
+			following = runes[end:]
+		}
+		spans := kanjiSpans(runes[start:end], following)
+		for i := range spans {
+			spans[i].Start += start
+			spans[i].End += start
+		}
+		if style == Latin {
+			for i := range spans {
+				next := ""
+				if i+1 < len(spans) {
+					next = spans[i+1].Reading
+				} else if len(following) > 0 {
+					next = string(following[0])
+				}
+				spans[i].Reading = romajiBefore(spans[i].Reading, next)
+			}
+		}
+		return spans
+	case scriptHiragana, scriptKatakana:
+		if style == Latin {
+			return []Span{{start, end, KanaToRomaji(text)}}
+		}
+	}
+	return nil
+}
+
+// romajiBefore romanizes the kana reading of a kanji followed by next, so
+// that a final っ doubles the consonant that comes after it: 日本 read にっ
+// and ぽん is "nip" and "pon".
+func romajiBefore(reading, next string) string {
+	if !strings.HasSuffix(reading, "っ") || next == "" {
+		return KanaToRomaji(reading)
+	}
+	first := string([]rune(next)[0])
+	return strings.TrimSuffix(KanaToRomaji(reading+first), KanaToRomaji(first))
+}
+
+func isHan(r rune) bool {
+	return unicode.Is(unicode.Han, r) || r == '々'
+}
+
+// baseLanguage returns the primary subtag of a language tag ("zh-TW" -> "zh").
+func baseLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return language
+}
+
+// keepCase applies the case of the source letter to its transliteration:
+// "Ж" becomes "Zh", or "ZH" when the letters around it are capitals too.
+func keepCase(latin string, source []rune, i int) string {
+	if !unicode.IsUpper(source[i]) {
+		return latin
+	}
+	upperNeighbour := (i+1 < len(source) && unicode.IsUpper(source[i+1])) ||
+		(i > 0 && unicode.IsUpper(source[i-1]) && (i+1 == len(source) || !unicode.IsLetter(source[i+1])))
+	if upperNeighbour {
+		return strings.ToUpper(latin)
+	}
+	r := []rune(latin)
+	if len(r) == 0 {
+		return latin
+	}
+	return string(unicode.ToUpper(r[0])) + string(r[1:])
+}
+
+// readData calls fn with the tab separated fields of every line of an
+// embedded, gzipped data file, skipping comments.
+func readData(name string, fn func(fields []string)) {
+	f, err := dataFS.Open(name)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fn(strings.Split(line, "\t"))
+	}
+}